@@ -11,8 +11,8 @@ func main() {
 
 	var bbox ows.BoundingBox
 	bbox.Crs = `EPSG:4326`
-	bbox.LowerCorner = [2]float64{-180.0, -90.0}
-	bbox.UpperCorner = [2]float64{180.0, 90.0}
+	bbox.LowerCorner = ows.Position{-180.0, -90.0}
+	bbox.UpperCorner = ows.Position{180.0, 90.0}
 
 	xml, _ := xml.MarshalIndent(bbox, "", " ")
 
@@ -0,0 +1,103 @@
+package ows
+
+import (
+	"strings"
+	"sync"
+)
+
+// AxisOrder is the order of the horizontal axes of a CRS as defined by its authority
+type AxisOrder int
+
+// Axis orders, a CRS that isn't registered has the EastingNorthing (x y) order
+const (
+	EastingNorthing AxisOrder = iota
+	NorthingEasting
+)
+
+var (
+	axisOrderMutex sync.RWMutex
+	// axisOrders are the CRSs with a northing easting (lat lon) axis order
+	axisOrders = map[CRS]AxisOrder{
+		{Namespace: EPSG, Code: `4326`}:  NorthingEasting, // WGS 84
+		{Namespace: EPSG, Code: `4258`}:  NorthingEasting, // ETRS89
+		{Namespace: EPSG, Code: `4979`}:  NorthingEasting, // WGS 84 (3D)
+		{Namespace: EPSG, Code: `4937`}:  NorthingEasting, // ETRS89 (3D)
+		{Namespace: EPSG, Code: `9286`}:  NorthingEasting, // ETRS89 + NAP height
+		{Namespace: EPSG, Code: `4289`}:  NorthingEasting, // Amersfoort
+		{Namespace: EPSG, Code: `4171`}:  NorthingEasting, // RGF93
+		{Namespace: EPSG, Code: `4230`}:  NorthingEasting, // ED50
+		{Namespace: EPSG, Code: `4314`}:  NorthingEasting, // DHDN
+		{Namespace: EPSG, Code: `4267`}:  NorthingEasting, // NAD27
+		{Namespace: EPSG, Code: `4269`}:  NorthingEasting, // NAD83
+		{Namespace: EPSG, Code: `4283`}:  NorthingEasting, // GDA94
+		{Namespace: EPSG, Code: `7844`}:  NorthingEasting, // GDA2020
+		{Namespace: EPSG, Code: `3034`}:  NorthingEasting, // ETRS89-extended / LCC Europe
+		{Namespace: EPSG, Code: `3035`}:  NorthingEasting, // ETRS89-extended / LAEA Europe
+		{Namespace: EPSG, Code: `3006`}:  NorthingEasting, // SWEREF99 TM
+		{Namespace: EPSG, Code: `2180`}:  NorthingEasting, // ETRF2000-PL / CS92
+		{Namespace: EPSG, Code: `31466`}: NorthingEasting, // DHDN / 3-degree Gauss-Kruger zone 2
+		{Namespace: EPSG, Code: `31467`}: NorthingEasting, // DHDN / 3-degree Gauss-Kruger zone 3
+		{Namespace: EPSG, Code: `31468`}: NorthingEasting, // DHDN / 3-degree Gauss-Kruger zone 4
+		{Namespace: EPSG, Code: `31469`}: NorthingEasting, // DHDN / 3-degree Gauss-Kruger zone 5
+	}
+)
+
+// RegisterAxisOrder sets the axis order of the CRS
+// It adds a CRS that isn't known or overrules the axis order of a known CRS
+func RegisterAxisOrder(crs CRS, order AxisOrder) {
+	axisOrderMutex.Lock()
+	defer axisOrderMutex.Unlock()
+
+	axisOrders[crs] = order
+}
+
+// AxisOrder returns the axis order of the CRS as defined by its authority
+func (c *CRS) AxisOrder() AxisOrder {
+	axisOrderMutex.RLock()
+	defer axisOrderMutex.RUnlock()
+
+	if order, ok := axisOrders[*c]; ok {
+		return order
+	}
+	return EastingNorthing
+}
+
+// ToXY returns the Position, given in the axis order of the CRS, in x y order
+// Only the horizontal axes are swapped, the other coordinates like the height keep their place
+func (p Position) ToXY(crs CRS) Position {
+	if crs.AxisOrder() == NorthingEasting && len(p) >= 2 {
+		return append(Position{p[1], p[0]}, p[2:]...)
+	}
+	return p
+}
+
+// ToAuthority returns the Position, given in x y order, in the axis order of the CRS
+func (p Position) ToAuthority(crs CRS) Position {
+	// swapping the axes is its own inverse
+	return p.ToXY(crs)
+}
+
+// ToXY returns the BoundingBox, given in the axis order of the CRS, in x y order
+func (b BoundingBox) ToXY(crs CRS) BoundingBox {
+	b.LowerCorner = b.LowerCorner.ToXY(crs)
+	b.UpperCorner = b.UpperCorner.ToXY(crs)
+	return b
+}
+
+// ToAuthority returns the BoundingBox, given in x y order, in the axis order of the CRS
+func (b BoundingBox) ToAuthority(crs CRS) BoundingBox {
+	b.LowerCorner = b.LowerCorner.ToAuthority(crs)
+	b.UpperCorner = b.UpperCorner.ToAuthority(crs)
+	return b
+}
+
+// crs returns the crs attribute of the BoundingBox as CRS
+// The GML 2 form, like http://www.opengis.net/gml/srs/epsg.xml#4326, is always in x y order so it's returned as empty CRS
+func (b BoundingBox) crs() CRS {
+	if strings.HasPrefix(strings.ToLower(b.Crs), crsLegacyURIPrefix) {
+		return CRS{}
+	}
+	var crs CRS
+	crs.ParseString(b.Crs)
+	return crs
+}
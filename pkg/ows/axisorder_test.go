@@ -0,0 +1,85 @@
+package ows
+
+import "testing"
+
+func TestCRSAxisOrder(t *testing.T) {
+	var tests = []struct {
+		crs   string
+		order AxisOrder
+	}{
+		0: {crs: `EPSG:4326`, order: NorthingEasting},
+		1: {crs: `urn:ogc:def:crs:EPSG::4258`, order: NorthingEasting},
+		2: {crs: `EPSG:3035`, order: NorthingEasting},
+		3: {crs: `EPSG:28992`, order: EastingNorthing},
+		4: {crs: `EPSG:3857`, order: EastingNorthing},
+		5: {crs: `CRS:84`, order: EastingNorthing},
+		6: {crs: ``, order: EastingNorthing},
+	}
+
+	for k, test := range tests {
+		var crs CRS
+		crs.ParseString(test.crs)
+		if order := crs.AxisOrder(); order != test.order {
+			t.Errorf("test: %d, expected: %d,\n got: %d", k, test.order, order)
+		}
+	}
+}
+
+func TestRegisterAxisOrder(t *testing.T) {
+	crs := CRS{Namespace: EPSG, Code: `2056`}
+	if order := crs.AxisOrder(); order != EastingNorthing {
+		t.Errorf("test: %d, expected: %d,\n got: %d", 0, EastingNorthing, order)
+	}
+
+	RegisterAxisOrder(crs, NorthingEasting)
+	defer RegisterAxisOrder(crs, EastingNorthing)
+	if order := crs.AxisOrder(); order != NorthingEasting {
+		t.Errorf("test: %d, expected: %d,\n got: %d", 1, NorthingEasting, order)
+	}
+}
+
+func TestPositionToXY(t *testing.T) {
+	var tests = []struct {
+		crs       CRS
+		authority Position
+		xy        Position
+	}{
+		0: {crs: CRS{Namespace: EPSG, Code: `4326`}, authority: Position{52.1, 5.3}, xy: Position{5.3, 52.1}},
+		1: {crs: CRS{Namespace: EPSG, Code: `28992`}, authority: Position{155000, 463000}, xy: Position{155000, 463000}},
+		2: {crs: CRS{}, authority: Position{5.3, 52.1}, xy: Position{5.3, 52.1}},
+		3: {crs: CRS{Namespace: EPSG, Code: `4979`}, authority: Position{52.1, 5.3, 10}, xy: Position{5.3, 52.1, 10}},
+	}
+
+	for k, test := range tests {
+		if xy := test.authority.ToXY(test.crs); !xy.Equal(test.xy) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.xy, xy)
+		}
+		if authority := test.xy.ToAuthority(test.crs); !authority.Equal(test.authority) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.authority, authority)
+		}
+	}
+}
+
+func TestBoundingBoxToXY(t *testing.T) {
+	var tests = []struct {
+		crs       CRS
+		authority BoundingBox
+		xy        BoundingBox
+	}{
+		0: {crs: CRS{Namespace: EPSG, Code: `4326`},
+			authority: BoundingBox{Crs: `EPSG:4326`, LowerCorner: Position{50.7, 3.2}, UpperCorner: Position{53.5, 7.2}},
+			xy:        BoundingBox{Crs: `EPSG:4326`, LowerCorner: Position{3.2, 50.7}, UpperCorner: Position{7.2, 53.5}}},
+		1: {crs: CRS{Namespace: EPSG, Code: `28992`},
+			authority: BoundingBox{Crs: `EPSG:28992`, LowerCorner: Position{10000, 300000}, UpperCorner: Position{280000, 625000}},
+			xy:        BoundingBox{Crs: `EPSG:28992`, LowerCorner: Position{10000, 300000}, UpperCorner: Position{280000, 625000}}},
+	}
+
+	for k, test := range tests {
+		if xy := test.authority.ToXY(test.crs); !xy.Equal(test.xy) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.xy, xy)
+		}
+		if authority := test.xy.ToAuthority(test.crs); !authority.Equal(test.authority) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.authority, authority)
+		}
+	}
+}
@@ -3,19 +3,18 @@ package ows
 import (
 	"encoding/xml"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
 //
 const (
-	codeSpace = `urn:ogc:def:crs:EPSG::`
-	EPSG      = `EPSG`
+	EPSG = `EPSG`
 )
 
 // BoundingBox struct
 // Base BoundingBox struct to be used for OGC Boundingbox object
+// The corners are in x y order, encodings in the axis order of a CRS are converted with ToXY and ToAuthority
 // What todo with Geoserver implementation...
 // 'cause the http://schemas.opengis.net/ows/1.0.0/owsCommon.xsd is quite clear... PositionTypes and CRS not srsName and coords....
 // <BoundingBox srsName="http://www.opengis.net/gml/srs/epsg.xml#4326">
@@ -30,43 +29,123 @@ type BoundingBox struct {
 }
 
 // Position type
-type Position [2]float64
+// The coordinates are in x y order, followed by the other axes of the CRS like the height
+type Position []float64
+
+// XY returns the x and y of the Position, a missing coordinate is 0
+func (p Position) XY() [2]float64 {
+	var xy [2]float64
+	copy(xy[:], p)
+	return xy
+}
+
+// Dimension returns the number of coordinates of the Position
+func (p Position) Dimension() int {
+	return len(p)
+}
+
+// coords returns the coordinates to encode, an empty Position is encoded as the 2D origin
+func (p Position) coords() []float64 {
+	if len(p) < 2 {
+		xy := p.XY()
+		return xy[:]
+	}
+	return p
+}
+
+// MatchesCRS checks if the Position has the dimension of the CRS
+// Without a CRS the Position needs at least the x and y
+func (p Position) MatchesCRS(crs CRS) bool {
+	if d := crs.Dimension(); d > 0 {
+		return len(p) == d
+	}
+	return len(p) >= 2
+}
+
+// Equal checks if both Positions have the same coordinates
+func (p Position) Equal(o Position) bool {
+	if len(p) != len(o) {
+		return false
+	}
+	for i := range p {
+		if p[i] != o[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Dimension returns the number of coordinates of the corners of the BoundingBox
+func (b *BoundingBox) Dimension() int {
+	return len(b.LowerCorner)
+}
+
+// validDimension checks if the corners have the same number of coordinates as the dimensions attribute and the crs
+// A BoundingBox without corners isn't checked
+func (b *BoundingBox) validDimension() bool {
+	if len(b.LowerCorner) == 0 && len(b.UpperCorner) == 0 {
+		return true
+	}
+	if len(b.LowerCorner) != len(b.UpperCorner) {
+		return false
+	}
+	if b.Dimensions != `` && b.Dimensions != strconv.Itoa(len(b.LowerCorner)) {
+		return false
+	}
+	return b.LowerCorner.MatchesCRS(b.crs())
+}
+
+// Equal checks if both BoundingBoxes have the same crs, dimensions and corners
+func (b BoundingBox) Equal(o BoundingBox) bool {
+	return b.Crs == o.Crs && b.Dimensions == o.Dimensions && b.LowerCorner.Equal(o.LowerCorner) && b.UpperCorner.Equal(o.UpperCorner)
+}
 
 // BuildKVP function for getting a KVP Query BBOX value
+// The values are written in the order of the corners, use ToAuthority first when the axis order of a CRS is needed
+// The lower corner is followed by the upper corner, so a 3D BoundingBox has 6 values
 func (b *BoundingBox) BuildKVP() string {
-	return fmt.Sprintf("%f,%f,%f,%f", b.LowerCorner[0], b.LowerCorner[1], b.UpperCorner[0], b.UpperCorner[1])
+	var values []string
+	for _, c := range append(append([]float64{}, b.LowerCorner.coords()...), b.UpperCorner.coords()...) {
+		values = append(values, fmt.Sprintf("%f", c))
+	}
+	return strings.Join(values, `,`)
 }
 
 //ParseString builds a BoundingBox based on a string
+// The values are taken in the given order, use ToXY when they are in the axis order of a CRS
+// The lower corner is followed by the upper corner and optionally the crs, like minx,miny,minz,maxx,maxy,maxz,crs
 func (b *BoundingBox) ParseString(boundingbox string) Exception {
 	result := strings.Split(boundingbox, ",")
-	var lx, ly, ux, uy float64
-	var err error
+
+	var crs string
+	if len(result)%2 == 1 {
+		crs = result[len(result)-1]
+		result = result[:len(result)-1]
+	}
 
 	if len(result) < 4 {
 		return InvalidParameterValue(boundingbox, `boundingbox`)
 	}
 
-	if len(result) == 4 || len(result) == 5 {
-		if lx, err = strconv.ParseFloat(result[0], 64); err != nil {
-			return InvalidParameterValue(boundingbox, `boundingbox`)
-		}
-		if ly, err = strconv.ParseFloat(result[1], 64); err != nil {
-			return InvalidParameterValue(boundingbox, `boundingbox`)
-		}
-		if ux, err = strconv.ParseFloat(result[2], 64); err != nil {
-			return InvalidParameterValue(boundingbox, `boundingbox`)
-		}
-		if uy, err = strconv.ParseFloat(result[3], 64); err != nil {
+	coords := make(Position, len(result))
+	for i, v := range result {
+		c, err := strconv.ParseFloat(v, 64)
+		if err != nil {
 			return InvalidParameterValue(boundingbox, `boundingbox`)
 		}
+		coords[i] = c
 	}
 
-	b.LowerCorner = [2]float64{lx, ly}
-	b.UpperCorner = [2]float64{ux, uy}
+	half := len(coords) / 2
+	bbox := BoundingBox{Crs: crs, LowerCorner: coords[:half:half], UpperCorner: coords[half:]}
+	if !bbox.LowerCorner.MatchesCRS(bbox.crs()) {
+		return InvalidParameterValue(boundingbox, `boundingbox`)
+	}
 
-	if len(result) == 5 {
-		b.Crs = result[4]
+	b.LowerCorner = bbox.LowerCorner
+	b.UpperCorner = bbox.UpperCorner
+	if crs != `` {
+		b.Crs = crs
 	}
 
 	return nil
@@ -91,47 +170,8 @@ func StripDuplicateAttr(attr []xml.Attr) []xml.Attr {
 	return strippedAttr
 }
 
-// CRS struct with namespace/authority/registry and code
-type CRS struct {
-	Namespace string //TODO maybe AuthorityType is a better name...?
-	Code      int
-}
-
-// String of the EPSGCode
-func (c *CRS) String() string {
-	return c.Namespace + `:` + strconv.Itoa(c.Code)
-}
-
-// Identifier returns the EPSG
-func (c *CRS) Identifier() string {
-	return codeSpace + strconv.Itoa(c.Code)
-}
-
-// ParseString build CRS struct from input string
-func (c *CRS) ParseString(s string) {
-	c.parseString(s)
-}
-
-func (c *CRS) parseString(s string) {
-	regex := regexp.MustCompile(`(^.*):([0-9]+)`)
-	code := regex.FindStringSubmatch(s)
-	if len(code) == 3 { // code[0] is the full match, the other the parts
-		f := strings.Index(code[1], EPSG)
-		if f > -1 {
-			c.Namespace = EPSG
-		} else {
-			c.Namespace = code[1]
-		}
-
-		// the regex already checks if it [0-9]
-		i, _ := strconv.Atoi(code[2])
-		c.Code = i
-	}
-}
-
 func getPositionFromString(position string) []float64 {
-	regex := regexp.MustCompile(` `)
-	result := regex.Split(position, -1)
+	result := strings.Fields(position)
 	var ps []float64 //slice because length can be 2 or more
 
 	// check if 'strings' are parsable to float64
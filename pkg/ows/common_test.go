@@ -12,7 +12,8 @@ func TestBoundingBoxBuildQueryString(t *testing.T) {
 	}{
 		// While 'not' correct this will we checked in the validation step
 		0: {boundingbox: BoundingBox{}, boundingboxstring: `0.000000,0.000000,0.000000,0.000000`},
-		1: {boundingbox: BoundingBox{LowerCorner: [2]float64{-180.0, -90.0}, UpperCorner: [2]float64{180.0, 90.0}}, boundingboxstring: `-180.000000,-90.000000,180.000000,90.000000`},
+		1: {boundingbox: BoundingBox{LowerCorner: Position{-180.0, -90.0}, UpperCorner: Position{180.0, 90.0}}, boundingboxstring: `-180.000000,-90.000000,180.000000,90.000000`},
+		2: {boundingbox: BoundingBox{LowerCorner: Position{120000, 480000, -10}, UpperCorner: Position{121000, 480500, 50}}, boundingboxstring: `120000.000000,480000.000000,-10.000000,121000.000000,480500.000000,50.000000`},
 	}
 	for k, test := range tests {
 		str := test.boundingbox.BuildKVP()
@@ -28,12 +29,16 @@ func TestBuildBoundingBox(t *testing.T) {
 		bbox        BoundingBox
 		Exception   Exception
 	}{
-		0: {boundingbox: "0,0,100,100", bbox: BoundingBox{LowerCorner: [2]float64{0, 0}, UpperCorner: [2]float64{100, 100}}},
-		1: {boundingbox: "0,0,-100,-100", bbox: BoundingBox{LowerCorner: [2]float64{0, 0}, UpperCorner: [2]float64{-100, -100}}}, // while this isn't correct, this will be 'addressed' in the validation step
+		0: {boundingbox: "0,0,100,100", bbox: BoundingBox{LowerCorner: Position{0, 0}, UpperCorner: Position{100, 100}}},
+		1: {boundingbox: "0,0,-100,-100", bbox: BoundingBox{LowerCorner: Position{0, 0}, UpperCorner: Position{-100, -100}}}, // while this isn't correct, this will be 'addressed' in the validation step
 		2: {boundingbox: "0,0,100", Exception: InvalidParameterValue(`0,0,100`, `boundingbox`)},
 		3: {boundingbox: ",,,", Exception: InvalidParameterValue(`,,,`, `boundingbox`)},
 		4: {boundingbox: ",,,100", Exception: InvalidParameterValue(`,,,100`, `boundingbox`)},
 		5: {boundingbox: "number,,,100", Exception: InvalidParameterValue(`number,,,100`, `boundingbox`)},
+		6: {boundingbox: "0,0,-10,100,100,50", bbox: BoundingBox{LowerCorner: Position{0, 0, -10}, UpperCorner: Position{100, 100, 50}}},
+		7: {boundingbox: "0,0,100,100,EPSG:28992", bbox: BoundingBox{Crs: `EPSG:28992`, LowerCorner: Position{0, 0}, UpperCorner: Position{100, 100}}},
+		8: {boundingbox: "0,0,-10,100,100,50,EPSG:7415", bbox: BoundingBox{Crs: `EPSG:7415`, LowerCorner: Position{0, 0, -10}, UpperCorner: Position{100, 100, 50}}},
+		9: {boundingbox: "0,0,-10,100,100,50,EPSG:28992", Exception: InvalidParameterValue(`0,0,-10,100,100,50,EPSG:28992`, `boundingbox`)},
 	}
 
 	for k, test := range tests {
@@ -43,13 +48,55 @@ func TestBuildBoundingBox(t *testing.T) {
 				t.Errorf("test: %d, expected: %+v \ngot: %+v", k, test.Exception, err)
 			}
 		} else {
-			if bbox != test.bbox {
+			if !bbox.Equal(test.bbox) {
 				t.Errorf("test: %d, expected: %+v \ngot: %+v", k, test.bbox, bbox)
 			}
 		}
 	}
 }
 
+func TestPositionXY(t *testing.T) {
+	var tests = []struct {
+		position  Position
+		xy        [2]float64
+		dimension int
+	}{
+		0: {position: Position{155000, 463000}, xy: [2]float64{155000, 463000}, dimension: 2},
+		1: {position: Position{155000, 463000, 10}, xy: [2]float64{155000, 463000}, dimension: 3},
+		2: {position: Position{}, xy: [2]float64{0, 0}, dimension: 0},
+	}
+
+	for k, test := range tests {
+		if xy := test.position.XY(); xy != test.xy {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.xy, xy)
+		}
+		if d := test.position.Dimension(); d != test.dimension {
+			t.Errorf("test: %d, expected: %d,\n got: %d", k, test.dimension, d)
+		}
+	}
+}
+
+func TestPositionMatchesCRS(t *testing.T) {
+	var tests = []struct {
+		position Position
+		crs      CRS
+		matches  bool
+	}{
+		0: {position: Position{155000, 463000}, crs: CRS{Namespace: EPSG, Code: `28992`}, matches: true},
+		1: {position: Position{155000, 463000, 10}, crs: CRS{Namespace: EPSG, Code: `28992`}},
+		2: {position: Position{155000, 463000, 10}, crs: CRS{Namespace: EPSG, Code: `7415`}, matches: true},
+		3: {position: Position{155000, 463000}, crs: CRS{Namespace: EPSG, Code: `7415`}},
+		4: {position: Position{155000, 463000, 10, 0}, crs: CRS{}, matches: true},
+		5: {position: Position{155000}, crs: CRS{}},
+	}
+
+	for k, test := range tests {
+		if matches := test.position.MatchesCRS(test.crs); matches != test.matches {
+			t.Errorf("test: %d, expected: %t,\n got: %t", k, test.matches, matches)
+		}
+	}
+}
+
 func TestStripDuplicateAttr(t *testing.T) {
 	var tests = []struct {
 		attributes []xml.Attr
@@ -80,23 +127,3 @@ func TestStripDuplicateAttr(t *testing.T) {
 		}
 	}
 }
-
-func TestCRSParseString(t *testing.T) {
-	var tests = []struct {
-		input       string
-		expectedCRS CRS
-	}{
-		0: {}, // Empty input == empty struct
-		1: {input: `urn:ogc:def:crs:EPSG::4326`, expectedCRS: CRS{Code: 4326, Namespace: `EPSG`}},
-		2: {input: `EPSG:4326`, expectedCRS: CRS{Code: 4326, Namespace: `EPSG`}},
-	}
-
-	for k, test := range tests {
-		var crs CRS
-		crs.parseString(test.input)
-
-		if crs.Code != test.expectedCRS.Code || crs.Namespace != test.expectedCRS.Namespace {
-			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.expectedCRS, crs)
-		}
-	}
-}
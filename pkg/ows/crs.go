@@ -0,0 +1,212 @@
+package ows
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Prefixes of the URN and http URI forms of a CRS
+const (
+	crsURNPrefix = `urn:ogc:def:crs:`
+	crsURIPrefix = `http://www.opengis.net/def/crs/`
+	// crsLegacyURIPrefix is the GML 2 form, like http://www.opengis.net/gml/srs/epsg.xml#4326
+	crsLegacyURIPrefix = `http://www.opengis.net/gml/srs/epsg.xml#`
+)
+
+// Namespaces of the CRSs defined by the OGC
+// The CRS namespace holds the WMS 1.3.0 codes, like CRS:84, that are OGC:CRS84 in the URN and URI forms
+const (
+	ogc          = `OGC`
+	crsNamespace = `CRS`
+)
+
+// CRS84 is the WGS84 longitude latitude CRS of the OGC
+var CRS84 = CRS{Namespace: crsNamespace, Code: `84`}
+
+// equivalents are the CRSs that have the same meaning as another CRS, only the axis order can differ
+var equivalents = map[CRS]CRS{
+	{Namespace: crsNamespace, Code: `84`}: {Namespace: EPSG, Code: `4326`},
+	{Namespace: crsNamespace, Code: `83`}: {Namespace: EPSG, Code: `4269`},
+	{Namespace: crsNamespace, Code: `27`}: {Namespace: EPSG, Code: `4267`},
+	{Namespace: EPSG, Code: `900913`}:     {Namespace: EPSG, Code: `3857`},
+	{Namespace: EPSG, Code: `3785`}:       {Namespace: EPSG, Code: `3857`},
+	{Namespace: `ESRI`, Code: `102100`}:   {Namespace: EPSG, Code: `3857`},
+	{Namespace: `ESRI`, Code: `102113`}:   {Namespace: EPSG, Code: `3857`},
+}
+
+var (
+	dimensionMutex sync.RWMutex
+	// dimensions are the CRSs with more than 2 axes
+	dimensions = map[CRS]int{
+		{Namespace: EPSG, Code: `4978`}: 3, // WGS 84 geocentric
+		{Namespace: EPSG, Code: `4979`}: 3, // WGS 84 (3D)
+		{Namespace: EPSG, Code: `4936`}: 3, // ETRS89 geocentric
+		{Namespace: EPSG, Code: `4937`}: 3, // ETRS89 (3D)
+		{Namespace: EPSG, Code: `7415`}: 3, // Amersfoort / RD New + NAP height
+		{Namespace: EPSG, Code: `7423`}: 3, // ETRS89 + EVRF2007 height
+		{Namespace: EPSG, Code: `9286`}: 3, // ETRS89 + NAP height
+	}
+)
+
+// CRS struct with namespace/authority/registry and code
+type CRS struct {
+	Namespace string //TODO maybe AuthorityType is a better name...?
+	Code      string
+}
+
+// ParseCRS returns the CRS of the string, see ParseString for the accepted forms
+func ParseCRS(s string) (CRS, Exception) {
+	var crs CRS
+	err := crs.ParseString(s)
+	return crs, err
+}
+
+// String of the CRS in the short form, like EPSG:28992 or CRS:84
+func (c *CRS) String() string {
+	if c.Namespace == `` {
+		return ``
+	}
+	return c.Namespace + `:` + c.Code
+}
+
+// Identifier returns the URN of the CRS
+func (c *CRS) Identifier() string {
+	return c.URN()
+}
+
+// URN returns the CRS in the URN form, like urn:ogc:def:crs:EPSG::28992 or urn:ogc:def:crs:OGC:1.3:CRS84
+func (c *CRS) URN() string {
+	if c.Namespace == `` {
+		return ``
+	}
+	if c.Namespace == crsNamespace {
+		return crsURNPrefix + ogc + `:1.3:` + crsNamespace + c.Code
+	}
+	return crsURNPrefix + c.Namespace + `::` + c.Code
+}
+
+// URI returns the CRS in the http URI form, like http://www.opengis.net/def/crs/EPSG/0/28992 or http://www.opengis.net/def/crs/OGC/1.3/CRS84
+func (c *CRS) URI() string {
+	if c.Namespace == `` {
+		return ``
+	}
+	if c.Namespace == crsNamespace {
+		return crsURIPrefix + ogc + `/1.3/` + crsNamespace + c.Code
+	}
+	return crsURIPrefix + c.Namespace + `/0/` + c.Code
+}
+
+// Equivalent checks if both CRSs have the same meaning, like CRS:84 and EPSG:4326 or EPSG:900913 and EPSG:3857
+// The second value is true when the axis orders of the CRSs differ, like for CRS:84 and EPSG:4326
+func (c *CRS) Equivalent(o CRS) (bool, bool) {
+	if c.Namespace == `` || o.Namespace == `` {
+		return false, false
+	}
+	if c.base() != o.base() {
+		return false, false
+	}
+	return true, c.AxisOrder() != o.AxisOrder()
+}
+
+// RegisterDimension sets the number of axes of the CRS
+// It adds a CRS that isn't known or overrules the dimension of a known CRS
+func RegisterDimension(crs CRS, dimension int) {
+	dimensionMutex.Lock()
+	defer dimensionMutex.Unlock()
+
+	dimensions[crs] = dimension
+}
+
+// Dimension returns the number of axes of the CRS, a CRS that isn't registered has 2 axes
+// An empty CRS has no known dimension and returns 0
+func (c *CRS) Dimension() int {
+	if c.Namespace == `` {
+		return 0
+	}
+
+	dimensionMutex.RLock()
+	defer dimensionMutex.RUnlock()
+
+	if d, ok := dimensions[*c]; ok {
+		return d
+	}
+	return 2
+}
+
+// base returns the CRS this CRS is equivalent to, or the CRS itself
+func (c *CRS) base() CRS {
+	if b, ok := equivalents[*c]; ok {
+		return b
+	}
+	return *c
+}
+
+// ParseString build CRS struct from input string
+// The short form (EPSG:28992, CRS:84), the URN form (urn:ogc:def:crs:EPSG::28992, urn:ogc:def:crs:OGC:1.3:CRS84)
+// and the http URI form (http://www.opengis.net/def/crs/EPSG/0/28992) are accepted.
+// An empty string is an empty CRS, a string that isn't a CRS results in an InvalidParameterValue exception.
+func (c *CRS) ParseString(s string) Exception {
+	crs, ok := parseCRS(strings.TrimSpace(s))
+	if !ok {
+		*c = CRS{}
+		return InvalidParameterValue(s, `crs`)
+	}
+	*c = crs
+	return nil
+}
+
+// parseCRS returns the CRS of the string in one of the accepted forms
+func parseCRS(s string) (CRS, bool) {
+	lower := strings.ToLower(s)
+	switch {
+	case s == ``:
+		return CRS{}, true
+	case strings.HasPrefix(lower, `urn:`):
+		// urn:ogc:def:crs:{authority}:{version}:{code}, the version is often empty
+		parts := strings.Split(s, `:`)
+		if len(parts) != 7 || (strings.ToLower(parts[1]) != `ogc` && strings.ToLower(parts[1]) != `x-ogc`) ||
+			strings.ToLower(parts[2]) != `def` || strings.ToLower(parts[3]) != `crs` {
+			return CRS{}, false
+		}
+		return newCRS(parts[4], parts[6])
+	case strings.HasPrefix(lower, crsLegacyURIPrefix):
+		return newCRS(EPSG, s[len(crsLegacyURIPrefix):])
+	case strings.HasPrefix(lower, crsURIPrefix), strings.HasPrefix(lower, `https://www.opengis.net/def/crs/`):
+		// http://www.opengis.net/def/crs/{authority}/{version}/{code}
+		parts := strings.Split(s[strings.Index(lower, `/def/crs/`)+len(`/def/crs/`):], `/`)
+		if len(parts) != 3 {
+			return CRS{}, false
+		}
+		return newCRS(parts[0], parts[2])
+	default:
+		parts := strings.Split(s, `:`)
+		if len(parts) != 2 {
+			return CRS{}, false
+		}
+		return newCRS(parts[0], parts[1])
+	}
+}
+
+// newCRS returns the CRS of the authority and code
+// The OGC CRS84, CRS83 and CRS27 become the WMS 1.3.0 CRS:84, CRS:83 and CRS:27, like the OGC codes 84, 83 and 27
+// as used in urn:ogc:def:crs:OGC:2:84
+func newCRS(authority, code string) (CRS, bool) {
+	authority = strings.ToUpper(authority)
+	if authority == `` || code == `` || strings.ContainsAny(authority+code, " /:#") {
+		return CRS{}, false
+	}
+	if authority == ogc && strings.HasPrefix(strings.ToUpper(code), crsNamespace) {
+		authority, code = crsNamespace, code[len(crsNamespace):]
+	}
+	if authority == ogc && (code == `84` || code == `83` || code == `27`) {
+		authority = crsNamespace
+	}
+	if authority == EPSG || authority == crsNamespace {
+		// these registries only have numeric codes
+		if _, err := strconv.Atoi(code); err != nil {
+			return CRS{}, false
+		}
+	}
+	return CRS{Namespace: authority, Code: code}, true
+}
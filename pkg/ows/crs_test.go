@@ -0,0 +1,136 @@
+package ows
+
+import (
+	"testing"
+)
+
+func TestCRSParseString(t *testing.T) {
+	var tests = []struct {
+		input       string
+		expectedCRS CRS
+		exception   Exception
+	}{
+		0:  {}, // Empty input == empty struct
+		1:  {input: `urn:ogc:def:crs:EPSG::4326`, expectedCRS: CRS{Code: `4326`, Namespace: `EPSG`}},
+		2:  {input: `EPSG:4326`, expectedCRS: CRS{Code: `4326`, Namespace: `EPSG`}},
+		3:  {input: `epsg:28992`, expectedCRS: CRS{Code: `28992`, Namespace: `EPSG`}},
+		4:  {input: `CRS:84`, expectedCRS: CRS84},
+		5:  {input: `urn:ogc:def:crs:OGC:1.3:CRS84`, expectedCRS: CRS84},
+		6:  {input: `urn:x-ogc:def:crs:EPSG:6.6:28992`, expectedCRS: CRS{Code: `28992`, Namespace: `EPSG`}},
+		7:  {input: `http://www.opengis.net/def/crs/EPSG/0/28992`, expectedCRS: CRS{Code: `28992`, Namespace: `EPSG`}},
+		8:  {input: `http://www.opengis.net/def/crs/OGC/1.3/CRS84`, expectedCRS: CRS84},
+		9:  {input: `http://www.opengis.net/gml/srs/epsg.xml#4326`, expectedCRS: CRS{Code: `4326`, Namespace: `EPSG`}},
+		10: {input: `ESRI:102100`, expectedCRS: CRS{Code: `102100`, Namespace: `ESRI`}},
+		11: {input: `IGNF:LAMB93`, expectedCRS: CRS{Code: `LAMB93`, Namespace: `IGNF`}},
+		12: {input: `EPSG:RD`, exception: InvalidParameterValue(`EPSG:RD`, `crs`)},
+		13: {input: `28992`, exception: InvalidParameterValue(`28992`, `crs`)},
+		14: {input: `urn:ogc:def:crs:EPSG:28992`, exception: InvalidParameterValue(`urn:ogc:def:crs:EPSG:28992`, `crs`)},
+		15: {input: `http://www.opengis.net/def/crs/EPSG/28992`, exception: InvalidParameterValue(`http://www.opengis.net/def/crs/EPSG/28992`, `crs`)},
+		16: {input: `urn:ogc:def:crs:OGC:2:84`, expectedCRS: CRS84},
+		17: {input: `http://www.opengis.net/def/crs/OGC/1.3/84`, expectedCRS: CRS84},
+		18: {input: `urn:ogc:def:crs:OGC:2:83`, expectedCRS: CRS{Code: `83`, Namespace: `CRS`}},
+		19: {input: `urn:ogc:def:crs:OGC:1.3:CRS27`, expectedCRS: CRS{Code: `27`, Namespace: `CRS`}},
+		20: {input: `urn:ogc:def:crs:OGC:2:AnsiDate`, expectedCRS: CRS{Code: `AnsiDate`, Namespace: `OGC`}},
+	}
+
+	for k, test := range tests {
+		crs, exception := ParseCRS(test.input)
+		if exception != nil {
+			if test.exception == nil || exception.Error() != test.exception.Error() {
+				t.Errorf("test: %d, expected: %v,\n got: %s", k, test.exception, exception.Error())
+			}
+			continue
+		}
+		if test.exception != nil {
+			t.Errorf("test: %d, expected: %s,\n got: %v", k, test.exception.Error(), crs)
+			continue
+		}
+		if crs != test.expectedCRS {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.expectedCRS, crs)
+		}
+	}
+}
+
+func TestCRSForms(t *testing.T) {
+	var tests = []struct {
+		crs         CRS
+		expectedStr string
+		expectedURN string
+		expectedURI string
+	}{
+		0: {},
+		1: {crs: CRS{Code: `28992`, Namespace: `EPSG`}, expectedStr: `EPSG:28992`, expectedURN: `urn:ogc:def:crs:EPSG::28992`, expectedURI: `http://www.opengis.net/def/crs/EPSG/0/28992`},
+		2: {crs: CRS84, expectedStr: `CRS:84`, expectedURN: `urn:ogc:def:crs:OGC:1.3:CRS84`, expectedURI: `http://www.opengis.net/def/crs/OGC/1.3/CRS84`},
+	}
+
+	for k, test := range tests {
+		if s := test.crs.String(); s != test.expectedStr {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.expectedStr, s)
+		}
+		if s := test.crs.URN(); s != test.expectedURN {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.expectedURN, s)
+		}
+		if s := test.crs.URI(); s != test.expectedURI {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.expectedURI, s)
+		}
+		// every form parses to the same CRS
+		for _, s := range []string{test.expectedStr, test.expectedURN, test.expectedURI} {
+			if crs, _ := ParseCRS(s); crs != test.crs {
+				t.Errorf("test: %d, expected: %v,\n got: %v", k, test.crs, crs)
+			}
+		}
+	}
+}
+
+func TestCRSEquivalent(t *testing.T) {
+	var tests = []struct {
+		a, b              CRS
+		equivalent        bool
+		axisOrderSwitched bool
+	}{
+		0: {a: CRS84, b: CRS{Code: `4326`, Namespace: `EPSG`}, equivalent: true, axisOrderSwitched: true},
+		1: {a: CRS{Code: `900913`, Namespace: `EPSG`}, b: CRS{Code: `3857`, Namespace: `EPSG`}, equivalent: true},
+		2: {a: CRS{Code: `102100`, Namespace: `ESRI`}, b: CRS{Code: `900913`, Namespace: `EPSG`}, equivalent: true},
+		3: {a: CRS{Code: `28992`, Namespace: `EPSG`}, b: CRS{Code: `28992`, Namespace: `EPSG`}, equivalent: true},
+		4: {a: CRS{Code: `28992`, Namespace: `EPSG`}, b: CRS{Code: `4326`, Namespace: `EPSG`}},
+		5: {a: CRS{}, b: CRS{}},
+	}
+
+	for k, test := range tests {
+		equivalent, switched := test.a.Equivalent(test.b)
+		if equivalent != test.equivalent || switched != test.axisOrderSwitched {
+			t.Errorf("test: %d, expected: %t %t,\n got: %t %t", k, test.equivalent, test.axisOrderSwitched, equivalent, switched)
+		}
+	}
+}
+
+func TestCRSDimension(t *testing.T) {
+	var tests = []struct {
+		crs       CRS
+		dimension int
+	}{
+		0: {crs: CRS{Namespace: EPSG, Code: `28992`}, dimension: 2},
+		1: {crs: CRS{Namespace: EPSG, Code: `7415`}, dimension: 3},
+		2: {crs: CRS84, dimension: 2},
+		3: {crs: CRS{}, dimension: 0},
+	}
+
+	for k, test := range tests {
+		if d := test.crs.Dimension(); d != test.dimension {
+			t.Errorf("test: %d, expected: %d,\n got: %d", k, test.dimension, d)
+		}
+	}
+}
+
+func TestRegisterDimension(t *testing.T) {
+	crs := CRS{Namespace: EPSG, Code: `5498`}
+	if d := crs.Dimension(); d != 2 {
+		t.Errorf("test: %d, expected: %d,\n got: %d", 0, 2, d)
+	}
+
+	RegisterDimension(crs, 3)
+	defer RegisterDimension(crs, 2)
+	if d := crs.Dimension(); d != 3 {
+		t.Errorf("test: %d, expected: %d,\n got: %d", 1, 3, d)
+	}
+}
@@ -0,0 +1,370 @@
+package ows
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Namespace URIs of the OGC and W3C specifications used in this module
+const (
+	OWS11Namespace         = `http://www.opengis.net/ows/1.1`
+	OWS20Namespace         = `http://www.opengis.net/ows/2.0`
+	XLinkNamespace         = `http://www.w3.org/1999/xlink`
+	XSINamespace           = `http://www.w3.org/2001/XMLSchema-instance`
+	XSDNamespace           = `http://www.w3.org/2001/XMLSchema`
+	GML31Namespace         = `http://www.opengis.net/gml`
+	GML32Namespace         = `http://www.opengis.net/gml/3.2`
+	FES20Namespace         = `http://www.opengis.net/fes/2.0`
+	WFS20Namespace         = `http://www.opengis.net/wfs/2.0`
+	WMSNamespace           = `http://www.opengis.net/wms`
+	SLDNamespace           = `http://www.opengis.net/sld`
+	WMTS10Namespace        = `http://www.opengis.net/wmts/1.0`
+	WCS20Namespace         = `http://www.opengis.net/wcs/2.0`
+	GMLCOVNamespace        = `http://www.opengis.net/gmlcov/1.0`
+	SWE20Namespace         = `http://www.opengis.net/swe/2.0`
+	WCSCRSNamespace        = `http://www.opengis.net/wcs/crs/1.0`
+	WCSInterpolationNS     = `http://www.opengis.net/wcs/interpolation/1.0`
+	WCSScalingNamespace    = `http://www.opengis.net/wcs/scaling/1.0`
+	WCSRangeSubsettingNS   = `http://www.opengis.net/wcs/range-subsetting/1.0`
+	InspireCommonNamespace = `http://inspire.ec.europa.eu/schemas/common/1.0`
+	InspireDLSNamespace    = `http://inspire.ec.europa.eu/schemas/inspire_dls/1.0`
+	InspireVSNamespace     = `http://inspire.ec.europa.eu/schemas/inspire_vs/1.0`
+)
+
+// xmlNamespace is bound to the xml prefix by definition
+const xmlNamespace = `http://www.w3.org/XML/1998/namespace`
+
+// Namespaces maps namespace URIs on the prefixes used for encoding a XML document.
+// An empty prefix makes it the default namespace of the document.
+//
+// The structs in this module have namespace qualified struct tags, like
+// `xml:"http://www.opengis.net/ows/1.1 Title"`, so documents are decoded by namespace URI whatever
+// prefixes they use. Encoding those structs with encoding/xml results in a xmlns attribute on every
+// element, with Marshal and Encode the document gets the prefixes of the Namespaces instead.
+type Namespaces map[string]string
+
+// CanonicalNamespaces contains the prefixes as used by the OGC specifications
+var CanonicalNamespaces = Namespaces{
+	OWS11Namespace:         `ows`,
+	OWS20Namespace:         `ows`,
+	XLinkNamespace:         `xlink`,
+	XSINamespace:           `xsi`,
+	XSDNamespace:           `xsd`,
+	GML31Namespace:         `gml`,
+	GML32Namespace:         `gml`,
+	FES20Namespace:         `fes`,
+	WFS20Namespace:         `wfs`,
+	WMSNamespace:           ``,
+	SLDNamespace:           `sld`,
+	WMTS10Namespace:        ``,
+	WCS20Namespace:         `wcs`,
+	GMLCOVNamespace:        `gmlcov`,
+	SWE20Namespace:         `swe`,
+	WCSCRSNamespace:        `crs`,
+	WCSInterpolationNS:     `int`,
+	WCSScalingNamespace:    `scal`,
+	WCSRangeSubsettingNS:   `rsub`,
+	InspireCommonNamespace: `inspire_common`,
+	InspireDLSNamespace:    `inspire_dls`,
+	InspireVSNamespace:     `inspire_vs`,
+}
+
+// With returns a copy of the Namespaces with the prefix for the given namespace
+func (ns Namespaces) With(namespace, prefix string) Namespaces {
+	c := make(Namespaces, len(ns)+1)
+	for k, v := range ns {
+		c[k] = v
+	}
+	c[namespace] = prefix
+	return c
+}
+
+// Marshal marshals v like xml.MarshalIndent and encodes the result with the prefixes of the Namespaces
+func (ns Namespaces) Marshal(v interface{}, prefix, indent string) ([]byte, error) {
+	doc, err := xml.MarshalIndent(v, prefix, indent)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Encode(doc)
+}
+
+// Encode rewrites the XML document so every element and attribute uses the prefix of its namespace.
+// All namespaces are declared on the root element. Namespaces that aren't in the Namespaces keep
+// the prefix declared in the document, or get a generated one. Empty elements are closed with '/>'.
+func (ns Namespaces) Encode(doc []byte) ([]byte, error) {
+	tokens, err := resolveTokens(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes, attrPrefixes, declarations := ns.assign(tokens)
+
+	var buf bytes.Buffer
+	root := true
+	for i, t := range tokens {
+		switch e := t.token.(type) {
+		case xml.StartElement:
+			buf.WriteByte('<')
+			buf.WriteString(qualifiedName(e.Name, prefixes))
+			if root {
+				for _, d := range declarations {
+					writeAttr(&buf, d.Name.Local, d.Value)
+				}
+				root = false
+			}
+			for _, a := range e.Attr {
+				if isDeclaration(a) {
+					// all the namespaces are declared on the root element
+					continue
+				}
+				writeAttr(&buf, qualifiedName(a.Name, attrPrefixes), a.Value)
+			}
+			if i+1 < len(tokens) {
+				if _, ok := tokens[i+1].token.(xml.EndElement); ok {
+					buf.WriteString(`/>`)
+					tokens[i+1].skip = true
+					continue
+				}
+			}
+			buf.WriteByte('>')
+		case xml.EndElement:
+			if t.skip {
+				continue
+			}
+			buf.WriteString(`</`)
+			buf.WriteString(qualifiedName(e.Name, prefixes))
+			buf.WriteByte('>')
+		case xml.CharData:
+			textEscaper.WriteString(&buf, string(e))
+		case xml.Comment:
+			buf.WriteString(`<!--`)
+			buf.Write(e)
+			buf.WriteString(`-->`)
+		case xml.ProcInst:
+			buf.WriteString(`<?`)
+			buf.WriteString(e.Target)
+			if len(e.Inst) > 0 {
+				buf.WriteByte(' ')
+				buf.Write(e.Inst)
+			}
+			buf.WriteString(`?>`)
+		case xml.Directive:
+			buf.WriteString(`<!`)
+			buf.Write(e)
+			buf.WriteByte('>')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// resolvedToken is a token of which the element and attribute names have the namespace URI as Space,
+// except for prefixes that aren't declared, those keep the 'prefix:local' as Local name.
+// The xmlns declarations are kept as attributes with the Space xmlns.
+type resolvedToken struct {
+	token xml.Token
+	skip  bool
+}
+
+// resolveTokens reads the tokens of the document and resolves the namespaces itself,
+// because the xml.Decoder doesn't tell if a prefix is declared or not
+func resolveTokens(doc []byte) ([]resolvedToken, error) {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+
+	var tokens []resolvedToken
+	var scopes []map[string]string
+	var names []xml.Name
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := xml.CopyToken(t).(type) {
+		case xml.StartElement:
+			scope := map[string]string{`xml`: xmlNamespace}
+			if len(scopes) > 0 {
+				for k, v := range scopes[len(scopes)-1] {
+					scope[k] = v
+				}
+			}
+			for _, a := range e.Attr {
+				switch {
+				case a.Name.Space == `xmlns`:
+					scope[a.Name.Local] = a.Value
+				case isDeclaration(a):
+					scope[``] = a.Value
+				}
+			}
+			scopes = append(scopes, scope)
+			names = append(names, e.Name)
+
+			var attr []xml.Attr
+			for _, a := range e.Attr {
+				if isDeclaration(a) || a.Name.Space == `` {
+					attr = append(attr, a)
+					continue
+				}
+				attr = append(attr, xml.Attr{Name: resolveName(a.Name, scope), Value: a.Value})
+			}
+			e.Name = resolveName(e.Name, scope)
+			e.Attr = attr
+			tokens = append(tokens, resolvedToken{token: e})
+		case xml.EndElement:
+			// RawToken doesn't verify that the start and end elements match
+			if len(names) == 0 || names[len(names)-1] != e.Name {
+				line, _ := d.InputPos()
+				return nil, &xml.SyntaxError{Msg: `unexpected end element </` + e.Name.Local + `>`, Line: line}
+			}
+			e.Name = resolveName(e.Name, scopes[len(scopes)-1])
+			scopes = scopes[:len(scopes)-1]
+			names = names[:len(names)-1]
+			tokens = append(tokens, resolvedToken{token: e})
+		default:
+			tokens = append(tokens, resolvedToken{token: e})
+		}
+	}
+
+	if len(names) > 0 {
+		line, _ := d.InputPos()
+		return nil, &xml.SyntaxError{Msg: `unexpected EOF`, Line: line}
+	}
+	return tokens, nil
+}
+
+func resolveName(n xml.Name, scope map[string]string) xml.Name {
+	if namespace, ok := scope[n.Space]; ok && namespace != `` {
+		return xml.Name{Space: namespace, Local: n.Local}
+	}
+	if n.Space == `` {
+		return xml.Name{Local: n.Local}
+	}
+	// undeclared prefix
+	return xml.Name{Local: n.Space + `:` + n.Local}
+}
+
+// assign the prefixes to the namespaces used in the document, it returns the prefixes
+// for the elements and attributes and the xmlns declarations for the root element
+func (ns Namespaces) assign(tokens []resolvedToken) (map[string]string, map[string]string, []xml.Attr) {
+	// the namespaces of the names come first, so those get the prefix when a
+	// declared but unused namespace has the same prefix
+	var order, declaredOrder []string
+	used := make(map[string]bool)
+	attrUsed := make(map[string]bool)
+	declared := make(map[string]string)
+
+	use := func(namespace string) {
+		if namespace != `` && namespace != xmlNamespace && !used[namespace] {
+			used[namespace] = true
+			order = append(order, namespace)
+		}
+	}
+
+	for _, t := range tokens {
+		e, ok := t.token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		use(e.Name.Space)
+		for _, a := range e.Attr {
+			switch {
+			case a.Name.Space == `xmlns`:
+				if _, ok := declared[a.Value]; !ok && a.Value != `` {
+					declared[a.Value] = a.Name.Local
+					declaredOrder = append(declaredOrder, a.Value)
+				}
+			case a.Name.Space != ``:
+				use(a.Name.Space)
+				attrUsed[a.Name.Space] = true
+			}
+		}
+	}
+
+	for _, namespace := range declaredOrder {
+		use(namespace)
+	}
+
+	taken := make(map[string]bool)
+	free := func(prefix string, attribute bool) string {
+		if !taken[prefix] && !(attribute && prefix == ``) {
+			taken[prefix] = true
+			return prefix
+		}
+		base := prefix
+		if base == `` {
+			base = `ns`
+		}
+		for i := 1; ; i++ {
+			p := base + strconv.Itoa(i)
+			if !taken[p] {
+				taken[p] = true
+				return p
+			}
+		}
+	}
+
+	prefixes := make(map[string]string)
+	attrPrefixes := make(map[string]string)
+	var declarations []xml.Attr
+	for _, namespace := range order {
+		prefix, ok := ns[namespace]
+		if !ok {
+			if prefix, ok = declared[namespace]; !ok {
+				prefix = ``
+			}
+		}
+
+		prefixes[namespace] = free(prefix, false)
+		declarations = append(declarations, declaration(prefixes[namespace], namespace))
+
+		attrPrefixes[namespace] = prefixes[namespace]
+		if attrUsed[namespace] && prefixes[namespace] == `` {
+			// attributes don't have a default namespace
+			if prefix, ok = declared[namespace]; !ok {
+				prefix = ``
+			}
+			attrPrefixes[namespace] = free(prefix, true)
+			declarations = append(declarations, declaration(attrPrefixes[namespace], namespace))
+		}
+	}
+	return prefixes, attrPrefixes, declarations
+}
+
+// isDeclaration checks if the attribute is a xmlns declaration
+func isDeclaration(a xml.Attr) bool {
+	return a.Name.Space == `xmlns` || (a.Name.Space == `` && a.Name.Local == `xmlns`)
+}
+
+func declaration(prefix, namespace string) xml.Attr {
+	if prefix == `` {
+		return xml.Attr{Name: xml.Name{Local: `xmlns`}, Value: namespace}
+	}
+	return xml.Attr{Name: xml.Name{Local: `xmlns:` + prefix}, Value: namespace}
+}
+
+func qualifiedName(n xml.Name, prefixes map[string]string) string {
+	if n.Space == xmlNamespace {
+		return `xml:` + n.Local
+	}
+	if prefix := prefixes[n.Space]; prefix != `` {
+		return prefix + `:` + n.Local
+	}
+	return n.Local
+}
+
+var textEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+
+var attrEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`, `"`, `&quot;`, "\t", `&#x9;`, "\n", `&#xA;`, "\r", `&#xD;`)
+
+func writeAttr(buf *bytes.Buffer, name, value string) {
+	buf.WriteByte(' ')
+	buf.WriteString(name)
+	buf.WriteString(`="`)
+	attrEscaper.WriteString(buf, value)
+	buf.WriteByte('"')
+}
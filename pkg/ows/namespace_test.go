@@ -0,0 +1,111 @@
+package ows
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+type testServiceIdentification struct {
+	XMLName  xml.Name  `xml:"http://www.opengis.net/ows/1.1 ServiceIdentification"`
+	Lang     string    `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Title    string    `xml:"http://www.opengis.net/ows/1.1 Title"`
+	Keywords *Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords"`
+	Link     struct {
+		Type string `xml:"http://www.w3.org/1999/xlink type,attr"`
+		Href string `xml:"http://www.w3.org/1999/xlink href,attr"`
+	} `xml:"http://www.opengis.net/ows/1.1 Link"`
+	Empty string `xml:"http://www.opengis.net/ows/1.1 Empty"`
+}
+
+func TestNamespacesMarshal(t *testing.T) {
+	si := testServiceIdentification{Lang: `nl`, Title: `A & B`, Keywords: &Keywords{Keyword: []string{`one`}}}
+	si.Link.Type = `simple`
+	si.Link.Href = `http://localhost/?a=1&b="2"`
+
+	var tests = []struct {
+		namespaces Namespaces
+		result     string
+	}{
+		0: {namespaces: CanonicalNamespaces,
+			result: `<ows:ServiceIdentification xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:xlink="http://www.w3.org/1999/xlink" xml:lang="nl">
+ <ows:Title>A &amp; B</ows:Title>
+ <ows:Keywords>
+  <ows:Keyword>one</ows:Keyword>
+ </ows:Keywords>
+ <ows:Link xlink:type="simple" xlink:href="http://localhost/?a=1&amp;b=&quot;2&quot;"/>
+ <ows:Empty/>
+</ows:ServiceIdentification>`},
+		// OWS as default namespace, that can't be used for the attributes
+		1: {namespaces: Namespaces{OWS11Namespace: ``, XLinkNamespace: ``},
+			result: `<ServiceIdentification xmlns="http://www.opengis.net/ows/1.1" xmlns:ns1="http://www.w3.org/1999/xlink" xml:lang="nl">
+ <Title>A &amp; B</Title>
+ <Keywords>
+  <Keyword>one</Keyword>
+ </Keywords>
+ <Link ns1:type="simple" ns1:href="http://localhost/?a=1&amp;b=&quot;2&quot;"/>
+ <Empty/>
+</ServiceIdentification>`},
+		2: {namespaces: CanonicalNamespaces.With(OWS11Namespace, `o`),
+			result: `<o:ServiceIdentification xmlns:o="http://www.opengis.net/ows/1.1" xmlns:xlink="http://www.w3.org/1999/xlink" xml:lang="nl">
+ <o:Title>A &amp; B</o:Title>
+ <o:Keywords>
+  <o:Keyword>one</o:Keyword>
+ </o:Keywords>
+ <o:Link xlink:type="simple" xlink:href="http://localhost/?a=1&amp;b=&quot;2&quot;"/>
+ <o:Empty/>
+</o:ServiceIdentification>`},
+	}
+
+	for k, test := range tests {
+		b, err := test.namespaces.Marshal(si, ``, ` `)
+		if err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if string(b) != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, string(b))
+		}
+
+		// whatever prefixes are used, the result can be decoded again
+		var result testServiceIdentification
+		if err := xml.Unmarshal(b, &result); err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if result.Title != si.Title || result.Link != si.Link || result.Lang != si.Lang || result.Keywords.Keyword[0] != `one` {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, si, result)
+		}
+	}
+}
+
+func TestNamespacesEncode(t *testing.T) {
+	var tests = []struct {
+		doc    string
+		result string
+	}{
+		// Namespaces that are unknown keep their prefix, declarations are moved to the root element
+		0: {doc: `<?xml version="1.0" encoding="UTF-8"?><x:FeatureTypeList xmlns:x="http://www.opengis.net/wfs/2.0"><x:FeatureType xmlns:topp="http://www.openplans.org/topp"><x:Name>topp:states</x:Name><topp:extra/></x:FeatureType></x:FeatureTypeList>`,
+			result: `<?xml version="1.0" encoding="UTF-8"?><wfs:FeatureTypeList xmlns:wfs="http://www.opengis.net/wfs/2.0" xmlns:topp="http://www.openplans.org/topp"><wfs:FeatureType><wfs:Name>topp:states</wfs:Name><topp:extra/></wfs:FeatureType></wfs:FeatureTypeList>`},
+		// Unknown namespaces without a prefix get a generated one, undeclared prefixes are kept as is
+		1: {doc: `<Capabilities xmlns="http://www.opengis.net/wmts/1.0"><Contents xmlns="http://www.example.com"><ows:Title>title</ows:Title><!-- comment --></Contents></Capabilities>`,
+			result: `<Capabilities xmlns="http://www.opengis.net/wmts/1.0" xmlns:ns1="http://www.example.com"><ns1:Contents><ows:Title>title</ows:Title><!-- comment --></ns1:Contents></Capabilities>`},
+		// The same prefix for different namespaces
+		2: {doc: `<a:root xmlns:a="http://www.opengis.net/gml" xmlns:b="http://www.opengis.net/gml/3.2"><b:child/></a:root>`,
+			result: `<gml:root xmlns:gml="http://www.opengis.net/gml" xmlns:gml1="http://www.opengis.net/gml/3.2"><gml1:child/></gml:root>`},
+	}
+
+	for k, test := range tests {
+		b, err := CanonicalNamespaces.Encode([]byte(test.doc))
+		if err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if string(b) != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, string(b))
+		}
+	}
+
+	if _, err := CanonicalNamespaces.Encode([]byte(`<a><b></a>`)); err == nil {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 3, err)
+	}
+}
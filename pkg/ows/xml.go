@@ -3,6 +3,7 @@ package ows
 import (
 	"encoding/xml"
 	"fmt"
+	"strings"
 )
 
 // XMLAttribute wrapper around the array of xml.Attr
@@ -34,8 +35,11 @@ func (xmlattr *XMLAttribute) UnmarshalXML(d *xml.Decoder, start xml.StartElement
 
 // MarshalXML Position
 func (p *Position) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	s := fmt.Sprintf("%f %f", p[0], p[1])
-	return e.EncodeElement(s, start)
+	var coords []string
+	for _, c := range p.coords() {
+		coords = append(coords, fmt.Sprintf("%f", c))
+	}
+	return e.EncodeElement(strings.Join(coords, ` `), start)
 }
 
 // UnmarshalXML Position
@@ -48,11 +52,7 @@ func (p *Position) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 		switch el := token.(type) {
 		case xml.CharData:
-			coords := getPositionFromString(string([]byte(el)))
-			if len(coords) >= 2 {
-				// take first 2 positions (xy)
-				position = [2]float64{coords[0], coords[1]}
-			}
+			position = getPositionFromString(string([]byte(el)))
 		case xml.EndElement:
 			if el == start.End() {
 				*p = position
@@ -62,17 +62,12 @@ func (p *Position) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	}
 }
 
-// MarshalXML Position
+// MarshalXML CRS
 func (c *CRS) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	var s = ``
-	if c.Namespace != `` {
-		s = fmt.Sprintf("%s:%d", c.Namespace, c.Code)
-	}
-
-	return e.EncodeElement(s, start)
+	return e.EncodeElement(c.String(), start)
 }
 
-// UnmarshalXML Position
+// UnmarshalXML CRS
 func (c *CRS) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var crs CRS
 	for {
@@ -82,7 +77,9 @@ func (c *CRS) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 		switch el := token.(type) {
 		case xml.CharData:
-			crs.parseString(string([]byte(el)))
+			if exception := crs.ParseString(string([]byte(el))); exception != nil {
+				return exception
+			}
 		case xml.EndElement:
 			if el == start.End() {
 				*c = crs
@@ -91,3 +88,99 @@ func (c *CRS) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 	}
 }
+
+// UnmarshalXML BoundingBox
+// The corners are matched on their local name, so both <LowerCorner> and <ows:LowerCorner> are accepted.
+// The corners are given in the axis order of the crs and are converted to x y order.
+func (b *BoundingBox) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var boundingbox BoundingBox
+	for _, attr := range start.Attr {
+		switch localName(attr.Name) {
+		case `crs`:
+			boundingbox.Crs = attr.Value
+		case `dimensions`:
+			boundingbox.Dimensions = attr.Value
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			var err error
+			switch localName(el.Name) {
+			case `LowerCorner`:
+				err = d.DecodeElement(&boundingbox.LowerCorner, &el)
+			case `UpperCorner`:
+				err = d.DecodeElement(&boundingbox.UpperCorner, &el)
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if el == start.End() {
+				if !boundingbox.validDimension() {
+					return InvalidParameterValue(boundingbox.BuildKVP(), `boundingbox`)
+				}
+				// the corners are given in the axis order of the crs
+				*b = boundingbox.ToXY(boundingbox.crs())
+				return nil
+			}
+		}
+	}
+}
+
+// MarshalXML BoundingBox
+// The corners are encoded in the axis order of the crs
+func (b BoundingBox) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// the boundingbox type has no methods, so it's marshalled like a plain struct
+	type boundingbox BoundingBox
+	authority := boundingbox(b.ToAuthority(b.crs()))
+	return e.EncodeElement(&authority, start)
+}
+
+// UnmarshalXML Keywords
+// The keywords are matched on their local name, so both <Keyword> and <ows:Keyword> are accepted
+func (k *Keywords) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var keywords Keywords
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			var err error
+			switch localName(el.Name) {
+			case `Keyword`:
+				var keyword string
+				if err = d.DecodeElement(&keyword, &el); err == nil {
+					keywords.Keyword = append(keywords.Keyword, keyword)
+				}
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if el == start.End() {
+				*k = keywords
+				return nil
+			}
+		}
+	}
+}
+
+// localName returns the name without a prefix that isn't resolved to a namespace
+func localName(n xml.Name) string {
+	if i := strings.LastIndex(n.Local, `:`); i > -1 {
+		return n.Local[i+1:]
+	}
+	return n.Local
+}
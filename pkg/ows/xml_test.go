@@ -56,12 +56,12 @@ func TestUnMarshalXMLBoundingBox(t *testing.T) {
 		<ows:LowerCorner>-180.0 -90.0</ows:LowerCorner>
 		<ows:UpperCorner>180.0 90.0</ows:UpperCorner>
 		</BoundingBox>`,
-			boundingbox: BoundingBox{Crs: "http://www.opengis.net/gml/srs/epsg.xml#4326", LowerCorner: [2]float64{-180.0, -90.0}, UpperCorner: [2]float64{180.0, 90.0}}},
+			boundingbox: BoundingBox{Crs: "http://www.opengis.net/gml/srs/epsg.xml#4326", LowerCorner: Position{-180.0, -90.0}, UpperCorner: Position{180.0, 90.0}}},
 		1: {xmlraw: `<BoundingBox crs="http://www.opengis.net/gml/srs/epsg.xml#4326" dimensions="2">
 			<ows:LowerCorner>-180.0 -90.0</ows:LowerCorner>
 			<ows:UpperCorner>180.0 90.0</ows:UpperCorner>
 			</BoundingBox>`,
-			boundingbox: BoundingBox{Crs: "http://www.opengis.net/gml/srs/epsg.xml#4326", Dimensions: "2", LowerCorner: [2]float64{-180.0, -90.0}, UpperCorner: [2]float64{180.0, 90.0}}},
+			boundingbox: BoundingBox{Crs: "http://www.opengis.net/gml/srs/epsg.xml#4326", Dimensions: "2", LowerCorner: Position{-180.0, -90.0}, UpperCorner: Position{180.0, 90.0}}},
 		2: {xmlraw: `<BoundingBox crs="http://www.opengis.net/gml/srs/epsg.xml#4326" dimensions="2">
 			<ows:LowerCorner/>
 			<ows:UpperCorner/>
@@ -79,6 +79,33 @@ func TestUnMarshalXMLBoundingBox(t *testing.T) {
 			<ows:UpperCorner/>
 			corrupt xml"`,
 			exception: errors.New("XML syntax error on line 4: unexpected EOF")},
+		6: {xmlraw: `<ows:WGS84BoundingBox xmlns:ows="http://www.opengis.net/ows/1.1" dimensions="2">
+			<ows:LowerCorner>3.2 50.7</ows:LowerCorner>
+			<ows:UpperCorner>7.2 53.5</ows:UpperCorner>
+			</ows:WGS84BoundingBox>`,
+			boundingbox: BoundingBox{Dimensions: "2", LowerCorner: Position{3.2, 50.7}, UpperCorner: Position{7.2, 53.5}}},
+		// the corners are given in the northing easting axis order of EPSG:4326
+		7: {xmlraw: `<BoundingBox crs="urn:ogc:def:crs:EPSG::4326">
+			<ows:LowerCorner>50.7 3.2</ows:LowerCorner>
+			<ows:UpperCorner>53.5 7.2</ows:UpperCorner>
+			</BoundingBox>`,
+			boundingbox: BoundingBox{Crs: "urn:ogc:def:crs:EPSG::4326", LowerCorner: Position{3.2, 50.7}, UpperCorner: Position{7.2, 53.5}}},
+		// only the horizontal axes are swapped, the height keeps its place
+		8: {xmlraw: `<BoundingBox crs="urn:ogc:def:crs:EPSG::4979" dimensions="3">
+			<ows:LowerCorner>50.7 3.2 -10</ows:LowerCorner>
+			<ows:UpperCorner>53.5 7.2 50</ows:UpperCorner>
+			</BoundingBox>`,
+			boundingbox: BoundingBox{Crs: "urn:ogc:def:crs:EPSG::4979", Dimensions: "3", LowerCorner: Position{3.2, 50.7, -10}, UpperCorner: Position{7.2, 53.5, 50}}},
+		9: {xmlraw: `<BoundingBox dimensions="3">
+			<ows:LowerCorner>3.2 50.7</ows:LowerCorner>
+			<ows:UpperCorner>7.2 53.5</ows:UpperCorner>
+			</BoundingBox>`,
+			exception: InvalidParameterValue(`3.200000,50.700000,7.200000,53.500000`, `boundingbox`)},
+		10: {xmlraw: `<BoundingBox crs="urn:ogc:def:crs:EPSG::28992">
+			<ows:LowerCorner>120000 480000 -10</ows:LowerCorner>
+			<ows:UpperCorner>121000 480500 50</ows:UpperCorner>
+			</BoundingBox>`,
+			exception: InvalidParameterValue(`120000.000000,480000.000000,-10.000000,121000.000000,480500.000000,50.000000`, `boundingbox`)},
 	}
 	for k, a := range tests {
 		var bbox BoundingBox
@@ -88,20 +115,75 @@ func TestUnMarshalXMLBoundingBox(t *testing.T) {
 			}
 
 		} else {
-			if a.boundingbox != bbox {
+			if !a.boundingbox.Equal(bbox) {
 				t.Errorf("test: %d, expected: %v+,\n got: %v+", k, a.boundingbox, bbox)
 			}
 		}
 	}
 }
 
+func TestMarshalXMLBoundingBox(t *testing.T) {
+	var tests = []struct {
+		boundingbox BoundingBox
+		xml         string
+	}{
+		0: {boundingbox: BoundingBox{Crs: "EPSG:28992", LowerCorner: Position{120000, 480000}, UpperCorner: Position{121000, 480500}},
+			xml: `<BoundingBox crs="EPSG:28992"><LowerCorner>120000.000000 480000.000000</LowerCorner><UpperCorner>121000.000000 480500.000000</UpperCorner></BoundingBox>`},
+		1: {boundingbox: BoundingBox{Crs: "urn:ogc:def:crs:EPSG::4326", LowerCorner: Position{3.2, 50.7}, UpperCorner: Position{7.2, 53.5}},
+			xml: `<BoundingBox crs="urn:ogc:def:crs:EPSG::4326"><LowerCorner>50.700000 3.200000</LowerCorner><UpperCorner>53.500000 7.200000</UpperCorner></BoundingBox>`},
+		2: {boundingbox: BoundingBox{LowerCorner: Position{3.2, 50.7}, UpperCorner: Position{7.2, 53.5}},
+			xml: `<BoundingBox><LowerCorner>3.200000 50.700000</LowerCorner><UpperCorner>7.200000 53.500000</UpperCorner></BoundingBox>`},
+	}
+	for k, a := range tests {
+		d, err := xml.Marshal(a.boundingbox)
+		if err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if string(d) != a.xml {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, a.xml, string(d))
+		}
+	}
+}
+
+func TestUnMarshalXMLKeywords(t *testing.T) {
+	var tests = []struct {
+		xmlraw   string
+		keywords Keywords
+	}{
+		0: {xmlraw: `<Keywords><Keyword>one</Keyword><Keyword>two</Keyword></Keywords>`,
+			keywords: Keywords{Keyword: []string{`one`, `two`}}},
+		1: {xmlraw: `<ows:Keywords xmlns:ows="http://www.opengis.net/ows/1.1"><ows:Keyword>one</ows:Keyword><ows:Type>theme</ows:Type></ows:Keywords>`,
+			keywords: Keywords{Keyword: []string{`one`}}},
+		2: {xmlraw: `<Keywords/>`,
+			keywords: Keywords{}},
+	}
+	for k, a := range tests {
+		var keywords Keywords
+		if err := xml.Unmarshal([]byte(a.xmlraw), &keywords); err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if len(a.keywords.Keyword) != len(keywords.Keyword) {
+			t.Errorf("test: %d, expected: %v+,\n got: %v+", k, a.keywords, keywords)
+			continue
+		}
+		for i := range keywords.Keyword {
+			if a.keywords.Keyword[i] != keywords.Keyword[i] {
+				t.Errorf("test: %d, expected: %v+,\n got: %v+", k, a.keywords, keywords)
+			}
+		}
+	}
+}
+
 func TestMarshalXMLPosition(t *testing.T) {
 	var tests = []struct {
 		position Position
 		xml      string
 	}{
-		0: {position: Position{}, xml: "<Position>0.000000 0.000000</Position>"},
+		0: {position: Position{0, 0}, xml: "<Position>0.000000 0.000000</Position>"},
 		1: {position: Position{-180.0, 90.0}, xml: "<Position>-180.000000 90.000000</Position>"},
+		2: {position: Position{155000, 463000, -4.5}, xml: "<Position>155000.000000 463000.000000 -4.500000</Position>"},
 	}
 	for k, a := range tests {
 		d, err := xml.Marshal(&a.position)
@@ -121,10 +203,11 @@ func TestUnMarshalXMLPosition(t *testing.T) {
 		xml       string
 		exception error
 	}{
-		0: {position: Position{}, xml: "<Position>0.000000 0.000000</Position>", exception: errors.New("")},
+		0: {position: Position{0, 0}, xml: "<Position>0.000000 0.000000</Position>", exception: errors.New("")},
 		1: {position: Position{-180.0, 90.0}, xml: "<Position>-180.000000 90.000000</Position>", exception: errors.New("")},
 		2: {position: Position{}, xml: "<Position/>", exception: errors.New("")},
 		3: {position: Position{}, xml: "EOF", exception: errors.New("EOF")},
+		4: {position: Position{155000, 463000, -4.5}, xml: "<Position>155000 463000 -4.5</Position>", exception: errors.New("")},
 	}
 	for k, a := range tests {
 		var position Position
@@ -134,7 +217,7 @@ func TestUnMarshalXMLPosition(t *testing.T) {
 			}
 
 		} else {
-			if a.position != position {
+			if !a.position.Equal(position) {
 				t.Errorf("test: %d, expected: %v+,\n got: %v+", k, a.position, position)
 			}
 		}
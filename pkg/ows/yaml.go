@@ -8,7 +8,9 @@ func (c *CRS) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	var crs CRS
-	crs.parseString(s)
+	if exception := crs.ParseString(s); exception != nil {
+		return exception
+	}
 
 	*c = crs
 
@@ -16,14 +18,24 @@ func (c *CRS) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 // UnmarshalYAML Position
+// The coordinates are a sequence, like [155000, 463000, 10], or a string, like 155000 463000 10
 func (p *Position) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var coords []float64
+	if err := unmarshal(&coords); err == nil {
+		*p = coords
+		return nil
+	}
+
 	var s string
 	if err := unmarshal(&s); err != nil {
 		return err
 	}
 
 	position := getPositionFromString(s)
-	*p = Position{position[0], position[1]}
+	if len(position) == 0 {
+		return InvalidParameterValue(s, `position`)
+	}
+	*p = position
 
 	return nil
 }
@@ -22,9 +22,9 @@ othercrs:
 		yaml        []byte
 		expectedCrs CRS
 	}{
-		0: {yaml: []byte(stringYAML), expectedCrs: CRS{Code: 4326, Namespace: EPSG}},
-		1: {yaml: []byte(`defaultcrs: urn:ogc:def:crs:EPSG::4326`), expectedCrs: CRS{Code: 4326, Namespace: EPSG}},
-		2: {yaml: []byte(`defaultcrs: EPSG:4326`), expectedCrs: CRS{Code: 4326, Namespace: EPSG}},
+		0: {yaml: []byte(stringYAML), expectedCrs: CRS{Code: `4326`, Namespace: EPSG}},
+		1: {yaml: []byte(`defaultcrs: urn:ogc:def:crs:EPSG::4326`), expectedCrs: CRS{Code: `4326`, Namespace: EPSG}},
+		2: {yaml: []byte(`defaultcrs: EPSG:4326`), expectedCrs: CRS{Code: `4326`, Namespace: EPSG}},
 	}
 	for k, test := range tests {
 		var ftl FeatureType
@@ -47,7 +47,8 @@ func TestUnmarshalYAMLPosition(t *testing.T) {
 	}{
 		0: {positionstring: []byte(`2.52712538742158 50.2128625669452`), expectedposition: Position{2.52712538742158, 50.2128625669452}},
 		1: {positionstring: []byte(`7.37402550506231 55.7211602557705`), expectedposition: Position{7.37402550506231, 55.7211602557705}},
-		2: {positionstring: []byte(`7.37402550506231 55.7211602557705 0 1 2 3`), expectedposition: Position{7.37402550506231, 55.7211602557705}},
+		2: {positionstring: []byte(`7.37402550506231 55.7211602557705 0 1 2 3`), expectedposition: Position{7.37402550506231, 55.7211602557705, 0, 1, 2, 3}},
+		3: {positionstring: []byte(`[155000, 463000, -4.5]`), expectedposition: Position{155000, 463000, -4.5}},
 	}
 
 	for k, test := range tests {
@@ -56,7 +57,7 @@ func TestUnmarshalYAMLPosition(t *testing.T) {
 		if err != nil {
 			t.Errorf("test: %d, yaml.UnMarshal failed with '%s'\n", k, err)
 		} else {
-			if pos != test.expectedposition {
+			if !pos.Equal(test.expectedposition) {
 				t.Errorf("test: %d, expected: %v+,\n got: %v+", k, test.expectedposition, pos)
 			}
 		}
@@ -0,0 +1,125 @@
+package transform
+
+import "math"
+
+// arcSecond in radians
+const arcSecond = math.Pi / (180 * 3600)
+
+// Ellipsoids of the built-in CRSs
+var (
+	EllipsoidWGS84      = Ellipsoid{A: 6378137, InvF: 298.257223563}
+	EllipsoidGRS80      = Ellipsoid{A: 6378137, InvF: 298.257222101}
+	EllipsoidBessel1841 = Ellipsoid{A: 6377397.155, InvF: 299.1528128}
+	// EllipsoidSphere is the sphere with the radius of the WGS84 semi-major axis, as used by Pseudo-Mercator
+	EllipsoidSphere = Ellipsoid{A: 6378137}
+)
+
+// Ellipsoid is defined by the semi-major axis A in meters and the inverse flattening InvF
+// An InvF of 0 is a sphere with radius A
+type Ellipsoid struct {
+	A    float64
+	InvF float64
+}
+
+// F returns the flattening of the Ellipsoid
+func (e Ellipsoid) F() float64 {
+	if e.InvF == 0 {
+		return 0
+	}
+	return 1 / e.InvF
+}
+
+// E2 returns the square of the eccentricity of the Ellipsoid
+func (e Ellipsoid) E2() float64 {
+	f := e.F()
+	return f * (2 - f)
+}
+
+// E returns the eccentricity of the Ellipsoid
+func (e Ellipsoid) E() float64 {
+	return math.Sqrt(e.E2())
+}
+
+// geocentric returns the geocentric X Y Z of the longitude and latitude, in radians, at the height h
+func (e Ellipsoid) geocentric(lon, lat, h float64) (float64, float64, float64) {
+	e2 := e.E2()
+	sinlat := math.Sin(lat)
+	n := e.A / math.Sqrt(1-e2*sinlat*sinlat)
+	return (n + h) * math.Cos(lat) * math.Cos(lon),
+		(n + h) * math.Cos(lat) * math.Sin(lon),
+		(n*(1-e2) + h) * sinlat
+}
+
+// geodetic returns the longitude and latitude, in radians, and the height of the geocentric X Y Z
+func (e Ellipsoid) geodetic(x, y, z float64) (float64, float64, float64) {
+	e2 := e.E2()
+	p := math.Hypot(x, y)
+	lon := math.Atan2(y, x)
+	lat := math.Atan2(z, p*(1-e2))
+	var h float64
+	// converges within a few iterations for positions near the surface
+	for i := 0; i < 10; i++ {
+		sinlat := math.Sin(lat)
+		n := e.A / math.Sqrt(1-e2*sinlat*sinlat)
+		h = p/math.Cos(lat) - n
+		lat = math.Atan2(z, p*(1-e2*n/(n+h)))
+	}
+	return lon, lat, h
+}
+
+// Helmert are the 7 parameters of a datum shift to WGS84 using the position vector convention (EPSG:9606), like the PROJ towgs84 parameter
+// The translations are in meters, the rotations in arc-seconds and the scale in parts per million
+type Helmert struct {
+	Tx, Ty, Tz float64
+	Rx, Ry, Rz float64
+	S          float64
+}
+
+// forward applies the Helmert transformation to the geocentric X Y Z
+func (h Helmert) forward(x, y, z float64) (float64, float64, float64) {
+	rx, ry, rz := h.Rx*arcSecond, h.Ry*arcSecond, h.Rz*arcSecond
+	m := 1 + h.S*1e-6
+	return h.Tx + m*(x-rz*y+ry*z),
+		h.Ty + m*(rz*x+y-rx*z),
+		h.Tz + m*(-ry*x+rx*y+z)
+}
+
+// inverse applies the reverse Helmert transformation to the geocentric X Y Z
+// The rotation matrix I + W, with W the cross product with the rotation vector w, has the inverse (I - W + w w') / (1 + |w|^2)
+func (h Helmert) inverse(x, y, z float64) (float64, float64, float64) {
+	rx, ry, rz := h.Rx*arcSecond, h.Ry*arcSecond, h.Rz*arcSecond
+	m := 1 + h.S*1e-6
+	ux, uy, uz := (x-h.Tx)/m, (y-h.Ty)/m, (z-h.Tz)/m
+
+	dot := rx*ux + ry*uy + rz*uz
+	norm := 1 + rx*rx + ry*ry + rz*rz
+	return (ux - (ry*uz - rz*uy) + rx*dot) / norm,
+		(uy - (rz*ux - rx*uz) + ry*dot) / norm,
+		(uz - (rx*uy - ry*ux) + rz*dot) / norm
+}
+
+// Datum is the Ellipsoid of a geodetic datum and its shift to WGS84
+type Datum struct {
+	Ellipsoid Ellipsoid
+	ToWGS84   Helmert
+}
+
+// Datums of the built-in CRSs
+var (
+	DatumWGS84  = Datum{Ellipsoid: EllipsoidWGS84}
+	DatumETRS89 = Datum{Ellipsoid: EllipsoidGRS80}
+	// DatumAmersfoort with the shift of EPSG:15739 (Amersfoort to WGS 84 (3))
+	DatumAmersfoort = Datum{Ellipsoid: EllipsoidBessel1841, ToWGS84: Helmert{Tx: 565.417, Ty: 50.3319, Tz: 465.552, Rx: -0.398957, Ry: 0.343988, Rz: -1.8774, S: 4.0725}}
+)
+
+// shift returns the longitude and latitude, in radians, given in the datum from in the datum to
+func shift(lon, lat float64, from, to Datum) (float64, float64) {
+	if from == to {
+		return lon, lat
+	}
+	x, y, z := from.Ellipsoid.geocentric(lon, lat, 0)
+	x, y, z = from.ToWGS84.forward(x, y, z)
+	x, y, z = to.ToWGS84.inverse(x, y, z)
+	lon, lat, _ = to.Ellipsoid.geodetic(x, y, z)
+	return lon, lat
+}
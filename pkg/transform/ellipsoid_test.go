@@ -0,0 +1,45 @@
+package transform
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEllipsoidGeocentric(t *testing.T) {
+	var tests = []struct {
+		ellipsoid Ellipsoid
+		lon, lat  float64
+		x, y, z   float64
+	}{
+		0: {ellipsoid: EllipsoidWGS84, x: 6378137},
+		1: {ellipsoid: EllipsoidWGS84, lat: 90, z: 6356752.314245},
+		2: {ellipsoid: EllipsoidSphere, lon: 90, y: 6378137},
+		3: {ellipsoid: EllipsoidBessel1841, lon: 5.387638889, lat: 52.156160556, x: 3903453.148, y: 368135.313, z: 5012970.306},
+	}
+
+	for k, test := range tests {
+		x, y, z := test.ellipsoid.geocentric(radians(test.lon), radians(test.lat), 0)
+		if math.Abs(x-test.x) > 0.001 || math.Abs(y-test.y) > 0.001 || math.Abs(z-test.z) > 0.001 {
+			t.Errorf("test: %d, expected: %f %f %f,\n got: %f %f %f", k, test.x, test.y, test.z, x, y, z)
+		}
+		lon, lat, h := test.ellipsoid.geodetic(x, y, z)
+		if math.Abs(degrees(lon)-test.lon) > 1e-9 || math.Abs(degrees(lat)-test.lat) > 1e-9 || math.Abs(h) > 0.001 {
+			t.Errorf("test: %d, expected: %f %f,\n got: %f %f %f", k, test.lon, test.lat, degrees(lon), degrees(lat), h)
+		}
+	}
+}
+
+func TestHelmert(t *testing.T) {
+	h := DatumAmersfoort.ToWGS84
+	x, y, z := 3903453.148, 368135.313, 5012970.306
+
+	ix, iy, iz := h.inverse(h.forward(x, y, z))
+	if math.Abs(ix-x) > 1e-6 || math.Abs(iy-y) > 1e-6 || math.Abs(iz-z) > 1e-6 {
+		t.Errorf("test: %d, expected: %f %f %f,\n got: %f %f %f", 0, x, y, z, ix, iy, iz)
+	}
+
+	// without parameters nothing changes
+	if fx, fy, fz := (Helmert{}).forward(x, y, z); fx != x || fy != y || fz != z {
+		t.Errorf("test: %d, expected: %f %f %f,\n got: %f %f %f", 1, x, y, z, fx, fy, fz)
+	}
+}
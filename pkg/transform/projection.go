@@ -0,0 +1,305 @@
+package transform
+
+import "math"
+
+// maxIterations of the iterative inverse projections
+const maxIterations = 15
+
+// Projection converts between the longitude and latitude, in degrees, and the x y of a CRS
+type Projection interface {
+	Forward(lon, lat float64) (float64, float64)
+	Inverse(x, y float64) (float64, float64)
+}
+
+// Geographic is the Projection of a geographic CRS, the x y are the longitude and latitude
+type Geographic struct{}
+
+// Forward Geographic
+func (Geographic) Forward(lon, lat float64) (float64, float64) {
+	return lon, lat
+}
+
+// Inverse Geographic
+func (Geographic) Inverse(x, y float64) (float64, float64) {
+	return x, y
+}
+
+// TransverseMercator (EPSG:9807) using the JHS formulas of the EPSG Guidance Note 7-2
+type TransverseMercator struct {
+	Ellipsoid     Ellipsoid
+	Lat0, Lon0    float64
+	K0            float64
+	FalseEasting  float64
+	FalseNorthing float64
+}
+
+// series returns the rectifying radius B and the coefficients of the forward and inverse series
+func (p TransverseMercator) series() (float64, [4]float64, [4]float64) {
+	n := p.Ellipsoid.F() / (2 - p.Ellipsoid.F())
+	n2, n3, n4 := n*n, n*n*n, n*n*n*n
+	b := p.Ellipsoid.A / (1 + n) * (1 + n2/4 + n4/64)
+	forward := [4]float64{
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180,
+		13*n2/48 - 3*n3/5 + 557*n4/1440,
+		61*n3/240 - 103*n4/140,
+		49561 * n4 / 161280,
+	}
+	inverse := [4]float64{
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360,
+		n2/48 + n3/15 - 437*n4/1440,
+		17*n3/480 - 37*n4/840,
+		4397 * n4 / 161280,
+	}
+	return b, forward, inverse
+}
+
+// meridian returns the meridional arc of the latitude, in radians, divided by B
+func (p TransverseMercator) meridian(lat float64, forward [4]float64) float64 {
+	if lat == 0 {
+		return 0
+	}
+	e := p.Ellipsoid.E()
+	beta := math.Atan(math.Sinh(math.Asinh(math.Tan(lat)) - e*math.Atanh(e*math.Sin(lat))))
+	xi := beta
+	for i, h := range forward {
+		xi += h * math.Sin(float64(2*(i+1))*beta)
+	}
+	return xi
+}
+
+// Forward TransverseMercator
+func (p TransverseMercator) Forward(lon, lat float64) (float64, float64) {
+	b, forward, _ := p.series()
+	e := p.Ellipsoid.E()
+	phi, dlambda := radians(lat), radians(lon-p.Lon0)
+
+	beta := math.Atan(math.Sinh(math.Asinh(math.Tan(phi)) - e*math.Atanh(e*math.Sin(phi))))
+	eta0 := math.Atanh(math.Cos(beta) * math.Sin(dlambda))
+	xi0 := math.Asin(math.Sin(beta) * math.Cosh(eta0))
+	xi, eta := xi0, eta0
+	for i, h := range forward {
+		k := float64(2 * (i + 1))
+		xi += h * math.Sin(k*xi0) * math.Cosh(k*eta0)
+		eta += h * math.Cos(k*xi0) * math.Sinh(k*eta0)
+	}
+
+	m0 := p.meridian(radians(p.Lat0), forward)
+	return p.FalseEasting + p.K0*b*eta, p.FalseNorthing + p.K0*b*(xi-m0)
+}
+
+// Inverse TransverseMercator
+func (p TransverseMercator) Inverse(x, y float64) (float64, float64) {
+	b, forward, inverse := p.series()
+	e := p.Ellipsoid.E()
+
+	m0 := p.meridian(radians(p.Lat0), forward)
+	eta := (x - p.FalseEasting) / (b * p.K0)
+	xi := (y-p.FalseNorthing)/(b*p.K0) + m0
+	xi0, eta0 := xi, eta
+	for i, h := range inverse {
+		k := float64(2 * (i + 1))
+		xi0 -= h * math.Sin(k*xi) * math.Cosh(k*eta)
+		eta0 -= h * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	beta := math.Asin(math.Sin(xi0) / math.Cosh(eta0))
+	q := math.Asinh(math.Tan(beta))
+	qq := q
+	for i := 0; i < maxIterations; i++ {
+		next := q + e*math.Atanh(e*math.Tanh(qq))
+		if math.Abs(next-qq) < 1e-14 {
+			qq = next
+			break
+		}
+		qq = next
+	}
+	return p.Lon0 + degrees(math.Asin(math.Tanh(eta0)/math.Cos(beta))), degrees(math.Atan(math.Sinh(qq)))
+}
+
+// UTM returns the TransverseMercator of the UTM zone, on the southern hemisphere with a false northing of 10000000
+func UTM(e Ellipsoid, zone int, south bool) TransverseMercator {
+	p := TransverseMercator{Ellipsoid: e, Lon0: float64(zone*6 - 183), K0: 0.9996, FalseEasting: 500000}
+	if south {
+		p.FalseNorthing = 10000000
+	}
+	return p
+}
+
+// ObliqueStereographic (EPSG:9809) as used by the Dutch RD New
+type ObliqueStereographic struct {
+	Ellipsoid     Ellipsoid
+	Lat0, Lon0    float64
+	K0            float64
+	FalseEasting  float64
+	FalseNorthing float64
+}
+
+// conformal returns the radius of the conformal sphere, the constants n and c and the conformal latitude of the origin
+func (p ObliqueStereographic) conformal() (float64, float64, float64, float64) {
+	e, e2 := p.Ellipsoid.E(), p.Ellipsoid.E2()
+	phi0 := radians(p.Lat0)
+	sin0, cos0 := math.Sin(phi0), math.Cos(phi0)
+
+	rho0 := p.Ellipsoid.A * (1 - e2) / math.Pow(1-e2*sin0*sin0, 1.5)
+	nu0 := p.Ellipsoid.A / math.Sqrt(1-e2*sin0*sin0)
+	r := math.Sqrt(rho0 * nu0)
+	n := math.Sqrt(1 + e2*math.Pow(cos0, 4)/(1-e2))
+
+	w1 := math.Pow((1+sin0)/(1-sin0)*math.Pow((1-e*sin0)/(1+e*sin0), e), n)
+	sinchi0 := (w1 - 1) / (w1 + 1)
+	c := (n + sin0) * (1 - sinchi0) / ((n - sin0) * (1 + sinchi0))
+	w2 := c * w1
+	chi0 := math.Asin((w2 - 1) / (w2 + 1))
+	return r, n, c, chi0
+}
+
+// Forward ObliqueStereographic
+func (p ObliqueStereographic) Forward(lon, lat float64) (float64, float64) {
+	r, n, c, chi0 := p.conformal()
+	e := p.Ellipsoid.E()
+	phi := radians(lat)
+	sin := math.Sin(phi)
+
+	dlambda := n * radians(lon-p.Lon0)
+	w := c * math.Pow((1+sin)/(1-sin)*math.Pow((1-e*sin)/(1+e*sin), e), n)
+	chi := math.Asin((w - 1) / (w + 1))
+	b := 1 + math.Sin(chi)*math.Sin(chi0) + math.Cos(chi)*math.Cos(chi0)*math.Cos(dlambda)
+
+	return p.FalseEasting + 2*r*p.K0*math.Cos(chi)*math.Sin(dlambda)/b,
+		p.FalseNorthing + 2*r*p.K0*(math.Sin(chi)*math.Cos(chi0)-math.Cos(chi)*math.Sin(chi0)*math.Cos(dlambda))/b
+}
+
+// Inverse ObliqueStereographic
+func (p ObliqueStereographic) Inverse(x, y float64) (float64, float64) {
+	r, n, c, chi0 := p.conformal()
+	e, e2 := p.Ellipsoid.E(), p.Ellipsoid.E2()
+	dx, dy := x-p.FalseEasting, y-p.FalseNorthing
+
+	g := 2 * r * p.K0 * math.Tan(math.Pi/4-chi0/2)
+	h := 4*r*p.K0*math.Tan(chi0) + g
+	i := math.Atan(dx / (h + dy))
+	j := math.Atan(dx/(g-dy)) - i
+	chi := chi0 + 2*math.Atan((dy-dx*math.Tan(j/2))/(2*r*p.K0))
+	dlambda := j + 2*i
+
+	psi := 0.5 * math.Log((1+math.Sin(chi))/(c*(1-math.Sin(chi)))) / n
+	phi := 2*math.Atan(math.Exp(psi)) - math.Pi/2
+	for k := 0; k < maxIterations; k++ {
+		sin := math.Sin(phi)
+		psii := math.Log(math.Tan(phi/2+math.Pi/4) * math.Pow((1-e*sin)/(1+e*sin), e/2))
+		next := phi - (psii-psi)*math.Cos(phi)*(1-e2*sin*sin)/(1-e2)
+		if math.Abs(next-phi) < 1e-14 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+	return p.Lon0 + degrees(dlambda/n), degrees(phi)
+}
+
+// Mercator (EPSG:9804) variant A
+// On the EllipsoidSphere, with the longitude and latitude of the WGS84 datum, it's the Pseudo-Mercator (EPSG:1024) of EPSG:3857
+type Mercator struct {
+	Ellipsoid     Ellipsoid
+	Lon0          float64
+	K0            float64
+	FalseEasting  float64
+	FalseNorthing float64
+}
+
+// Forward Mercator
+func (p Mercator) Forward(lon, lat float64) (float64, float64) {
+	e := p.Ellipsoid.E()
+	phi := radians(lat)
+	sin := math.Sin(phi)
+	ak := p.Ellipsoid.A * p.K0
+	return p.FalseEasting + ak*radians(lon-p.Lon0),
+		p.FalseNorthing + ak*math.Log(math.Tan(math.Pi/4+phi/2)*math.Pow((1-e*sin)/(1+e*sin), e/2))
+}
+
+// Inverse Mercator
+func (p Mercator) Inverse(x, y float64) (float64, float64) {
+	e := p.Ellipsoid.E()
+	ak := p.Ellipsoid.A * p.K0
+	t := math.Exp((p.FalseNorthing - y) / ak)
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < maxIterations; i++ {
+		sin := math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-e*sin)/(1+e*sin), e/2))
+		if math.Abs(next-phi) < 1e-14 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+	return p.Lon0 + degrees((x-p.FalseEasting)/ak), degrees(phi)
+}
+
+// LambertAzimuthalEqualArea (EPSG:9820) as used by the European EPSG:3035
+type LambertAzimuthalEqualArea struct {
+	Ellipsoid     Ellipsoid
+	Lat0, Lon0    float64
+	FalseEasting  float64
+	FalseNorthing float64
+}
+
+// q returns the authalic q of the latitude, in radians
+func (p LambertAzimuthalEqualArea) q(phi float64) float64 {
+	e, e2 := p.Ellipsoid.E(), p.Ellipsoid.E2()
+	sin := math.Sin(phi)
+	if e == 0 {
+		return 2 * sin
+	}
+	return (1 - e2) * (sin/(1-e2*sin*sin) - 1/(2*e)*math.Log((1-e*sin)/(1+e*sin)))
+}
+
+// authalic returns the radius Rq, the constant D and the authalic latitude of the origin
+func (p LambertAzimuthalEqualArea) authalic() (float64, float64, float64) {
+	e2 := p.Ellipsoid.E2()
+	phi0 := radians(p.Lat0)
+	qp := p.q(math.Pi / 2)
+	beta0 := math.Asin(p.q(phi0) / qp)
+	rq := p.Ellipsoid.A * math.Sqrt(qp/2)
+	d := p.Ellipsoid.A * (math.Cos(phi0) / math.Sqrt(1-e2*math.Sin(phi0)*math.Sin(phi0))) / (rq * math.Cos(beta0))
+	return rq, d, beta0
+}
+
+// Forward LambertAzimuthalEqualArea
+func (p LambertAzimuthalEqualArea) Forward(lon, lat float64) (float64, float64) {
+	rq, d, beta0 := p.authalic()
+	beta := math.Asin(p.q(radians(lat)) / p.q(math.Pi/2))
+	dlambda := radians(lon - p.Lon0)
+
+	b := rq * math.Sqrt(2/(1+math.Sin(beta0)*math.Sin(beta)+math.Cos(beta0)*math.Cos(beta)*math.Cos(dlambda)))
+	return p.FalseEasting + b*d*math.Cos(beta)*math.Sin(dlambda),
+		p.FalseNorthing + b/d*(math.Cos(beta0)*math.Sin(beta)-math.Sin(beta0)*math.Cos(beta)*math.Cos(dlambda))
+}
+
+// Inverse LambertAzimuthalEqualArea
+func (p LambertAzimuthalEqualArea) Inverse(x, y float64) (float64, float64) {
+	rq, d, beta0 := p.authalic()
+	e2 := p.Ellipsoid.E2()
+	dx, dy := x-p.FalseEasting, y-p.FalseNorthing
+
+	rho := math.Hypot(dx/d, d*dy)
+	if rho == 0 {
+		return p.Lon0, p.Lat0
+	}
+	c := 2 * math.Asin(rho/(2*rq))
+	beta := math.Asin(math.Cos(c)*math.Sin(beta0) + d*dy*math.Sin(c)*math.Cos(beta0)/rho)
+	dlambda := math.Atan2(dx*math.Sin(c), d*rho*math.Cos(beta0)*math.Cos(c)-d*d*dy*math.Sin(beta0)*math.Sin(c))
+
+	e4, e6 := e2*e2, e2*e2*e2
+	phi := beta + (e2/3+31*e4/180+517*e6/5040)*math.Sin(2*beta) +
+		(23*e4/360+251*e6/3780)*math.Sin(4*beta) +
+		(761*e6/45360)*math.Sin(6*beta)
+	return p.Lon0 + degrees(dlambda), degrees(phi)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
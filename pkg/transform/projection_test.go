@@ -0,0 +1,65 @@
+package transform
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProjection(t *testing.T) {
+	var tests = []struct {
+		projection Projection
+		lon, lat   float64
+		x, y       float64
+	}{
+		// the examples of the EPSG Guidance Note 7-2
+		// OSGB 1936 / British National Grid
+		0: {projection: TransverseMercator{Ellipsoid: Ellipsoid{A: 6377563.396, InvF: 299.3249646}, Lat0: 49, Lon0: -2, K0: 0.9996012717, FalseEasting: 400000, FalseNorthing: -100000},
+			lon: 0.5, lat: 50.5, x: 577274.99, y: 69740.50},
+		// Amersfoort / RD New
+		1: {projection: ObliqueStereographic{Ellipsoid: EllipsoidBessel1841, Lat0: 52.15616055555555, Lon0: 5.38763888888889, K0: 0.9999079, FalseEasting: 155000, FalseNorthing: 463000},
+			lon: 6, lat: 53, x: 196105.283, y: 557057.739},
+		// Makassar / NEIEZ
+		2: {projection: Mercator{Ellipsoid: EllipsoidBessel1841, Lon0: 110, K0: 0.997, FalseEasting: 3900000, FalseNorthing: 900000},
+			lon: 120, lat: -3, x: 5009726.58, y: 569150.82},
+		// WGS 84 / Pseudo-Mercator
+		3: {projection: Mercator{Ellipsoid: EllipsoidSphere, K0: 1},
+			lon: -100.33333333333333, lat: 24.381786944444444, x: -11169055.58, y: 2800000.00},
+		// ETRS89-extended / LAEA Europe
+		4: {projection: LambertAzimuthalEqualArea{Ellipsoid: EllipsoidGRS80, Lat0: 52, Lon0: 10, FalseEasting: 4321000, FalseNorthing: 3210000},
+			lon: 5, lat: 50, x: 3962799.45, y: 2999718.85},
+		// WGS 84 / UTM zone 31N
+		5: {projection: UTM(EllipsoidWGS84, 31, false), lon: 3, lat: 0, x: 500000, y: 0},
+		6: {projection: Geographic{}, lon: 5.3, lat: 52.1, x: 5.3, y: 52.1},
+	}
+
+	for k, test := range tests {
+		x, y := test.projection.Forward(test.lon, test.lat)
+		if math.Abs(x-test.x) > 0.01 || math.Abs(y-test.y) > 0.01 {
+			t.Errorf("test: %d, expected: %f %f,\n got: %f %f", k, test.x, test.y, x, y)
+		}
+		lon, lat := test.projection.Inverse(test.x, test.y)
+		if math.Abs(lon-test.lon) > 1e-7 || math.Abs(lat-test.lat) > 1e-7 {
+			t.Errorf("test: %d, expected: %f %f,\n got: %f %f", k, test.lon, test.lat, lon, lat)
+		}
+	}
+}
+
+func TestUTM(t *testing.T) {
+	var tests = []struct {
+		zone  int
+		south bool
+		lon0  float64
+		fn    float64
+	}{
+		0: {zone: 1, lon0: -177},
+		1: {zone: 31, lon0: 3},
+		2: {zone: 60, south: true, lon0: 177, fn: 10000000},
+	}
+
+	for k, test := range tests {
+		p := UTM(EllipsoidWGS84, test.zone, test.south)
+		if p.Lon0 != test.lon0 || p.FalseNorthing != test.fn || p.K0 != 0.9996 || p.FalseEasting != 500000 {
+			t.Errorf("test: %d, expected: %f %f,\n got: %f %f", k, test.lon0, test.fn, p.Lon0, p.FalseNorthing)
+		}
+	}
+}
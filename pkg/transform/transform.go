@@ -0,0 +1,174 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// Segments is the number of segments every edge of a BoundingBox is divided in before it's transformed,
+// so the curvature of the edges in the target CRS is part of the transformed extent
+const Segments = 20
+
+// CRSs of the built-in Definitions
+var (
+	WGS84  = ows.CRS{Namespace: ows.EPSG, Code: `4326`}
+	ETRS89 = ows.CRS{Namespace: ows.EPSG, Code: `4258`}
+	RDNew  = ows.CRS{Namespace: ows.EPSG, Code: `28992`}
+)
+
+// Definition of a CRS, the Projection works on the longitude and latitude of the Datum
+type Definition struct {
+	Datum      Datum
+	Projection Projection
+}
+
+var (
+	mutex       sync.RWMutex
+	definitions = map[ows.CRS]Definition{
+		ows.CRS84:                           {Datum: DatumWGS84, Projection: Geographic{}},
+		WGS84:                               {Datum: DatumWGS84, Projection: Geographic{}},
+		ETRS89:                              {Datum: DatumETRS89, Projection: Geographic{}},
+		{Namespace: ows.EPSG, Code: `4289`}: {Datum: DatumAmersfoort, Projection: Geographic{}},
+		RDNew:                               {Datum: DatumAmersfoort, Projection: ObliqueStereographic{Ellipsoid: EllipsoidBessel1841, Lat0: 52.15616055555555, Lon0: 5.38763888888889, K0: 0.9999079, FalseEasting: 155000, FalseNorthing: 463000}},
+		{Namespace: ows.EPSG, Code: `3857`}: {Datum: DatumWGS84, Projection: Mercator{Ellipsoid: EllipsoidSphere, K0: 1}},
+		{Namespace: ows.EPSG, Code: `3395`}: {Datum: DatumWGS84, Projection: Mercator{Ellipsoid: EllipsoidWGS84, K0: 1}},
+		{Namespace: ows.EPSG, Code: `3035`}: {Datum: DatumETRS89, Projection: LambertAzimuthalEqualArea{Ellipsoid: EllipsoidGRS80, Lat0: 52, Lon0: 10, FalseEasting: 4321000, FalseNorthing: 3210000}},
+	}
+)
+
+func init() {
+	for zone := 1; zone <= 60; zone++ {
+		definitions[ows.CRS{Namespace: ows.EPSG, Code: strconv.Itoa(32600 + zone)}] = Definition{Datum: DatumWGS84, Projection: UTM(EllipsoidWGS84, zone, false)}
+		definitions[ows.CRS{Namespace: ows.EPSG, Code: strconv.Itoa(32700 + zone)}] = Definition{Datum: DatumWGS84, Projection: UTM(EllipsoidWGS84, zone, true)}
+	}
+	// ETRS89 / UTM zone 28N up to 38N
+	for zone := 28; zone <= 38; zone++ {
+		definitions[ows.CRS{Namespace: ows.EPSG, Code: strconv.Itoa(25800 + zone)}] = Definition{Datum: DatumETRS89, Projection: UTM(EllipsoidGRS80, zone, false)}
+	}
+}
+
+// Register adds the Definition of a CRS, so it can be used in a transformation
+// An already defined CRS can't be registered again
+func Register(crs ows.CRS, d Definition) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := definitions[crs]; ok {
+		return fmt.Errorf("CRS %s is already registered", crs.String())
+	}
+	definitions[crs] = d
+	return nil
+}
+
+// Get returns the Definition of the CRS
+func Get(crs ows.CRS) (Definition, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	d, ok := definitions[crs]
+	return d, ok
+}
+
+// Transform returns the Position, given in x y order in the CRS from, in x y order in the CRS to
+// Only the horizontal axes are transformed, the other coordinates like the height are kept
+func Transform(p ows.Position, from, to ows.CRS) (ows.Position, error) {
+	f, t, err := get(from, to)
+	if err != nil {
+		return ows.Position{}, err
+	}
+	return transform(p, f, t, from, to)
+}
+
+// TransformBoundingBox returns the extent of the BoundingBox, given in x y order in the CRS from, in x y order in the CRS to
+// The edges are densified with Segments, so the extent also covers the edges that become curves in the CRS to
+func TransformBoundingBox(b ows.BoundingBox, from, to ows.CRS) (ows.BoundingBox, error) {
+	f, t, err := get(from, to)
+	if err != nil {
+		return ows.BoundingBox{}, err
+	}
+	if len(b.LowerCorner) < 2 || len(b.UpperCorner) < 2 {
+		return ows.BoundingBox{}, errors.New(`the corners of the BoundingBox have no x and y`)
+	}
+
+	lower := ows.Position{math.Inf(1), math.Inf(1)}
+	upper := ows.Position{math.Inf(-1), math.Inf(-1)}
+	for _, p := range densify(b) {
+		tp, err := transform(p, f, t, from, to)
+		if err != nil {
+			return ows.BoundingBox{}, err
+		}
+		lower = ows.Position{math.Min(lower[0], tp[0]), math.Min(lower[1], tp[1])}
+		upper = ows.Position{math.Max(upper[0], tp[0]), math.Max(upper[1], tp[1])}
+	}
+	if len(b.LowerCorner) > 2 && len(b.UpperCorner) > 2 {
+		lower = append(lower, b.LowerCorner[2:]...)
+		upper = append(upper, b.UpperCorner[2:]...)
+	}
+	return ows.BoundingBox{Crs: to.String(), Dimensions: b.Dimensions, LowerCorner: lower, UpperCorner: upper}, nil
+}
+
+// GeographicBoundingBox returns the extent of the BoundingBox, given in the CRS from, in WGS84 longitude and latitude
+// as used by the EX_GeographicBoundingBox and the WGS84BoundingBox of the capabilities
+func GeographicBoundingBox(b ows.BoundingBox, from ows.CRS) (ows.BoundingBox, error) {
+	bbox, err := TransformBoundingBox(b, from, ows.CRS84)
+	bbox.Crs = ``
+	return bbox, err
+}
+
+// get returns the Definitions of both CRSs
+func get(from, to ows.CRS) (Definition, Definition, error) {
+	f, ok := Get(from)
+	if !ok {
+		return Definition{}, Definition{}, fmt.Errorf("CRS %s is not supported", from.String())
+	}
+	t, ok := Get(to)
+	if !ok {
+		return Definition{}, Definition{}, fmt.Errorf("CRS %s is not supported", to.String())
+	}
+	return f, t, nil
+}
+
+// transform the Position from the Definition f to the Definition t
+func transform(p ows.Position, f, t Definition, from, to ows.CRS) (ows.Position, error) {
+	if len(p) < 2 {
+		return ows.Position{}, fmt.Errorf("position %v has no x and y", p)
+	}
+	lon, lat := f.Projection.Inverse(p[0], p[1])
+	if f.Datum != t.Datum {
+		l, b := shift(radians(lon), radians(lat), f.Datum, t.Datum)
+		lon, lat = degrees(l), degrees(b)
+	}
+	x, y := t.Projection.Forward(lon, lat)
+
+	for _, v := range []float64{x, y} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ows.Position{}, fmt.Errorf("position %v in %s can't be transformed to %s", p, from.String(), to.String())
+		}
+	}
+	return append(ows.Position{x, y}, p[2:]...), nil
+}
+
+// densify returns the Positions along the edges of the BoundingBox
+func densify(b ows.BoundingBox) []ows.Position {
+	lower, upper := b.LowerCorner.XY(), b.UpperCorner.XY()
+	minx, miny := lower[0], lower[1]
+	maxx, maxy := upper[0], upper[1]
+	dx, dy := (maxx-minx)/Segments, (maxy-miny)/Segments
+
+	positions := make([]ows.Position, 0, 4*Segments)
+	for i := 0; i < Segments; i++ {
+		f := float64(i)
+		positions = append(positions,
+			ows.Position{minx + f*dx, miny},
+			ows.Position{maxx, miny + f*dy},
+			ows.Position{maxx - f*dx, maxy},
+			ows.Position{minx, maxy - f*dy},
+		)
+	}
+	return positions
+}
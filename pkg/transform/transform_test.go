@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+func equalPosition(a, b ows.Position, tolerance float64) bool {
+	if len(a) < 2 || len(b) < 2 {
+		return false
+	}
+	return math.Abs(a[0]-b[0]) <= tolerance && math.Abs(a[1]-b[1]) <= tolerance
+}
+
+func TestTransform(t *testing.T) {
+	webmercator := ows.CRS{Namespace: ows.EPSG, Code: `3857`}
+	utm31n := ows.CRS{Namespace: ows.EPSG, Code: `32631`}
+	etrs89utm31n := ows.CRS{Namespace: ows.EPSG, Code: `25831`}
+
+	var tests = []struct {
+		position  ows.Position
+		from, to  ows.CRS
+		expected  ows.Position
+		tolerance float64
+	}{
+		// the origin of RD New, the datum shift is accurate to about a meter
+		0: {position: ows.Position{155000, 463000}, from: RDNew, to: WGS84, expected: ows.Position{5.38720621, 52.15517440}, tolerance: 1e-5},
+		1: {position: ows.Position{5.38720621, 52.15517440}, from: ows.CRS84, to: RDNew, expected: ows.Position{155000, 463000}, tolerance: 1},
+		2: {position: ows.Position{-100.33333333333333, 24.381786944444444}, from: WGS84, to: webmercator, expected: ows.Position{-11169055.58, 2800000.00}, tolerance: 0.01},
+		3: {position: ows.Position{500000, 0}, from: utm31n, to: WGS84, expected: ows.Position{3, 0}, tolerance: 1e-9},
+		// ETRS89 and WGS84 are the same within the accuracy of the transformation
+		4: {position: ows.Position{500000, 5760000}, from: etrs89utm31n, to: utm31n, expected: ows.Position{500000, 5760000}, tolerance: 0.01},
+		5: {position: ows.Position{5, 52}, from: ETRS89, to: ETRS89, expected: ows.Position{5, 52}, tolerance: 0},
+	}
+
+	for k, test := range tests {
+		p, err := Transform(test.position, test.from, test.to)
+		if err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if !equalPosition(p, test.expected, test.tolerance) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.expected, p)
+		}
+	}
+}
+
+func TestTransformHeight(t *testing.T) {
+	p, err := Transform(ows.Position{155000, 463000, 43.2}, RDNew, WGS84)
+	if err != nil {
+		t.Fatalf("expected no error,\n got: %s", err.Error())
+	}
+	if len(p) != 3 || p[2] != 43.2 {
+		t.Errorf("test: %d, expected: %f,\n got: %v", 0, 43.2, p)
+	}
+
+	bbox, err := TransformBoundingBox(ows.BoundingBox{LowerCorner: ows.Position{120000, 480000, -10}, UpperCorner: ows.Position{121000, 480500, 50}}, RDNew, WGS84)
+	if err != nil {
+		t.Fatalf("expected no error,\n got: %s", err.Error())
+	}
+	if len(bbox.LowerCorner) != 3 || bbox.LowerCorner[2] != -10 || len(bbox.UpperCorner) != 3 || bbox.UpperCorner[2] != 50 {
+		t.Errorf("test: %d, expected: %f %f,\n got: %v", 1, -10.0, 50.0, bbox)
+	}
+}
+
+func TestTransformNoXY(t *testing.T) {
+	var tests = []struct {
+		position ows.Position
+		err      string
+	}{
+		0: {position: ows.Position{}, err: `position [] has no x and y`},
+		1: {position: ows.Position{155000}, err: `position [155000] has no x and y`},
+	}
+
+	for k, test := range tests {
+		if _, err := Transform(test.position, RDNew, WGS84); err == nil || err.Error() != test.err {
+			t.Errorf("test: %d, expected: %s,\n got: %v", k, test.err, err)
+		}
+	}
+
+	if _, err := TransformBoundingBox(ows.BoundingBox{}, RDNew, WGS84); err == nil || err.Error() != `the corners of the BoundingBox have no x and y` {
+		t.Errorf("test: %d, expected: %s,\n got: %v", 2, `the corners of the BoundingBox have no x and y`, err)
+	}
+}
+
+func TestTransformRoundTrip(t *testing.T) {
+	for k, crs := range []ows.CRS{RDNew, {Namespace: ows.EPSG, Code: `3857`}, {Namespace: ows.EPSG, Code: `3035`}, {Namespace: ows.EPSG, Code: `25831`}, {Namespace: ows.EPSG, Code: `4289`}} {
+		for _, position := range []ows.Position{{3.3, 50.7}, {5.4, 52.2}, {7.2, 53.5}} {
+			p, err := Transform(position, WGS84, crs)
+			if err != nil {
+				t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+				continue
+			}
+			p, err = Transform(p, crs, WGS84)
+			if err != nil {
+				t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+				continue
+			}
+			if !equalPosition(p, position, 1e-7) {
+				t.Errorf("test: %d, expected: %v,\n got: %v", k, position, p)
+			}
+		}
+	}
+}
+
+func TestTransformUnsupported(t *testing.T) {
+	unknown := ows.CRS{Namespace: ows.EPSG, Code: `1`}
+
+	var tests = []struct {
+		from, to ows.CRS
+		err      string
+	}{
+		0: {from: unknown, to: WGS84, err: `CRS EPSG:1 is not supported`},
+		1: {from: WGS84, to: unknown, err: `CRS EPSG:1 is not supported`},
+	}
+
+	for k, test := range tests {
+		if _, err := Transform(ows.Position{5, 52}, test.from, test.to); err == nil || err.Error() != test.err {
+			t.Errorf("test: %d, expected: %s,\n got: %v", k, test.err, err)
+		}
+		if _, err := TransformBoundingBox(ows.BoundingBox{}, test.from, test.to); err == nil || err.Error() != test.err {
+			t.Errorf("test: %d, expected: %s,\n got: %v", k, test.err, err)
+		}
+	}
+}
+
+func TestTransformBoundingBox(t *testing.T) {
+	laea := ows.CRS{Namespace: ows.EPSG, Code: `3035`}
+	projection := LambertAzimuthalEqualArea{Ellipsoid: EllipsoidGRS80, Lat0: 52, Lon0: 10, FalseEasting: 4321000, FalseNorthing: 3210000}
+
+	bbox, err := TransformBoundingBox(ows.BoundingBox{LowerCorner: ows.Position{0, 50}, UpperCorner: ows.Position{20, 60}}, ETRS89, laea)
+	if err != nil {
+		t.Fatalf("expected no error,\n got: %s", err.Error())
+	}
+	if bbox.Crs != `EPSG:3035` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, `EPSG:3035`, bbox.Crs)
+	}
+
+	// the southern edge bends to the south, the extent is set by its middle on the central meridian and not by the corners
+	_, south := projection.Forward(10, 50)
+	_, corner := projection.Forward(0, 50)
+	if math.Abs(bbox.LowerCorner[1]-south) > 0.01 || bbox.LowerCorner[1] >= corner {
+		t.Errorf("test: %d, expected: %f,\n got: %f", 1, south, bbox.LowerCorner[1])
+	}
+	// the western edge is the widest at the southern corner
+	west, _ := projection.Forward(0, 50)
+	if math.Abs(bbox.LowerCorner[0]-west) > 0.01 {
+		t.Errorf("test: %d, expected: %f,\n got: %f", 2, west, bbox.LowerCorner[0])
+	}
+}
+
+func TestGeographicBoundingBox(t *testing.T) {
+	bbox, err := GeographicBoundingBox(ows.BoundingBox{Dimensions: `2`, LowerCorner: ows.Position{10000, 300000}, UpperCorner: ows.Position{280000, 625000}}, RDNew)
+	if err != nil {
+		t.Fatalf("expected no error,\n got: %s", err.Error())
+	}
+
+	expected := ows.BoundingBox{Dimensions: `2`, LowerCorner: ows.Position{3.197, 50.672}, UpperCorner: ows.Position{7.275, 53.611}}
+	if bbox.Crs != expected.Crs || bbox.Dimensions != expected.Dimensions || !equalPosition(bbox.LowerCorner, expected.LowerCorner, 0.001) || !equalPosition(bbox.UpperCorner, expected.UpperCorner, 0.001) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, bbox)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	crs := ows.CRS{Namespace: ows.EPSG, Code: `32662`}
+	d := Definition{Datum: DatumWGS84, Projection: TransverseMercator{Ellipsoid: EllipsoidWGS84, K0: 1}}
+
+	if err := Register(crs, d); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+	if _, ok := Get(crs); !ok {
+		t.Errorf("test: %d, expected: %s to be registered", 1, crs.String())
+	}
+	if err := Register(RDNew, d); err == nil || err.Error() != `CRS EPSG:28992 is already registered` {
+		t.Errorf("test: %d, expected: %s,\n got: %v", 2, `CRS EPSG:28992 is already registered`, err)
+	}
+}
@@ -1,26 +1,44 @@
 package capabilities
 
+import (
+	"encoding/xml"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"gopkg.in/yaml.v2"
+)
+
 // ParseXML func
+// The document can be a complete WCS Capabilities document
 func (c *Capabilities) ParseXML(doc []byte) error {
+	var capabilities Capabilities
+	if err := xml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	*c = capabilities
 	return nil
 }
 
 // ParseYAMl func
 func (c *Capabilities) ParseYAMl(doc []byte) error {
+	var capabilities Capabilities
+	if err := yaml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	*c = capabilities
 	return nil
 }
 
 // Capabilities struct
 type Capabilities struct {
-	OperationsMetadata OperationsMetadata `xml:"ows:OperationsMetadata" yaml:"operationsmetadata"`
-	ServiceMetadata    ServiceMetadata    `xml:"wcs:ServiceMetadata" yaml:"servicemetadata"`
-	Contents           Contents           `xml:"wcs:Contents" yaml:"contents"`
+	OperationsMetadata OperationsMetadata `xml:"http://www.opengis.net/ows/2.0 OperationsMetadata" yaml:"operationsmetadata"`
+	ServiceMetadata    ServiceMetadata    `xml:"http://www.opengis.net/wcs/2.0 ServiceMetadata" yaml:"servicemetadata"`
+	Contents           Contents           `xml:"http://www.opengis.net/wcs/2.0 Contents" yaml:"contents"`
 }
 
 // OperationsMetadata struct for the WCS 2.0.1
 type OperationsMetadata struct {
-	Operation            []Operation           `xml:"ows:Operation" yaml:"operation"`
-	ExtendedCapabilities *ExtendedCapabilities `xml:"ows:ExtendedCapabilities" yaml:"extendedcapabilities"`
+	Operation            []Operation           `xml:"http://www.opengis.net/ows/2.0 Operation" yaml:"operation"`
+	ExtendedCapabilities *ExtendedCapabilities `xml:"http://www.opengis.net/ows/2.0 ExtendedCapabilities" yaml:"extendedcapabilities"`
 }
 
 // Operation in struct for repeatability
@@ -29,68 +47,132 @@ type Operation struct {
 	DCP  struct {
 		HTTP struct {
 			Get struct {
-				Type string `xml:"xlink:type,attr" yaml:"type"`
-				Href string `xml:"xlink:href,attr" yaml:"href"`
-			} `xml:"ows:Get" yaml:"get"`
-			Post *Post `xml:"ows:Post" yaml:"post"`
-		} `xml:"ows:HTTP"  yaml:"http"`
-	} `xml:"ows:DCP" yaml:"dcp"`
+				Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+				Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+			} `xml:"http://www.opengis.net/ows/2.0 Get" yaml:"get"`
+			Post *Post `xml:"http://www.opengis.net/ows/2.0 Post" yaml:"post"`
+		} `xml:"http://www.opengis.net/ows/2.0 HTTP"  yaml:"http"`
+	} `xml:"http://www.opengis.net/ows/2.0 DCP" yaml:"dcp"`
 }
 
 // Post in separated struct so to use it as a Pointer
 type Post struct {
-	Type       string `xml:"xlink:type,attr" yaml:"type"`
-	Href       string `xml:"xlink:href,attr" yaml:"href"`
+	Type       string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+	Href       string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
 	Constraint struct {
 		Name          string `xml:"name,attr" yaml:"name"`
 		AllowedValues struct {
-			Value []string `xml:"ows:Value" yaml:"value"`
-		} `xml:"ows:AllowedValues" yaml:"allowedvalues"`
-	} `xml:"ows:Constraint" yaml:"constraint"`
+			Value []string `xml:"http://www.opengis.net/ows/2.0 Value" yaml:"value"`
+		} `xml:"http://www.opengis.net/ows/2.0 AllowedValues" yaml:"allowedvalues"`
+	} `xml:"http://www.opengis.net/ows/2.0 Constraint" yaml:"constraint"`
 }
 
 // ExtendedCapabilities struct for the WCS 2.0.1
 type ExtendedCapabilities struct {
 	ExtendedCapabilities struct {
 		MetadataURL struct {
-			Type      string `xml:"xsi:type,attr"`
-			URL       string `xml:"inspire_common:URL"`
-			MediaType string `xml:"inspire_common:MediaType"`
-		} `xml:"inspire_common:MetadataUrl"`
+			Type      string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr" yaml:"type"`
+			URL       string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 URL" yaml:"url"`
+			MediaType string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MediaType" yaml:"mediatype"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MetadataUrl" yaml:"metadataurl"`
 		SupportedLanguages struct {
 			DefaultLanguage struct {
-				Language string `xml:"inspire_common:Language"`
-			} `xml:"inspire_common:DefaultLanguage"`
-		} `xml:"inspire_common:SupportedLanguages"`
+				Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+			} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 DefaultLanguage" yaml:"defaultlanguage"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 SupportedLanguages" yaml:"supportedlanguages"`
 		ResponseLanguage struct {
-			Language string `xml:"inspire_common:Language"`
-		} `xml:"inspire_common:ResponseLanguage"`
+			Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 ResponseLanguage" yaml:"responselanguage"`
 		SpatialDataSetIdentifier struct {
-			Code string `xml:"Code"`
-		} `xml:"inspire_dls:SpatialDataSetIdentifier"`
-	} `xml:"inspire_dls:ExtendedCapabilities"`
+			Code string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Code" yaml:"code"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/inspire_dls/1.0 SpatialDataSetIdentifier" yaml:"spatialdatasetidentifier"`
+	} `xml:"http://inspire.ec.europa.eu/schemas/inspire_dls/1.0 ExtendedCapabilities" yaml:"extendedcapabilities"`
 }
 
 // ServiceMetadata struct for the WCS 2.0.1
 type ServiceMetadata struct {
-	FormatSupported []string `xml:"wcs:formatSupported"`
+	FormatSupported []string `xml:"http://www.opengis.net/wcs/2.0 formatSupported" yaml:"formatsupported"`
 	Extension       struct {
 		InterpolationMetadata struct {
-			InterpolationSupported []string `xml:"int:InterpolationSupported"`
-		} `xml:"int:InterpolationMetadata"`
+			InterpolationSupported []string `xml:"http://www.opengis.net/wcs/interpolation/1.0 InterpolationSupported" yaml:"interpolationsupported"`
+		} `xml:"http://www.opengis.net/wcs/interpolation/1.0 InterpolationMetadata" yaml:"interpolationmetadata"`
 		CrsMetadata struct {
-			CrsSupported []string `xml:"crs:crsSupported"`
-		} `xml:"crs:CrsMetadata"`
-	} `xml:"wcs:Extension"`
+			CrsSupported []string `xml:"http://www.opengis.net/wcs/crs/1.0 crsSupported" yaml:"crssupported"`
+		} `xml:"http://www.opengis.net/wcs/crs/1.0 CrsMetadata" yaml:"crsmetadata"`
+	} `xml:"http://www.opengis.net/wcs/2.0 Extension" yaml:"extension"`
+}
+
+// HasFormatSupported checks if the format is supported by the server
+func (sm ServiceMetadata) HasFormatSupported(format string) bool {
+	for _, f := range sm.FormatSupported {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCrsSupported checks if the CRS is supported by the server, as subsetting and output CRS
+func (sm ServiceMetadata) HasCrsSupported(crs string) bool {
+	for _, c := range sm.Extension.CrsMetadata.CrsSupported {
+		if c == crs {
+			return true
+		}
+	}
+	return false
+}
+
+// HasInterpolationSupported checks if the interpolation method is supported by the server
+func (sm ServiceMetadata) HasInterpolationSupported(method string) bool {
+	for _, i := range sm.Extension.InterpolationMetadata.InterpolationSupported {
+		if i == method {
+			return true
+		}
+	}
+	return false
 }
 
 // Contents in struct for repeatability
 type Contents struct {
-	CoverageSummary []CoverageSummary `xml:"wcs:CoverageSummary"`
+	CoverageSummary []CoverageSummary `xml:"http://www.opengis.net/wcs/2.0 CoverageSummary" yaml:"coveragesummary"`
+}
+
+// GetCoverageSummary returns the CoverageSummary with the given CoverageID
+func (c Contents) GetCoverageSummary(coverageid string) (CoverageSummary, bool) {
+	for _, cs := range c.CoverageSummary {
+		if cs.CoverageID == coverageid {
+			return cs, true
+		}
+	}
+	return CoverageSummary{}, false
 }
 
 // CoverageSummary in struct for repeatability
+// The order of the elements follows the wcs:CoverageSummaryType of the WCS 2.0.1 schema
 type CoverageSummary struct {
-	CoverageID      string `xml:"wcs:CoverageId"`
-	CoverageSubtype string `xml:"wcs:CoverageSubtype"`
+	Title            string            `xml:"http://www.opengis.net/ows/2.0 Title,omitempty" yaml:"title,omitempty"`
+	Abstract         string            `xml:"http://www.opengis.net/ows/2.0 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Keywords         *ows.Keywords     `xml:"http://www.opengis.net/ows/2.0 Keywords" yaml:"keywords,omitempty"`
+	WGS84BoundingBox *ows.BoundingBox  `xml:"http://www.opengis.net/ows/2.0 WGS84BoundingBox" yaml:"wgs84boundingbox,omitempty"`
+	CoverageID       string            `xml:"http://www.opengis.net/wcs/2.0 CoverageId" yaml:"coverageid"`
+	CoverageSubtype  string            `xml:"http://www.opengis.net/wcs/2.0 CoverageSubtype" yaml:"coveragesubtype"`
+	BoundingBox      []ows.BoundingBox `xml:"http://www.opengis.net/ows/2.0 BoundingBox" yaml:"boundingbox,omitempty"`
+	Metadata         []Metadata        `xml:"http://www.opengis.net/ows/2.0 Metadata" yaml:"metadata,omitempty"`
+}
+
+// GetBoundingBox returns the BoundingBox in the given CRS
+func (cs CoverageSummary) GetBoundingBox(crs string) (ows.BoundingBox, bool) {
+	for _, b := range cs.BoundingBox {
+		if b.Crs == crs {
+			return b, true
+		}
+	}
+	return ows.BoundingBox{}, false
+}
+
+// Metadata contains a reference to metadata
+type Metadata struct {
+	Href  string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	Role  string `xml:"http://www.w3.org/1999/xlink role,attr,omitempty" yaml:"role,omitempty"`
+	Title string `xml:"http://www.w3.org/1999/xlink title,attr,omitempty" yaml:"title,omitempty"`
 }
@@ -0,0 +1,238 @@
+package capabilities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// capabilities document with the INSPIRE extended capabilities and the CRS and interpolation extensions
+var ahnCapabilities = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<wcs:Capabilities version="2.0.1" xmlns:wcs="http://www.opengis.net/wcs/2.0" xmlns:ows="http://www.opengis.net/ows/2.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:crs="http://www.opengis.net/wcs/crs/1.0" xmlns:int="http://www.opengis.net/wcs/interpolation/1.0" xmlns:inspire_common="http://inspire.ec.europa.eu/schemas/common/1.0" xmlns:inspire_dls="http://inspire.ec.europa.eu/schemas/inspire_dls/1.0">
+  <ows:ServiceIdentification>
+    <ows:Title>Actueel Hoogtebestand Nederland</ows:Title>
+    <ows:ServiceType>OGC WCS</ows:ServiceType>
+    <ows:ServiceTypeVersion>2.0.1</ows:ServiceTypeVersion>
+  </ows:ServiceIdentification>
+  <ows:OperationsMetadata>
+    <ows:Operation name="GetCapabilities">
+      <ows:DCP><ows:HTTP><ows:Get xlink:type="simple" xlink:href="https://service.pdok.nl/rws/ahn/wcs/v1_0"/></ows:HTTP></ows:DCP>
+    </ows:Operation>
+    <ows:Operation name="GetCoverage">
+      <ows:DCP>
+        <ows:HTTP>
+          <ows:Get xlink:type="simple" xlink:href="https://service.pdok.nl/rws/ahn/wcs/v1_0"/>
+          <ows:Post xlink:type="simple" xlink:href="https://service.pdok.nl/rws/ahn/wcs/v1_0">
+            <ows:Constraint name="PostEncoding">
+              <ows:AllowedValues><ows:Value>XML</ows:Value></ows:AllowedValues>
+            </ows:Constraint>
+          </ows:Post>
+        </ows:HTTP>
+      </ows:DCP>
+    </ows:Operation>
+    <ows:ExtendedCapabilities>
+      <inspire_dls:ExtendedCapabilities>
+        <inspire_common:MetadataUrl xsi:type="inspire_common:resourceLocatorType">
+          <inspire_common:URL>https://www.nationaalgeoregister.nl/metadata</inspire_common:URL>
+          <inspire_common:MediaType>application/vnd.ogc.csw.GetRecordByIdResponse_xml</inspire_common:MediaType>
+        </inspire_common:MetadataUrl>
+        <inspire_common:SupportedLanguages>
+          <inspire_common:DefaultLanguage><inspire_common:Language>dut</inspire_common:Language></inspire_common:DefaultLanguage>
+        </inspire_common:SupportedLanguages>
+        <inspire_common:ResponseLanguage><inspire_common:Language>dut</inspire_common:Language></inspire_common:ResponseLanguage>
+        <inspire_dls:SpatialDataSetIdentifier><inspire_common:Code>a1b2c3</inspire_common:Code></inspire_dls:SpatialDataSetIdentifier>
+      </inspire_dls:ExtendedCapabilities>
+    </ows:ExtendedCapabilities>
+  </ows:OperationsMetadata>
+  <wcs:ServiceMetadata>
+    <wcs:formatSupported>image/tiff</wcs:formatSupported>
+    <wcs:formatSupported>image/png</wcs:formatSupported>
+    <wcs:Extension>
+      <int:InterpolationMetadata>
+        <int:InterpolationSupported>http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor</int:InterpolationSupported>
+      </int:InterpolationMetadata>
+      <crs:CrsMetadata>
+        <crs:crsSupported>http://www.opengis.net/def/crs/EPSG/0/28992</crs:crsSupported>
+        <crs:crsSupported>http://www.opengis.net/def/crs/EPSG/0/4326</crs:crsSupported>
+      </crs:CrsMetadata>
+    </wcs:Extension>
+  </wcs:ServiceMetadata>
+  <wcs:Contents>
+    <wcs:CoverageSummary>
+      <ows:Title>DTM 0.5m</ows:Title>
+      <ows:Abstract>Digital terrain model with a resolution of 0.5 meter</ows:Abstract>
+      <ows:Keywords>
+        <ows:Keyword>AHN</ows:Keyword>
+        <ows:Keyword>DTM</ows:Keyword>
+      </ows:Keywords>
+      <ows:WGS84BoundingBox>
+        <ows:LowerCorner>3.2 50.7</ows:LowerCorner>
+        <ows:UpperCorner>7.2 53.5</ows:UpperCorner>
+      </ows:WGS84BoundingBox>
+      <wcs:CoverageId>dtm_05m</wcs:CoverageId>
+      <wcs:CoverageSubtype>RectifiedGridCoverage</wcs:CoverageSubtype>
+      <ows:BoundingBox crs="http://www.opengis.net/def/crs/EPSG/0/28992" dimensions="2">
+        <ows:LowerCorner>10000 300000</ows:LowerCorner>
+        <ows:UpperCorner>280000 625000</ows:UpperCorner>
+      </ows:BoundingBox>
+      <ows:Metadata xlink:href="https://www.nationaalgeoregister.nl/metadata/dtm" xlink:role="http://www.opengis.net/def/role/OGC/0/metadata"/>
+    </wcs:CoverageSummary>
+    <wcs:CoverageSummary>
+      <wcs:CoverageId>dsm_05m</wcs:CoverageId>
+      <wcs:CoverageSubtype>RectifiedGridCoverage</wcs:CoverageSubtype>
+    </wcs:CoverageSummary>
+  </wcs:Contents>
+</wcs:Capabilities>`)
+
+func TestCapabilitiesParseXML(t *testing.T) {
+	var c Capabilities
+	if err := c.ParseXML(ahnCapabilities); err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+
+	e := c.OperationsMetadata.ExtendedCapabilities.ExtendedCapabilities
+	result := []string{
+		c.OperationsMetadata.Operation[0].Name,
+		c.OperationsMetadata.Operation[1].DCP.HTTP.Get.Href,
+		c.OperationsMetadata.Operation[1].DCP.HTTP.Post.Constraint.AllowedValues.Value[0],
+		e.MetadataURL.Type,
+		e.MetadataURL.URL,
+		e.SupportedLanguages.DefaultLanguage.Language,
+		e.SpatialDataSetIdentifier.Code,
+		c.ServiceMetadata.FormatSupported[1],
+		c.ServiceMetadata.Extension.InterpolationMetadata.InterpolationSupported[0],
+		c.ServiceMetadata.Extension.CrsMetadata.CrsSupported[1],
+		c.Contents.CoverageSummary[0].Title,
+		c.Contents.CoverageSummary[0].Abstract,
+		c.Contents.CoverageSummary[0].Keywords.Keyword[1],
+		c.Contents.CoverageSummary[0].CoverageID,
+		c.Contents.CoverageSummary[0].CoverageSubtype,
+		c.Contents.CoverageSummary[0].BoundingBox[0].Crs,
+		c.Contents.CoverageSummary[0].Metadata[0].Href,
+		c.Contents.CoverageSummary[0].Metadata[0].Role,
+		c.Contents.CoverageSummary[1].CoverageID,
+	}
+	expected := []string{`GetCapabilities`, `https://service.pdok.nl/rws/ahn/wcs/v1_0`, `XML`, `inspire_common:resourceLocatorType`,
+		`https://www.nationaalgeoregister.nl/metadata`, `dut`, `a1b2c3`, `image/png`, `http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor`,
+		`http://www.opengis.net/def/crs/EPSG/0/4326`, `DTM 0.5m`, `Digital terrain model with a resolution of 0.5 meter`, `DTM`, `dtm_05m`,
+		`RectifiedGridCoverage`, `http://www.opengis.net/def/crs/EPSG/0/28992`, `https://www.nationaalgeoregister.nl/metadata/dtm`,
+		`http://www.opengis.net/def/role/OGC/0/metadata`, `dsm_05m`}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, result)
+	}
+
+	wgs84 := ows.BoundingBox{LowerCorner: ows.Position{3.2, 50.7}, UpperCorner: ows.Position{7.2, 53.5}}
+	if bbox := c.Contents.CoverageSummary[0].WGS84BoundingBox; bbox == nil || !bbox.Equal(wgs84) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 1, wgs84, bbox)
+	}
+	if c.Contents.CoverageSummary[1].WGS84BoundingBox != nil || c.Contents.CoverageSummary[1].Keywords != nil {
+		t.Errorf("test: %d, expected: no WGS84BoundingBox and Keywords,\n got: %+v", 2, c.Contents.CoverageSummary[1])
+	}
+	if err := c.ParseXML([]byte(`no XML document, just a string`)); err == nil {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 3, err)
+	}
+}
+
+// TestCapabilitiesRoundTrip parses, marshals and parses the document again, the result should be the same
+func TestCapabilitiesRoundTrip(t *testing.T) {
+	var original Capabilities
+	if err := original.ParseXML(ahnCapabilities); err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+
+	b, err := ows.CanonicalNamespaces.Marshal(original, ``, ``)
+	if err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+	for _, element := range []string{`<ows:OperationsMetadata>`, `<wcs:ServiceMetadata>`, `<wcs:CoverageSummary>`, `<ows:WGS84BoundingBox>`, `<ows:Metadata `} {
+		if !strings.Contains(string(b), element) {
+			t.Errorf("test: %d, expected: %s,\n got: %s", 0, element, string(b))
+		}
+	}
+
+	var result Capabilities
+	if err := result.ParseXML(b); err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+	if !reflect.DeepEqual(original, result) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 0, original, result)
+	}
+}
+
+func TestCapabilitiesParseYAMl(t *testing.T) {
+	doc := []byte(`operationsmetadata:
+  operation:
+  - name: GetCoverage
+    dcp:
+      http:
+        get:
+          type: simple
+          href: https://service.pdok.nl/rws/ahn/wcs/v1_0
+  extendedcapabilities:
+    extendedcapabilities:
+      metadataurl:
+        url: https://www.nationaalgeoregister.nl/metadata
+      spatialdatasetidentifier:
+        code: a1b2c3
+servicemetadata:
+  formatsupported:
+  - image/tiff
+  extension:
+    crsmetadata:
+      crssupported:
+      - http://www.opengis.net/def/crs/EPSG/0/28992
+contents:
+  coveragesummary:
+  - title: DTM 0.5m
+    keywords:
+      keyword:
+      - AHN
+    wgs84boundingbox:
+      lowercorner: 3.2 50.7
+      uppercorner: 7.2 53.5
+    coverageid: dtm_05m
+    coveragesubtype: RectifiedGridCoverage
+    boundingbox:
+    - crs: http://www.opengis.net/def/crs/EPSG/0/28992
+      lowercorner: 10000 300000
+      uppercorner: 280000 625000
+    metadata:
+    - href: https://www.nationaalgeoregister.nl/metadata/dtm`)
+
+	var c Capabilities
+	if err := c.ParseYAMl(doc); err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+
+	result := []string{
+		c.OperationsMetadata.Operation[0].Name,
+		c.OperationsMetadata.Operation[0].DCP.HTTP.Get.Href,
+		c.OperationsMetadata.ExtendedCapabilities.ExtendedCapabilities.MetadataURL.URL,
+		c.OperationsMetadata.ExtendedCapabilities.ExtendedCapabilities.SpatialDataSetIdentifier.Code,
+		c.ServiceMetadata.FormatSupported[0],
+		c.ServiceMetadata.Extension.CrsMetadata.CrsSupported[0],
+		c.Contents.CoverageSummary[0].Title,
+		c.Contents.CoverageSummary[0].Keywords.Keyword[0],
+		c.Contents.CoverageSummary[0].CoverageID,
+		c.Contents.CoverageSummary[0].CoverageSubtype,
+		c.Contents.CoverageSummary[0].Metadata[0].Href,
+	}
+	expected := []string{`GetCoverage`, `https://service.pdok.nl/rws/ahn/wcs/v1_0`, `https://www.nationaalgeoregister.nl/metadata`, `a1b2c3`, `image/tiff`,
+		`http://www.opengis.net/def/crs/EPSG/0/28992`, `DTM 0.5m`, `AHN`, `dtm_05m`, `RectifiedGridCoverage`, `https://www.nationaalgeoregister.nl/metadata/dtm`}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, result)
+	}
+
+	bbox := ows.BoundingBox{Crs: `http://www.opengis.net/def/crs/EPSG/0/28992`, LowerCorner: ows.Position{10000, 300000}, UpperCorner: ows.Position{280000, 625000}}
+	if b, ok := c.Contents.CoverageSummary[0].GetBoundingBox(`http://www.opengis.net/def/crs/EPSG/0/28992`); !ok || !b.Equal(bbox) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 1, bbox, b)
+	}
+	if _, ok := c.Contents.CoverageSummary[0].GetBoundingBox(`http://www.opengis.net/def/crs/EPSG/0/4326`); ok {
+		t.Errorf("test: %d, expected: no BoundingBox,\n got: %v", 2, ok)
+	}
+	if err := c.ParseYAMl([]byte(`contents: [`)); err == nil {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 3, err)
+	}
+}
@@ -0,0 +1,201 @@
+package exception
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// Type and Version as constant
+const (
+	Service string = `WCS`
+	Version string = `2.0.1`
+)
+
+// HTTP status codes of the exception codes, Table 18 of the WCS 2.0.1 core spec,
+// Table 28 of OWS Common 2.0 and the exception tables of the WCS 2.0 extensions
+var statusCodes = map[string]int{
+	`OperationNotSupported`:           http.StatusNotImplemented,
+	`MissingParameterValue`:           http.StatusBadRequest,
+	`InvalidParameterValue`:           http.StatusBadRequest,
+	`VersionNegotiationFailed`:        http.StatusBadRequest,
+	`InvalidUpdateSequence`:           http.StatusBadRequest,
+	`OptionNotSupported`:              http.StatusNotImplemented,
+	`NoApplicableCode`:                http.StatusInternalServerError,
+	`NoSuchCoverage`:                  http.StatusNotFound,
+	`EmptyCoverageIdList`:             http.StatusNotFound,
+	`InvalidAxisLabel`:                http.StatusNotFound,
+	`InvalidSubsetting`:               http.StatusNotFound,
+	`InvalidScaleFactor`:              http.StatusNotFound,
+	`InvalidExtent`:                   http.StatusNotFound,
+	`InterpolationMethodNotSupported`: http.StatusNotFound,
+	`NotACrs`:                         http.StatusNotFound,
+	`SubsettingCrs-NotSupported`:      http.StatusNotFound,
+	`OutputCrs-NotSupported`:          http.StatusNotFound,
+}
+
+// StatusCode returns the HTTP status code for the exception
+// Unknown exception codes are handled as NoApplicableCode
+func StatusCode(e ows.Exception) int {
+	if code, ok := statusCodes[e.Code()]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// WCSExceptionReport struct
+// The WCS 2.0 uses the OWS Common 2.0 ExceptionReport
+type WCSExceptionReport struct {
+	XMLName        xml.Name       `xml:"ows:ExceptionReport" yaml:"exceptionreport"`
+	Ows            string         `xml:"xmlns:ows,attr,omitempty"`
+	Xsi            string         `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string         `xml:"xsi:schemaLocation,attr,omitempty"`
+	Version        string         `xml:"version,attr" yaml:"version"`
+	Language       string         `xml:"xml:lang,attr,omitempty" yaml:"lang,omitempty"`
+	Exception      ows.Exceptions `xml:"ows:Exception"`
+}
+
+// Report returns WCSExceptionReport
+func (r WCSExceptionReport) Report(errors ows.Exceptions) []byte {
+	r.Ows = `http://www.opengis.net/ows/2.0`
+	r.Xsi = `http://www.w3.org/2001/XMLSchema-instance`
+	r.SchemaLocation = `http://www.opengis.net/ows/2.0 http://schemas.opengis.net/ows/2.0/owsExceptionReport.xsd`
+	r.Version = Version
+	r.Language = `en`
+	r.Exception = errors
+
+	si, _ := xml.MarshalIndent(r, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// StatusCode returns the HTTP status code for the report, that is the status code of the first exception
+func (r WCSExceptionReport) StatusCode(errors ows.Exceptions) int {
+	if len(errors) == 0 {
+		return http.StatusInternalServerError
+	}
+	return StatusCode(errors[0])
+}
+
+// WCSException grouping the error message variables together
+type WCSException struct {
+	ExceptionText string `xml:",chardata" yaml:"exception"`
+	ExceptionCode string `xml:"exceptionCode,attr" yaml:"exceptioncode"`
+	LocatorCode   string `xml:"locator,attr,omitempty" yaml:"locator,omitempty"`
+}
+
+// Error returns available ExceptionText
+func (e WCSException) Error() string {
+	return e.ExceptionText
+}
+
+// Code returns available ExceptionCode
+func (e WCSException) Code() string {
+	return e.ExceptionCode
+}
+
+// Locator returns available LocatorCode
+func (e WCSException) Locator() string {
+	return e.LocatorCode
+}
+
+// NoSuchCoverage exception
+// One of the identifiers passed does not match with any of the coverages offered by this server
+func NoSuchCoverage(coverageids ...string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("No such coverage: %s", strings.Join(coverageids, `, `)),
+		ExceptionCode: `NoSuchCoverage`,
+		LocatorCode:   strings.Join(coverageids, ` `),
+	}
+}
+
+// EmptyCoverageIDList exception
+// Operation request contains an empty list of coverage identifiers
+func EmptyCoverageIDList() WCSException {
+	return WCSException{
+		ExceptionText: `The list of coverage identifiers is empty`,
+		ExceptionCode: `EmptyCoverageIdList`,
+	}
+}
+
+// InvalidAxisLabel exception
+// The dimension subsetting operation specified an axis label that does not exist in the Envelope or has been used more than once
+func InvalidAxisLabel(axis string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Invalid axis label: %s", axis),
+		ExceptionCode: `InvalidAxisLabel`,
+		LocatorCode:   axis,
+	}
+}
+
+// InvalidSubsetting exception
+// A trim or slice value is outside the extent of the coverage or, in a trim, the lower bound is above the upper bound
+func InvalidSubsetting(axis, reason string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Invalid subsetting of axis %s: %s", axis, reason),
+		ExceptionCode: `InvalidSubsetting`,
+		LocatorCode:   axis,
+	}
+}
+
+// InvalidScaleFactor exception
+// The scale factor passed is not positive or no valid number
+func InvalidScaleFactor(factor string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Invalid scale factor: %s", factor),
+		ExceptionCode: `InvalidScaleFactor`,
+		LocatorCode:   factor,
+	}
+}
+
+// InvalidExtent exception
+// The extent interval passed has an upper bound smaller than the lower bound
+func InvalidExtent(axis, extent string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Invalid extent of axis %s: %s", axis, extent),
+		ExceptionCode: `InvalidExtent`,
+		LocatorCode:   extent,
+	}
+}
+
+// InterpolationMethodNotSupported exception
+// The interpolation method is not supported by the server
+func InterpolationMethodNotSupported(method string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Interpolation method not supported: %s", method),
+		ExceptionCode: `InterpolationMethodNotSupported`,
+		LocatorCode:   `interpolation`,
+	}
+}
+
+// NotACrs exception
+// The CRS passed is not a valid CRS identifier, the locator is the parameter that contains the CRS
+func NotACrs(crs, locator string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Not a CRS: %s", crs),
+		ExceptionCode: `NotACrs`,
+		LocatorCode:   locator,
+	}
+}
+
+// SubsettingCrsNotSupported exception
+// The CRS passed as subsetting CRS is not supported by the server
+func SubsettingCrsNotSupported(crs string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Subsetting CRS not supported: %s", crs),
+		ExceptionCode: `SubsettingCrs-NotSupported`,
+		LocatorCode:   crs,
+	}
+}
+
+// OutputCrsNotSupported exception
+// The CRS passed as output CRS is not supported by the server
+func OutputCrsNotSupported(crs string) WCSException {
+	return WCSException{
+		ExceptionText: fmt.Sprintf("Output CRS not supported: %s", crs),
+		ExceptionCode: `OutputCrs-NotSupported`,
+		LocatorCode:   crs,
+	}
+}
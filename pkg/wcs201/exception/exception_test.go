@@ -0,0 +1,148 @@
+package exception
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+func TestWCSException(t *testing.T) {
+	var tests = []struct {
+		exception     ows.Exception
+		exceptionText string
+		exceptionCode string
+		locatorCode   string
+	}{
+		0: {exception: WCSException{ExceptionCode: "", ExceptionText: "", LocatorCode: ""},
+			exceptionText: "",
+			exceptionCode: "",
+			locatorCode:   "",
+		},
+		1: {exception: NoSuchCoverage("C0001"),
+			exceptionText: "No such coverage: C0001",
+			exceptionCode: "NoSuchCoverage",
+			locatorCode:   "C0001",
+		},
+		2: {exception: NoSuchCoverage("C0001", "C0002"),
+			exceptionText: "No such coverage: C0001, C0002",
+			exceptionCode: "NoSuchCoverage",
+			locatorCode:   "C0001 C0002",
+		},
+		3: {exception: EmptyCoverageIDList(),
+			exceptionText: "The list of coverage identifiers is empty",
+			exceptionCode: "EmptyCoverageIdList",
+		},
+		4: {exception: InvalidAxisLabel("Lat"),
+			exceptionText: "Invalid axis label: Lat",
+			exceptionCode: "InvalidAxisLabel",
+			locatorCode:   "Lat",
+		},
+		5: {exception: InvalidSubsetting("x", "the lower bound 20 is above the upper bound 10"),
+			exceptionText: "Invalid subsetting of axis x: the lower bound 20 is above the upper bound 10",
+			exceptionCode: "InvalidSubsetting",
+			locatorCode:   "x",
+		},
+		6: {exception: InvalidScaleFactor("-2"),
+			exceptionText: "Invalid scale factor: -2",
+			exceptionCode: "InvalidScaleFactor",
+			locatorCode:   "-2",
+		},
+		7: {exception: InvalidExtent("x", "20:10"),
+			exceptionText: "Invalid extent of axis x: 20:10",
+			exceptionCode: "InvalidExtent",
+			locatorCode:   "20:10",
+		},
+		8: {exception: InterpolationMethodNotSupported("http://www.opengis.net/def/interpolation/OGC/1/cubic"),
+			exceptionText: "Interpolation method not supported: http://www.opengis.net/def/interpolation/OGC/1/cubic",
+			exceptionCode: "InterpolationMethodNotSupported",
+			locatorCode:   "interpolation",
+		},
+		9: {exception: NotACrs("EPSG:4326", "OUTPUTCRS"),
+			exceptionText: "Not a CRS: EPSG:4326",
+			exceptionCode: "NotACrs",
+			locatorCode:   "OUTPUTCRS",
+		},
+		10: {exception: SubsettingCrsNotSupported("http://www.opengis.net/def/crs/EPSG/0/3035"),
+			exceptionText: "Subsetting CRS not supported: http://www.opengis.net/def/crs/EPSG/0/3035",
+			exceptionCode: "SubsettingCrs-NotSupported",
+			locatorCode:   "http://www.opengis.net/def/crs/EPSG/0/3035",
+		},
+		11: {exception: OutputCrsNotSupported("http://www.opengis.net/def/crs/EPSG/0/3035"),
+			exceptionText: "Output CRS not supported: http://www.opengis.net/def/crs/EPSG/0/3035",
+			exceptionCode: "OutputCrs-NotSupported",
+			locatorCode:   "http://www.opengis.net/def/crs/EPSG/0/3035",
+		},
+	}
+
+	for k, a := range tests {
+		if a.exception.Error() != a.exceptionText {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.exceptionText, a.exception.Error())
+		}
+		if a.exception.Code() != a.exceptionCode {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.exceptionCode, a.exception.Code())
+		}
+		if a.exception.Locator() != a.locatorCode {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.locatorCode, a.exception.Locator())
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	var tests = []struct {
+		exceptions []ows.Exception
+		result     []byte
+	}{
+		0: {exceptions: []ows.Exception{WCSException{ExceptionCode: "", ExceptionText: "", LocatorCode: ""}},
+			result: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<ows:ExceptionReport xmlns:ows="http://www.opengis.net/ows/2.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/ows/2.0 http://schemas.opengis.net/ows/2.0/owsExceptionReport.xsd" version="2.0.1" xml:lang="en">
+ <ows:Exception exceptionCode=""></ows:Exception>
+</ows:ExceptionReport>`)},
+		1: {exceptions: []ows.Exception{
+			NoSuchCoverage("dtm_05m"),
+			ows.MissingParameterValue("SUBSET"),
+		},
+			result: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<ows:ExceptionReport xmlns:ows="http://www.opengis.net/ows/2.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/ows/2.0 http://schemas.opengis.net/ows/2.0/owsExceptionReport.xsd" version="2.0.1" xml:lang="en">
+ <ows:Exception exceptionCode="NoSuchCoverage" locator="dtm_05m">No such coverage: dtm_05m</ows:Exception>
+ <ows:Exception exceptionCode="MissingParameterValue" locator="SUBSET">Missing key: SUBSET</ows:Exception>
+</ows:ExceptionReport>`)},
+	}
+
+	for k, a := range tests {
+		report := WCSExceptionReport{}
+		r := report.Report(a.exceptions)
+		if string(r) != string(a.result) {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.result, r)
+		}
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	var tests = []struct {
+		exceptions ows.Exceptions
+		statuscode int
+	}{
+		0:  {exceptions: ows.Exceptions{NoSuchCoverage("dtm_05m")}, statuscode: http.StatusNotFound},
+		1:  {exceptions: ows.Exceptions{EmptyCoverageIDList()}, statuscode: http.StatusNotFound},
+		2:  {exceptions: ows.Exceptions{InvalidAxisLabel("Lat")}, statuscode: http.StatusNotFound},
+		3:  {exceptions: ows.Exceptions{InvalidSubsetting("x", "out of range")}, statuscode: http.StatusNotFound},
+		4:  {exceptions: ows.Exceptions{InvalidScaleFactor("0")}, statuscode: http.StatusNotFound},
+		5:  {exceptions: ows.Exceptions{InvalidExtent("x", "10:0")}, statuscode: http.StatusNotFound},
+		6:  {exceptions: ows.Exceptions{InterpolationMethodNotSupported("cubic")}, statuscode: http.StatusNotFound},
+		7:  {exceptions: ows.Exceptions{NotACrs("EPSG:4326", "SUBSETTINGCRS")}, statuscode: http.StatusNotFound},
+		8:  {exceptions: ows.Exceptions{SubsettingCrsNotSupported("http://www.opengis.net/def/crs/EPSG/0/3035")}, statuscode: http.StatusNotFound},
+		9:  {exceptions: ows.Exceptions{OutputCrsNotSupported("http://www.opengis.net/def/crs/EPSG/0/3035")}, statuscode: http.StatusNotFound},
+		10: {exceptions: ows.Exceptions{ows.OperationNotSupported("GetMap")}, statuscode: http.StatusNotImplemented},
+		11: {exceptions: ows.Exceptions{ows.MissingParameterValue("COVERAGEID"), NoSuchCoverage("dtm_05m")}, statuscode: http.StatusBadRequest},
+		12: {exceptions: ows.Exceptions{WCSException{ExceptionCode: "Unknown"}}, statuscode: http.StatusInternalServerError},
+		13: {statuscode: http.StatusInternalServerError},
+	}
+
+	for k, a := range tests {
+		report := WCSExceptionReport{}
+		if statuscode := report.StatusCode(a.exceptions); statuscode != a.statuscode {
+			t.Errorf("test: %d, expected: %d\n got: %d", k, a.statuscode, statuscode)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package request
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+)
+
+//
+const (
+	describecoverage = `DescribeCoverage`
+)
+
+// DescribeCoverage Tokens
+const (
+	COVERAGEID = `COVERAGEID`
+)
+
+// Type returns DescribeCoverage
+func (dc *DescribeCoverage) Type() string {
+	return describecoverage
+}
+
+// Validate validates the DescribeCoverage against the capabilities
+// Every requested CoverageID needs to be offered in the Contents
+// The capabilities are a *capabilities.Capabilities, other capabilities result in a NoApplicableCode exception
+func (dc *DescribeCoverage) Validate(c ows.Capabilities) ows.Exceptions {
+	wcscapabilities, ok := c.(*capabilities.Capabilities)
+	if !ok {
+		return ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WCS 2.0.1 capabilities`)}
+	}
+	contents := wcscapabilities.Contents
+
+	if len(dc.CoverageID) == 0 {
+		return ows.Exceptions{exception.EmptyCoverageIDList()}
+	}
+
+	var exceptions ows.Exceptions
+	for _, coverageid := range dc.CoverageID {
+		if _, ok := contents.GetCoverageSummary(coverageid); !ok {
+			exceptions = append(exceptions, exception.NoSuchCoverage(coverageid))
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// ParseOperationRequestKVP process the simple struct to a complex struct
+func (dc *DescribeCoverage) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Exceptions {
+	dckvp := orkvp.(*DescribeCoverageKVP)
+
+	exceptions := dc.BaseRequest.Build(dckvp.Service, dckvp.Version)
+	if dckvp.CoverageID == `` {
+		exceptions = append(exceptions, ows.MissingParameterValue(COVERAGEID))
+	}
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	dc.XMLName.Local = describecoverage
+	dc.CoverageID = dckvp.coverageIDs()
+
+	return nil
+}
+
+// ParseKVP builds a DescribeCoverage object based on the available query parameters
+func (dc *DescribeCoverage) ParseKVP(query url.Values) ows.Exceptions {
+	if len(query) == 0 {
+		// When there are no query values we know that at least
+		// the mandatory SERVICE, VERSION and REQUEST parameter is missing.
+		return ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}
+	}
+
+	dckvp := DescribeCoverageKVP{}
+	if err := dckvp.ParseKVP(query); err != nil {
+		return err
+	}
+
+	if err := dc.ParseOperationRequestKVP(&dckvp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseXML builds a DescribeCoverage object based on a XML document
+func (dc *DescribeCoverage) ParseXML(body []byte) ows.Exceptions {
+	var xmlattributes ows.XMLAttribute
+	if err := xml.Unmarshal(body, &xmlattributes); err != nil {
+		return ows.Exceptions{ows.MissingParameterValue()}
+	}
+	if err := xml.Unmarshal(body, &dc); err != nil {
+		return ows.Exceptions{ows.NoApplicableCode(err.Error())}
+	}
+	var n []xml.Attr
+	for _, a := range xmlattributes {
+		switch strings.ToUpper(a.Name.Local) {
+		case VERSION:
+		case SERVICE:
+		default:
+			n = append(n, a)
+		}
+	}
+	dc.BaseRequest.Attr = ows.StripDuplicateAttr(n)
+	return nil
+}
+
+// BuildKVP builds a new query string that will be proxied
+func (dc *DescribeCoverage) BuildKVP() url.Values {
+	dckvp := DescribeCoverageKVP{}
+	dckvp.ParseOperationRequest(dc)
+
+	return dckvp.BuildKVP()
+}
+
+// BuildXML builds a 'new' XML document 'based' on the 'original' XML document
+func (dc *DescribeCoverage) BuildXML() []byte {
+	si, _ := ows.CanonicalNamespaces.Marshal(dc, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// DescribeCoverage struct with the needed parameters/attributes needed for making a DescribeCoverage request
+// Struct based on http://schemas.opengis.net/wcs/2.0/wcsDescribeCoverage.xsd
+type DescribeCoverage struct {
+	XMLName xml.Name `xml:"http://www.opengis.net/wcs/2.0 DescribeCoverage" yaml:"describecoverage"`
+	BaseRequest
+	CoverageID []string `xml:"http://www.opengis.net/wcs/2.0 CoverageId" yaml:"coverageid"`
+}
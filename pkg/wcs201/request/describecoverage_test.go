@@ -0,0 +1,150 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+)
+
+func TestDescribeCoverageType(t *testing.T) {
+	dc := DescribeCoverage{}
+	if dc.Type() != `DescribeCoverage` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, `DescribeCoverage`, dc.Type())
+	}
+}
+
+func TestDescribeCoverageParseKVP(t *testing.T) {
+	var tests = []struct {
+		query            url.Values
+		describecoverage DescribeCoverage
+		exceptions       ows.Exceptions
+	}{
+		0: {query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`DescribeCoverage`}, COVERAGEID: {`dtm_05m`}},
+			describecoverage: DescribeCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: []string{`dtm_05m`}}},
+		// Keys are case insensitive and the COVERAGEID is a comma separated list
+		1: {query: map[string][]string{`service`: {`WCS`}, `version`: {`2.0.1`}, `request`: {`DescribeCoverage`}, `CoverageId`: {`dtm_05m, dsm_05m,`}},
+			describecoverage: DescribeCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: []string{`dtm_05m`, `dsm_05m`}}},
+		2: {query: map[string][]string{SERVICE: {`WCS`}, REQUEST: {`DescribeCoverage`}},
+			exceptions: ows.Exceptions{ows.MissingParameterValue(VERSION), ows.MissingParameterValue(COVERAGEID)}},
+		3: {query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`DescribeCoverage`}, COVERAGEID: {`dtm_05m`, `dsm_05m`}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`dtm_05m,dsm_05m`, COVERAGEID)}},
+		4: {query: map[string][]string{}, exceptions: ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}},
+	}
+
+	for k, test := range tests {
+		var dc DescribeCoverage
+		exceptions := dc.ParseKVP(test.query)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.describecoverage.XMLName.Local = `DescribeCoverage`
+		if !reflect.DeepEqual(dc, test.describecoverage) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.describecoverage, dc)
+		}
+	}
+}
+
+func TestDescribeCoverageBuildKVP(t *testing.T) {
+	var tests = []struct {
+		describecoverage DescribeCoverage
+		query            url.Values
+	}{
+		0: {describecoverage: DescribeCoverage{CoverageID: []string{`dtm_05m`, `dsm_05m`}},
+			query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`DescribeCoverage`}, COVERAGEID: {`dtm_05m,dsm_05m`}}},
+	}
+
+	for k, test := range tests {
+		query := test.describecoverage.BuildKVP()
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
+
+func TestDescribeCoverageParseXML(t *testing.T) {
+	var tests = []struct {
+		body             []byte
+		describecoverage DescribeCoverage
+		exceptions       ows.Exceptions
+	}{
+		0: {body: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<wcs:DescribeCoverage xmlns:wcs="http://www.opengis.net/wcs/2.0" service="WCS" version="2.0.1">
+ <wcs:CoverageId>dtm_05m</wcs:CoverageId>
+ <wcs:CoverageId>dsm_05m</wcs:CoverageId>
+</wcs:DescribeCoverage>`),
+			describecoverage: DescribeCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: []string{`dtm_05m`, `dsm_05m`}}},
+		1: {body: []byte(`no XML document, just a string`), exceptions: ows.Exceptions{ows.MissingParameterValue()}},
+	}
+
+	for k, test := range tests {
+		var dc DescribeCoverage
+		exceptions := dc.ParseXML(test.body)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if dc.XMLName.Local != `DescribeCoverage` || !reflect.DeepEqual(dc.CoverageID, test.describecoverage.CoverageID) ||
+			dc.Service != test.describecoverage.Service || dc.Version != test.describecoverage.Version {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.describecoverage, dc)
+		}
+	}
+}
+
+func TestDescribeCoverageBuildXML(t *testing.T) {
+	var tests = []struct {
+		describecoverage DescribeCoverage
+		result           string
+	}{
+		0: {describecoverage: DescribeCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: []string{`dtm_05m`, `dsm_05m`}},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<wcs:DescribeCoverage xmlns:wcs="http://www.opengis.net/wcs/2.0" service="WCS" version="2.0.1">
+ <wcs:CoverageId>dtm_05m</wcs:CoverageId>
+ <wcs:CoverageId>dsm_05m</wcs:CoverageId>
+</wcs:DescribeCoverage>`},
+	}
+
+	for k, test := range tests {
+		result := string(test.describecoverage.BuildXML())
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestDescribeCoverageValidate(t *testing.T) {
+	var c capabilities.Capabilities
+	c.Contents.CoverageSummary = []capabilities.CoverageSummary{{CoverageID: `dtm_05m`, CoverageSubtype: `RectifiedGridCoverage`}, {CoverageID: `dsm_05m`, CoverageSubtype: `RectifiedGridCoverage`}}
+
+	var tests = []struct {
+		describecoverage DescribeCoverage
+		exceptions       ows.Exceptions
+	}{
+		0: {describecoverage: DescribeCoverage{CoverageID: []string{`dtm_05m`, `dsm_05m`}}},
+		1: {describecoverage: DescribeCoverage{CoverageID: []string{`dtm_05m`, `unknown`, `dtm_5m`}},
+			exceptions: ows.Exceptions{exception.NoSuchCoverage(`unknown`), exception.NoSuchCoverage(`dtm_5m`)}},
+		2: {describecoverage: DescribeCoverage{}, exceptions: ows.Exceptions{exception.EmptyCoverageIDList()}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.describecoverage.Validate(&c)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+	// capabilities of another type result in an exception instead of a panic
+	expected := ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WCS 2.0.1 capabilities`)}
+	if exceptions := tests[0].describecoverage.Validate(nil); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 3, expected, exceptions)
+	}
+}
@@ -0,0 +1,72 @@
+package request
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// DescribeCoverageKVP struct
+type DescribeCoverageKVP struct {
+	// Table 12 - DescribeCoverage request parameters
+	BaseRequestKVP
+	// CoverageID is the comma separated list of coverage identifiers
+	CoverageID string `yaml:"coverageid,omitempty"`
+}
+
+// ParseKVP builds a DescribeCoverageKVP object based on the available query parameters
+func (dckvp *DescribeCoverageKVP) ParseKVP(query url.Values) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for k, v := range query {
+		if len(v) != 1 {
+			exceptions = append(exceptions, ows.InvalidParameterValue(strings.Join(v, ","), k))
+			continue
+		}
+		if dckvp.BaseRequestKVP.ParseKVP(strings.ToUpper(k), v[0]) {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case COVERAGEID:
+			dckvp.CoverageID = v[0]
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	return nil
+}
+
+// ParseOperationRequest builds a DescribeCoverageKVP object based on a DescribeCoverage struct
+func (dckvp *DescribeCoverageKVP) ParseOperationRequest(or ows.OperationRequest) ows.Exceptions {
+	dc := or.(*DescribeCoverage)
+
+	dckvp.Request = describecoverage
+	dckvp.Version = Version
+	dckvp.Service = Service
+	dckvp.CoverageID = strings.Join(dc.CoverageID, `,`)
+
+	return nil
+}
+
+// BuildKVP builds a url.Values query from a DescribeCoverageKVP object
+func (dckvp *DescribeCoverageKVP) BuildKVP() url.Values {
+	query := make(map[string][]string)
+	dckvp.BaseRequestKVP.BuildKVP(query)
+	query[COVERAGEID] = []string{dckvp.CoverageID}
+
+	return query
+}
+
+// coverageIDs returns the CoverageID as list, empty identifiers are ignored
+func (dckvp *DescribeCoverageKVP) coverageIDs() []string {
+	var coverageids []string
+	for _, coverageid := range strings.Split(dckvp.CoverageID, `,`) {
+		if c := strings.TrimSpace(coverageid); c != `` {
+			coverageids = append(coverageids, c)
+		}
+	}
+	return coverageids
+}
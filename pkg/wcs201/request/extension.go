@@ -0,0 +1,465 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+)
+
+// WCS 2.0 extension Tokens
+const (
+	SCALEFACTOR   = `SCALEFACTOR`
+	SCALEAXES     = `SCALEAXES`
+	SCALESIZE     = `SCALESIZE`
+	SCALEEXTENT   = `SCALEEXTENT`
+	SUBSETTINGCRS = `SUBSETTINGCRS`
+	OUTPUTCRS     = `OUTPUTCRS`
+	INTERPOLATION = `INTERPOLATION`
+	RANGESUBSET   = `RANGESUBSET`
+)
+
+// Conformance classes of the WCS 2.0 extensions, a server declares these as ows:Profile in the capabilities
+const (
+	ScalingConformance         = `http://www.opengis.net/spec/WCS_service-extension_scaling/1.0/conf/scaling`
+	CRSConformance             = `http://www.opengis.net/spec/WCS_service-extension_crs/1.0/conf/crs`
+	InterpolationConformance   = `http://www.opengis.net/spec/WCS_service-extension_interpolation/1.0/conf/interpolation`
+	RangeSubsettingConformance = `http://www.opengis.net/spec/WCS_service-extension_range-subsetting/1.0/conf/record-subsetting`
+)
+
+// extensions maps the conformance classes on the parameters that make use of the extension
+var extensions = []struct {
+	conformance string
+	parameters  string
+	used        func(e *Extension) bool
+}{
+	{ScalingConformance, `SCALEFACTOR, SCALEAXES, SCALESIZE and SCALEEXTENT`, func(e *Extension) bool { return e.Scaling != nil }},
+	{CRSConformance, `SUBSETTINGCRS and OUTPUTCRS`, func(e *Extension) bool { return e.SubsettingCRS != `` || e.OutputCRS != `` }},
+	{InterpolationConformance, `INTERPOLATION`, func(e *Extension) bool { return e.Interpolation != nil }},
+	{RangeSubsettingConformance, `RANGESUBSET`, func(e *Extension) bool { return e.RangeSubset != nil }},
+}
+
+// ValidateConformance checks if the extensions used by the GetCoverage are declared by the server
+// The profiles are the conformance classes listed in the ows:ServiceIdentification of the capabilities
+func (gc *GetCoverage) ValidateConformance(profiles []string) ows.Exceptions {
+	if gc.Extension == nil {
+		return nil
+	}
+
+	declared := make(map[string]bool)
+	for _, p := range profiles {
+		declared[p] = true
+	}
+
+	var exceptions ows.Exceptions
+	for _, e := range extensions {
+		if e.used(gc.Extension) && !declared[e.conformance] {
+			exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("%s are not supported, the server does not conform to %s", e.parameters, e.conformance)))
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// validate checks the extension parameters against the ServiceMetadata
+func (e *Extension) validate(sm capabilities.ServiceMetadata) ows.Exceptions {
+	var exceptions ows.Exceptions
+	if e.Scaling != nil {
+		exceptions = append(exceptions, e.Scaling.validate()...)
+	}
+	if e.SubsettingCRS != `` {
+		if !isCRS(e.SubsettingCRS) {
+			exceptions = append(exceptions, exception.NotACrs(e.SubsettingCRS, SUBSETTINGCRS))
+		} else if !sm.HasCrsSupported(e.SubsettingCRS) {
+			exceptions = append(exceptions, exception.SubsettingCrsNotSupported(e.SubsettingCRS))
+		}
+	}
+	if e.OutputCRS != `` {
+		if !isCRS(e.OutputCRS) {
+			exceptions = append(exceptions, exception.NotACrs(e.OutputCRS, OUTPUTCRS))
+		} else if !sm.HasCrsSupported(e.OutputCRS) {
+			exceptions = append(exceptions, exception.OutputCrsNotSupported(e.OutputCRS))
+		}
+	}
+	if e.Interpolation != nil && !sm.HasInterpolationSupported(e.Interpolation.GlobalInterpolation) {
+		exceptions = append(exceptions, exception.InterpolationMethodNotSupported(e.Interpolation.GlobalInterpolation))
+	}
+	return exceptions
+}
+
+// validate checks if the scale factors and sizes are positive and the extents aren't reversed
+func (s *Scaling) validate() ows.Exceptions {
+	var exceptions ows.Exceptions
+	if s.ScaleByFactor != nil && s.ScaleByFactor.ScaleFactor <= 0 {
+		exceptions = append(exceptions, exception.InvalidScaleFactor(formatFloat(s.ScaleByFactor.ScaleFactor)))
+	}
+	if s.ScaleAxesByFactor != nil {
+		for _, a := range s.ScaleAxesByFactor.ScaleAxis {
+			if a.ScaleFactor <= 0 {
+				exceptions = append(exceptions, exception.InvalidScaleFactor(formatFloat(a.ScaleFactor)))
+			}
+		}
+	}
+	if s.ScaleToSize != nil {
+		for _, a := range s.ScaleToSize.TargetAxisSize {
+			if a.TargetSize <= 0 {
+				exceptions = append(exceptions, ows.InvalidParameterValue(strconv.Itoa(a.TargetSize), SCALESIZE))
+			}
+		}
+	}
+	if s.ScaleToExtent != nil {
+		for _, a := range s.ScaleToExtent.TargetAxisExtent {
+			if a.Low > a.High {
+				exceptions = append(exceptions, exception.InvalidExtent(a.Axis, formatFloat(a.Low)+`:`+formatFloat(a.High)))
+			}
+		}
+	}
+	return exceptions
+}
+
+// validateDomain checks if the scaled axes are axes of the coverage and the range components are fields of the coverage
+func (e *Extension) validateDomain(d wcs201.CoverageDescription) ows.Exceptions {
+	var exceptions ows.Exceptions
+	if e.Scaling != nil {
+		for _, axis := range e.Scaling.axes() {
+			if _, ok := d.BoundedBy.Envelope.AxisIndex(axis); !ok {
+				exceptions = append(exceptions, exception.InvalidAxisLabel(axis))
+			}
+		}
+	}
+	if e.RangeSubset != nil {
+		fields := make(map[string]bool)
+		for _, f := range d.RangeType.DataRecord.Field {
+			fields[f.Name] = true
+		}
+		for _, component := range e.RangeSubset.components() {
+			if !fields[component] {
+				exceptions = append(exceptions, ows.InvalidParameterValue(component, RANGESUBSET))
+			}
+		}
+	}
+	return exceptions
+}
+
+// axes returns the axes that are scaled individually
+func (s *Scaling) axes() []string {
+	var axes []string
+	if s.ScaleAxesByFactor != nil {
+		for _, a := range s.ScaleAxesByFactor.ScaleAxis {
+			axes = append(axes, a.Axis)
+		}
+	}
+	if s.ScaleToSize != nil {
+		for _, a := range s.ScaleToSize.TargetAxisSize {
+			axes = append(axes, a.Axis)
+		}
+	}
+	if s.ScaleToExtent != nil {
+		for _, a := range s.ScaleToExtent.TargetAxisExtent {
+			axes = append(axes, a.Axis)
+		}
+	}
+	return axes
+}
+
+// components returns the range components that are named in the RangeSubset
+func (rs *RangeSubset) components() []string {
+	var components []string
+	for _, i := range rs.RangeItem {
+		if i.RangeInterval != nil {
+			components = append(components, i.RangeInterval.StartComponent, i.RangeInterval.EndComponent)
+			continue
+		}
+		components = append(components, i.RangeComponent)
+	}
+	return components
+}
+
+// isCRS checks if the CRS is a valid OGC CRS URI or URN, like http://www.opengis.net/def/crs/EPSG/0/4326
+func isCRS(crs string) bool {
+	if !strings.HasPrefix(crs, `http://www.opengis.net/def/crs`) && !strings.HasPrefix(strings.ToLower(crs), `urn:ogc:def:crs:`) {
+		return false
+	}
+	_, exception := ows.ParseCRS(crs)
+	return exception == nil
+}
+
+// buildExtension builds the Extension from the KVP extension parameters, it returns nil when no extension is used
+// Only one of the scaling parameters SCALEFACTOR, SCALEAXES, SCALESIZE and SCALEEXTENT can be used.
+func (gckvp *GetCoverageKVP) buildExtension() (*Extension, ows.Exceptions) {
+	var e Extension
+	var exceptions ows.Exceptions
+
+	var scaling []string
+	if gckvp.ScaleFactor != `` {
+		scaling = append(scaling, SCALEFACTOR)
+		f, err := strconv.ParseFloat(gckvp.ScaleFactor, 64)
+		if err != nil {
+			exceptions = append(exceptions, exception.InvalidScaleFactor(gckvp.ScaleFactor))
+		}
+		e.Scaling = &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: f}}
+	}
+	if gckvp.ScaleAxes != `` {
+		scaling = append(scaling, SCALEAXES)
+		var s ScaleAxesByFactor
+		values, ok := parseAxisValues(gckvp.ScaleAxes)
+		if !ok {
+			exceptions = append(exceptions, ows.InvalidParameterValue(gckvp.ScaleAxes, SCALEAXES))
+		}
+		for _, v := range values {
+			f, err := strconv.ParseFloat(v.value, 64)
+			if err != nil {
+				exceptions = append(exceptions, exception.InvalidScaleFactor(v.value))
+			}
+			s.ScaleAxis = append(s.ScaleAxis, ScaleAxis{Axis: v.axis, ScaleFactor: f})
+		}
+		e.Scaling = &Scaling{ScaleAxesByFactor: &s}
+	}
+	if gckvp.ScaleSize != `` {
+		scaling = append(scaling, SCALESIZE)
+		var s ScaleToSize
+		values, ok := parseAxisValues(gckvp.ScaleSize)
+		if !ok {
+			exceptions = append(exceptions, ows.InvalidParameterValue(gckvp.ScaleSize, SCALESIZE))
+		}
+		for _, v := range values {
+			size, err := strconv.Atoi(v.value)
+			if err != nil {
+				exceptions = append(exceptions, ows.InvalidParameterValue(v.value, SCALESIZE))
+			}
+			s.TargetAxisSize = append(s.TargetAxisSize, TargetAxisSize{Axis: v.axis, TargetSize: size})
+		}
+		e.Scaling = &Scaling{ScaleToSize: &s}
+	}
+	if gckvp.ScaleExtent != `` {
+		scaling = append(scaling, SCALEEXTENT)
+		var s ScaleToExtent
+		values, ok := parseAxisValues(gckvp.ScaleExtent)
+		if !ok {
+			exceptions = append(exceptions, ows.InvalidParameterValue(gckvp.ScaleExtent, SCALEEXTENT))
+		}
+		for _, v := range values {
+			extent := strings.Split(v.value, `:`)
+			if len(extent) != 2 {
+				exceptions = append(exceptions, exception.InvalidExtent(v.axis, v.value))
+				continue
+			}
+			low, errlow := strconv.ParseFloat(strings.TrimSpace(extent[0]), 64)
+			high, errhigh := strconv.ParseFloat(strings.TrimSpace(extent[1]), 64)
+			if errlow != nil || errhigh != nil {
+				exceptions = append(exceptions, exception.InvalidExtent(v.axis, v.value))
+			}
+			s.TargetAxisExtent = append(s.TargetAxisExtent, TargetAxisExtent{Axis: v.axis, Low: low, High: high})
+		}
+		e.Scaling = &Scaling{ScaleToExtent: &s}
+	}
+	if len(scaling) > 1 {
+		exceptions = append(exceptions, ows.InvalidParameterValue(strings.Join(scaling, `,`), SCALEFACTOR))
+	}
+
+	e.SubsettingCRS = gckvp.SubsettingCRS
+	e.OutputCRS = gckvp.OutputCRS
+	if gckvp.Interpolation != `` {
+		e.Interpolation = &Interpolation{GlobalInterpolation: gckvp.Interpolation}
+	}
+
+	if gckvp.RangeSubset != `` {
+		var rs RangeSubset
+		for _, item := range strings.Split(gckvp.RangeSubset, `,`) {
+			switch components := strings.Split(strings.TrimSpace(item), `:`); {
+			case len(components) == 1 && components[0] != ``:
+				rs.RangeItem = append(rs.RangeItem, RangeItem{RangeComponent: components[0]})
+			case len(components) == 2 && components[0] != `` && components[1] != ``:
+				rs.RangeItem = append(rs.RangeItem, RangeItem{RangeInterval: &RangeInterval{StartComponent: components[0], EndComponent: components[1]}})
+			default:
+				exceptions = append(exceptions, ows.InvalidParameterValue(gckvp.RangeSubset, RANGESUBSET))
+			}
+		}
+		e.RangeSubset = &rs
+	}
+
+	if len(exceptions) > 0 {
+		return nil, exceptions
+	}
+	if e == (Extension{}) {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+// parseExtension sets the KVP extension parameters from the Extension
+func (gckvp *GetCoverageKVP) parseExtension(e *Extension) {
+	if e == nil {
+		return
+	}
+	if s := e.Scaling; s != nil {
+		if s.ScaleByFactor != nil {
+			gckvp.ScaleFactor = formatFloat(s.ScaleByFactor.ScaleFactor)
+		}
+		if s.ScaleAxesByFactor != nil {
+			var values []string
+			for _, a := range s.ScaleAxesByFactor.ScaleAxis {
+				values = append(values, a.Axis+`(`+formatFloat(a.ScaleFactor)+`)`)
+			}
+			gckvp.ScaleAxes = strings.Join(values, `,`)
+		}
+		if s.ScaleToSize != nil {
+			var values []string
+			for _, a := range s.ScaleToSize.TargetAxisSize {
+				values = append(values, a.Axis+`(`+strconv.Itoa(a.TargetSize)+`)`)
+			}
+			gckvp.ScaleSize = strings.Join(values, `,`)
+		}
+		if s.ScaleToExtent != nil {
+			var values []string
+			for _, a := range s.ScaleToExtent.TargetAxisExtent {
+				values = append(values, a.Axis+`(`+formatFloat(a.Low)+`:`+formatFloat(a.High)+`)`)
+			}
+			gckvp.ScaleExtent = strings.Join(values, `,`)
+		}
+	}
+	gckvp.SubsettingCRS = e.SubsettingCRS
+	gckvp.OutputCRS = e.OutputCRS
+	if e.Interpolation != nil {
+		gckvp.Interpolation = e.Interpolation.GlobalInterpolation
+	}
+	if e.RangeSubset != nil {
+		var items []string
+		for _, i := range e.RangeSubset.RangeItem {
+			if i.RangeInterval != nil {
+				items = append(items, i.RangeInterval.StartComponent+`:`+i.RangeInterval.EndComponent)
+				continue
+			}
+			items = append(items, i.RangeComponent)
+		}
+		gckvp.RangeSubset = strings.Join(items, `,`)
+	}
+}
+
+type axisValue struct {
+	axis  string
+	value string
+}
+
+// axisValueRegex matches a single axis(value)
+var axisValueRegex = regexp.MustCompile(`^\s*([^,()\s]+)\s*\(([^()]*)\)\s*$`)
+
+// parseAxisValues parses a comma separated list of axis(value), like x(1.5),y(2)
+func parseAxisValues(s string) ([]axisValue, bool) {
+	var items []string
+	var depth, start int
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	items = append(items, s[start:])
+
+	var values []axisValue
+	for _, item := range items {
+		match := axisValueRegex.FindStringSubmatch(item)
+		if match == nil {
+			return nil, false
+		}
+		values = append(values, axisValue{axis: match[1], value: strings.TrimSpace(match[2])})
+	}
+	return values, true
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Extension struct for the wcs:Extension of the GetCoverage with the parameters of the WCS 2.0 extensions
+type Extension struct {
+	Scaling       *Scaling       `xml:"http://www.opengis.net/wcs/scaling/1.0 Scaling" yaml:"scaling,omitempty"`
+	SubsettingCRS string         `xml:"http://www.opengis.net/wcs/crs/1.0 subsettingCrs,omitempty" yaml:"subsettingcrs,omitempty"`
+	OutputCRS     string         `xml:"http://www.opengis.net/wcs/crs/1.0 outputCrs,omitempty" yaml:"outputcrs,omitempty"`
+	Interpolation *Interpolation `xml:"http://www.opengis.net/wcs/interpolation/1.0 Interpolation" yaml:"interpolation,omitempty"`
+	RangeSubset   *RangeSubset   `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 RangeSubset" yaml:"rangesubset,omitempty"`
+}
+
+// Scaling struct of the scaling extension, only one of the scaling methods is used
+type Scaling struct {
+	ScaleByFactor     *ScaleByFactor     `xml:"http://www.opengis.net/wcs/scaling/1.0 ScaleByFactor" yaml:"scalebyfactor,omitempty"`
+	ScaleAxesByFactor *ScaleAxesByFactor `xml:"http://www.opengis.net/wcs/scaling/1.0 ScaleAxesByFactor" yaml:"scaleaxesbyfactor,omitempty"`
+	ScaleToSize       *ScaleToSize       `xml:"http://www.opengis.net/wcs/scaling/1.0 ScaleToSize" yaml:"scaletosize,omitempty"`
+	ScaleToExtent     *ScaleToExtent     `xml:"http://www.opengis.net/wcs/scaling/1.0 ScaleToExtent" yaml:"scaletoextent,omitempty"`
+}
+
+// ScaleByFactor scales all axes by the same factor
+type ScaleByFactor struct {
+	ScaleFactor float64 `xml:"http://www.opengis.net/wcs/scaling/1.0 scaleFactor" yaml:"scalefactor"`
+}
+
+// ScaleAxesByFactor scales the axes by their own factor
+type ScaleAxesByFactor struct {
+	ScaleAxis []ScaleAxis `xml:"http://www.opengis.net/wcs/scaling/1.0 ScaleAxis" yaml:"scaleaxis"`
+}
+
+// ScaleAxis struct
+type ScaleAxis struct {
+	Axis        string  `xml:"http://www.opengis.net/wcs/scaling/1.0 axis" yaml:"axis"`
+	ScaleFactor float64 `xml:"http://www.opengis.net/wcs/scaling/1.0 scaleFactor" yaml:"scalefactor"`
+}
+
+// ScaleToSize scales the axes to a number of grid points
+type ScaleToSize struct {
+	TargetAxisSize []TargetAxisSize `xml:"http://www.opengis.net/wcs/scaling/1.0 TargetAxisSize" yaml:"targetaxissize"`
+}
+
+// TargetAxisSize struct
+type TargetAxisSize struct {
+	Axis       string `xml:"http://www.opengis.net/wcs/scaling/1.0 axis" yaml:"axis"`
+	TargetSize int    `xml:"http://www.opengis.net/wcs/scaling/1.0 targetSize" yaml:"targetsize"`
+}
+
+// ScaleToExtent scales the axes to a grid extent
+type ScaleToExtent struct {
+	TargetAxisExtent []TargetAxisExtent `xml:"http://www.opengis.net/wcs/scaling/1.0 TargetAxisExtent" yaml:"targetaxisextent"`
+}
+
+// TargetAxisExtent struct
+type TargetAxisExtent struct {
+	Axis string  `xml:"http://www.opengis.net/wcs/scaling/1.0 axis" yaml:"axis"`
+	Low  float64 `xml:"http://www.opengis.net/wcs/scaling/1.0 low" yaml:"low"`
+	High float64 `xml:"http://www.opengis.net/wcs/scaling/1.0 high" yaml:"high"`
+}
+
+// Interpolation struct of the interpolation extension, only the global interpolation method is supported
+type Interpolation struct {
+	GlobalInterpolation string `xml:"http://www.opengis.net/wcs/interpolation/1.0 globalInterpolation" yaml:"globalinterpolation"`
+}
+
+// RangeSubset struct of the range subsetting extension
+type RangeSubset struct {
+	RangeItem []RangeItem `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 RangeItem" yaml:"rangeitem"`
+}
+
+// RangeItem is a single range component or an interval of range components
+type RangeItem struct {
+	RangeComponent string         `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 RangeComponent,omitempty" yaml:"rangecomponent,omitempty"`
+	RangeInterval  *RangeInterval `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 RangeInterval" yaml:"rangeinterval,omitempty"`
+}
+
+// RangeInterval struct
+type RangeInterval struct {
+	StartComponent string `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 startComponent" yaml:"startcomponent"`
+	EndComponent   string `xml:"http://www.opengis.net/wcs/range-subsetting/1.0 endComponent" yaml:"endcomponent"`
+}
@@ -0,0 +1,233 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+)
+
+func TestGetCoverageParseKVPExtension(t *testing.T) {
+	base := map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`}}
+	with := func(key, value string) url.Values {
+		query := url.Values{}
+		for k, v := range base {
+			query[k] = v
+		}
+		query[key] = []string{value}
+		return query
+	}
+
+	var tests = []struct {
+		query      url.Values
+		extension  *Extension
+		exceptions ows.Exceptions
+	}{
+		0:  {query: base},
+		1:  {query: with(SCALEFACTOR, `2.5`), extension: &Extension{Scaling: &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: 2.5}}}},
+		2:  {query: with(`scaleaxes`, `x(1.5), y(2)`), extension: &Extension{Scaling: &Scaling{ScaleAxesByFactor: &ScaleAxesByFactor{ScaleAxis: []ScaleAxis{{Axis: `x`, ScaleFactor: 1.5}, {Axis: `y`, ScaleFactor: 2}}}}}},
+		3:  {query: with(SCALESIZE, `x(256),y(128)`), extension: &Extension{Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 256}, {Axis: `y`, TargetSize: 128}}}}}},
+		4:  {query: with(SCALEEXTENT, `x(0:255),y(-10:10)`), extension: &Extension{Scaling: &Scaling{ScaleToExtent: &ScaleToExtent{TargetAxisExtent: []TargetAxisExtent{{Axis: `x`, Low: 0, High: 255}, {Axis: `y`, Low: -10, High: 10}}}}}},
+		5:  {query: with(SUBSETTINGCRS, `http://www.opengis.net/def/crs/EPSG/0/4326`), extension: &Extension{SubsettingCRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}},
+		6:  {query: with(OUTPUTCRS, `http://www.opengis.net/def/crs/EPSG/0/3035`), extension: &Extension{OutputCRS: `http://www.opengis.net/def/crs/EPSG/0/3035`}},
+		7:  {query: with(INTERPOLATION, `http://www.opengis.net/def/interpolation/OGC/1/linear`), extension: &Extension{Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/linear`}}},
+		8:  {query: with(RANGESUBSET, `red,nir:swir`), extension: &Extension{RangeSubset: &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `red`}, {RangeInterval: &RangeInterval{StartComponent: `nir`, EndComponent: `swir`}}}}}},
+		9:  {query: with(SCALEFACTOR, `two`), exceptions: ows.Exceptions{exception.InvalidScaleFactor(`two`)}},
+		10: {query: with(SCALEAXES, `x[2]`), exceptions: ows.Exceptions{ows.InvalidParameterValue(`x[2]`, SCALEAXES)}},
+		11: {query: with(SCALESIZE, `x(1.5)`), exceptions: ows.Exceptions{ows.InvalidParameterValue(`1.5`, SCALESIZE)}},
+		12: {query: with(SCALEEXTENT, `x(0,255)`), exceptions: ows.Exceptions{exception.InvalidExtent(`x`, `0,255`)}},
+		13: {query: with(RANGESUBSET, `red,,nir:`), exceptions: ows.Exceptions{ows.InvalidParameterValue(`red,,nir:`, RANGESUBSET), ows.InvalidParameterValue(`red,,nir:`, RANGESUBSET)}},
+	}
+
+	for k, test := range tests {
+		var gc GetCoverage
+		exceptions := gc.ParseKVP(test.query)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if !reflect.DeepEqual(gc.Extension, test.extension) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.extension, gc.Extension)
+		}
+	}
+
+	// Only one of the scaling parameters can be used
+	query := with(SCALEFACTOR, `2`)
+	query[SCALESIZE] = []string{`x(10)`}
+	var gc GetCoverage
+	if exceptions := gc.ParseKVP(query); !reflect.DeepEqual(exceptions, ows.Exceptions{ows.InvalidParameterValue(`SCALEFACTOR,SCALESIZE`, SCALEFACTOR)}) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 14, ows.InvalidParameterValue(`SCALEFACTOR,SCALESIZE`, SCALEFACTOR), exceptions)
+	}
+}
+
+func TestGetCoverageBuildKVPExtension(t *testing.T) {
+	var tests = []struct {
+		extension *Extension
+		query     url.Values
+	}{
+		0: {extension: &Extension{Scaling: &Scaling{ScaleAxesByFactor: &ScaleAxesByFactor{ScaleAxis: []ScaleAxis{{Axis: `x`, ScaleFactor: 1.5}, {Axis: `y`, ScaleFactor: 2}}}},
+			SubsettingCRS: `http://www.opengis.net/def/crs/EPSG/0/4326`, OutputCRS: `http://www.opengis.net/def/crs/EPSG/0/3035`,
+			Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/linear`},
+			RangeSubset:   &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `red`}, {RangeInterval: &RangeInterval{StartComponent: `nir`, EndComponent: `swir`}}}}},
+			query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`},
+				SCALEAXES: {`x(1.5),y(2)`}, SUBSETTINGCRS: {`http://www.opengis.net/def/crs/EPSG/0/4326`}, OUTPUTCRS: {`http://www.opengis.net/def/crs/EPSG/0/3035`},
+				INTERPOLATION: {`http://www.opengis.net/def/interpolation/OGC/1/linear`}, RANGESUBSET: {`red,nir:swir`}}},
+		1: {extension: &Extension{Scaling: &Scaling{ScaleToExtent: &ScaleToExtent{TargetAxisExtent: []TargetAxisExtent{{Axis: `x`, Low: 0, High: 255.5}}}}},
+			query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`}, SCALEEXTENT: {`x(0:255.5)`}}},
+	}
+
+	for k, test := range tests {
+		gc := GetCoverage{CoverageID: `dtm_05m`, Extension: test.extension}
+		query := gc.BuildKVP()
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+			continue
+		}
+
+		var result GetCoverage
+		if exceptions := result.ParseKVP(query); exceptions != nil || !reflect.DeepEqual(result.Extension, test.extension) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v %v", k, test.extension, result.Extension, exceptions)
+		}
+	}
+}
+
+func TestGetCoverageExtensionXML(t *testing.T) {
+	gc := GetCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: `dtm_05m`,
+		Extension: &Extension{Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 256}}}},
+			OutputCRS:     `http://www.opengis.net/def/crs/EPSG/0/3035`,
+			Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor`},
+			RangeSubset:   &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `height`}}}}}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<wcs:GetCoverage xmlns:wcs="http://www.opengis.net/wcs/2.0" xmlns:scal="http://www.opengis.net/wcs/scaling/1.0" xmlns:crs="http://www.opengis.net/wcs/crs/1.0" xmlns:int="http://www.opengis.net/wcs/interpolation/1.0" xmlns:rsub="http://www.opengis.net/wcs/range-subsetting/1.0" service="WCS" version="2.0.1">
+ <wcs:Extension>
+  <scal:Scaling>
+   <scal:ScaleToSize>
+    <scal:TargetAxisSize>
+     <scal:axis>x</scal:axis>
+     <scal:targetSize>256</scal:targetSize>
+    </scal:TargetAxisSize>
+   </scal:ScaleToSize>
+  </scal:Scaling>
+  <crs:outputCrs>http://www.opengis.net/def/crs/EPSG/0/3035</crs:outputCrs>
+  <int:Interpolation>
+   <int:globalInterpolation>http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor</int:globalInterpolation>
+  </int:Interpolation>
+  <rsub:RangeSubset>
+   <rsub:RangeItem>
+    <rsub:RangeComponent>height</rsub:RangeComponent>
+   </rsub:RangeItem>
+  </rsub:RangeSubset>
+ </wcs:Extension>
+ <wcs:CoverageId>dtm_05m</wcs:CoverageId>
+</wcs:GetCoverage>`
+
+	doc := string(gc.BuildXML())
+	if doc != expected {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, expected, doc)
+	}
+
+	var result GetCoverage
+	if exceptions := result.ParseXML([]byte(doc)); exceptions != nil || !reflect.DeepEqual(result.Extension, gc.Extension) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v %v", 1, gc.Extension, result.Extension, exceptions)
+	}
+}
+
+func TestGetCoverageValidateExtension(t *testing.T) {
+	var c capabilities.Capabilities
+	c.ServiceMetadata.Extension.CrsMetadata.CrsSupported = []string{`http://www.opengis.net/def/crs/EPSG/0/28992`, `http://www.opengis.net/def/crs/EPSG/0/4326`}
+	c.ServiceMetadata.Extension.InterpolationMetadata.InterpolationSupported = []string{`http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor`}
+	c.Contents.CoverageSummary = []capabilities.CoverageSummary{{CoverageID: `dtm_05m`}}
+
+	var tests = []struct {
+		extension  *Extension
+		exceptions ows.Exceptions
+	}{
+		0: {extension: &Extension{Scaling: &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: 0.5}}, SubsettingCRS: `http://www.opengis.net/def/crs/EPSG/0/4326`,
+			OutputCRS: `http://www.opengis.net/def/crs/EPSG/0/28992`, Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/nearest-neighbor`}}},
+		1: {extension: &Extension{SubsettingCRS: `EPSG:4326`, OutputCRS: `http://www.opengis.net/def/crs/EPSG/0/3035`,
+			Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/cubic`}},
+			exceptions: ows.Exceptions{exception.NotACrs(`EPSG:4326`, SUBSETTINGCRS), exception.OutputCrsNotSupported(`http://www.opengis.net/def/crs/EPSG/0/3035`),
+				exception.InterpolationMethodNotSupported(`http://www.opengis.net/def/interpolation/OGC/1/cubic`)}},
+		2: {extension: &Extension{SubsettingCRS: `urn:ogc:def:crs:EPSG::3035`},
+			exceptions: ows.Exceptions{exception.SubsettingCrsNotSupported(`urn:ogc:def:crs:EPSG::3035`)}},
+		3: {extension: &Extension{Scaling: &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: 0}}},
+			exceptions: ows.Exceptions{exception.InvalidScaleFactor(`0`)}},
+		4: {extension: &Extension{Scaling: &Scaling{ScaleAxesByFactor: &ScaleAxesByFactor{ScaleAxis: []ScaleAxis{{Axis: `x`, ScaleFactor: -1}}}}},
+			exceptions: ows.Exceptions{exception.InvalidScaleFactor(`-1`)}},
+		5: {extension: &Extension{Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 0}}}}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`0`, SCALESIZE)}},
+		6: {extension: &Extension{Scaling: &Scaling{ScaleToExtent: &ScaleToExtent{TargetAxisExtent: []TargetAxisExtent{{Axis: `x`, Low: 10, High: 0}}}}},
+			exceptions: ows.Exceptions{exception.InvalidExtent(`x`, `10:0`)}},
+	}
+
+	for k, test := range tests {
+		gc := GetCoverage{CoverageID: `dtm_05m`, Extension: test.extension}
+		exceptions := gc.Validate(&c)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
+
+func TestGetCoverageValidateConformance(t *testing.T) {
+	profiles := []string{`http://www.opengis.net/spec/WCS/2.0/conf/core`, ScalingConformance, CRSConformance}
+
+	var tests = []struct {
+		extension  *Extension
+		exceptions ows.Exceptions
+	}{
+		0: {},
+		1: {extension: &Extension{Scaling: &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: 2}}, OutputCRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}},
+		2: {extension: &Extension{Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/linear`}, RangeSubset: &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `red`}}}},
+			exceptions: ows.Exceptions{
+				ows.OptionNotSupported(`INTERPOLATION are not supported, the server does not conform to ` + InterpolationConformance),
+				ows.OptionNotSupported(`RANGESUBSET are not supported, the server does not conform to ` + RangeSubsettingConformance)}},
+	}
+
+	for k, test := range tests {
+		gc := GetCoverage{CoverageID: `dtm_05m`, Extension: test.extension}
+		exceptions := gc.ValidateConformance(profiles)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
+
+func TestGetCoverageValidateDomainExtension(t *testing.T) {
+	description := wcs201.CoverageDescription{CoverageID: `dtm_05m`,
+		BoundedBy: wcs201.BoundedBy{Envelope: wcs201.Envelope{SrsName: `http://www.opengis.net/def/crs/EPSG/0/28992`, AxisLabels: wcs201.Labels{`x`, `y`}, SrsDimension: 2,
+			LowerCorner: wcs201.Coordinates{`10000`, `300000`}, UpperCorner: wcs201.Coordinates{`280000`, `625000`}}},
+		RangeType: wcs201.RangeType{DataRecord: wcs201.DataRecord{Field: []wcs201.Field{{Name: `height`}, {Name: `quality`}}}}}
+
+	var tests = []struct {
+		getcoverage GetCoverage
+		exceptions  ows.Exceptions
+	}{
+		0: {getcoverage: GetCoverage{Extension: &Extension{Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 256}, {Axis: `y`, TargetSize: 256}}}},
+			RangeSubset: &RangeSubset{RangeItem: []RangeItem{{RangeInterval: &RangeInterval{StartComponent: `height`, EndComponent: `quality`}}}}}}},
+		1: {getcoverage: GetCoverage{Extension: &Extension{Scaling: &Scaling{ScaleAxesByFactor: &ScaleAxesByFactor{ScaleAxis: []ScaleAxis{{Axis: `Lat`, ScaleFactor: 2}}}},
+			RangeSubset: &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `red`}}}}},
+			exceptions: ows.Exceptions{exception.InvalidAxisLabel(`Lat`), ows.InvalidParameterValue(`red`, RANGESUBSET)}},
+		// subsets in the SUBSETTINGCRS aren't checked against the extent in the native CRS
+		2: {getcoverage: GetCoverage{DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`}, SlicePoint: `5.2`}},
+			Extension: &Extension{SubsettingCRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}}},
+		3: {getcoverage: GetCoverage{DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`}, SlicePoint: `5.2`}},
+			Extension: &Extension{SubsettingCRS: `http://www.opengis.net/def/crs/EPSG/0/28992`}},
+			exceptions: ows.Exceptions{exception.InvalidSubsetting(`x`, `the slice point 5.2 lies outside the extent (10000,280000)`)}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.getcoverage.ValidateDomain(description)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
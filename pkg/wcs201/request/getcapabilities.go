@@ -15,19 +15,6 @@ const (
 	getcapabilities = `GetCapabilities`
 )
 
-// Type and Version as constant
-const (
-	Service string = `WCS`
-	Version string = `2.0.1`
-)
-
-// WCS 2.0.1 Tokens
-const (
-	SERVICE = `SERVICE`
-	REQUEST = `REQUEST`
-	VERSION = `VERSION`
-)
-
 // Type returns GetCapabilities
 func (gc *GetCapabilities) Type() string {
 	return getcapabilities
@@ -0,0 +1,364 @@
+package request
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+)
+
+//
+const (
+	getcoverage = `GetCoverage`
+)
+
+// GetCoverage Tokens
+const (
+	SUBSET    = `SUBSET`
+	FORMAT    = `FORMAT`
+	MEDIATYPE = `MEDIATYPE`
+)
+
+// Multipart is the only MEDIATYPE defined by WCS 2.0.1
+const Multipart = `multipart/related`
+
+// Type returns GetCoverage
+func (gc *GetCoverage) Type() string {
+	return getcoverage
+}
+
+// Validate validates the GetCoverage against the capabilities
+// Checks if the coverage is offered, the format is supported and if every axis is subsetted only once.
+// The extension parameters are checked against the ServiceMetadata.
+// The subsets themselves are validated against the domain of the coverage with ValidateDomain.
+// The capabilities are a *capabilities.Capabilities, other capabilities result in a NoApplicableCode exception.
+func (gc *GetCoverage) Validate(c ows.Capabilities) ows.Exceptions {
+	wcscapabilities, ok := c.(*capabilities.Capabilities)
+	if !ok {
+		return ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WCS 2.0.1 capabilities`)}
+	}
+
+	var exceptions ows.Exceptions
+	if _, ok := wcscapabilities.Contents.GetCoverageSummary(gc.CoverageID); !ok {
+		exceptions = append(exceptions, exception.NoSuchCoverage(gc.CoverageID))
+	}
+	if gc.Format != `` && !wcscapabilities.ServiceMetadata.HasFormatSupported(gc.Format) {
+		exceptions = append(exceptions, ows.InvalidParameterValue(gc.Format, FORMAT))
+	}
+	if gc.MediaType != `` && gc.MediaType != Multipart {
+		exceptions = append(exceptions, ows.InvalidParameterValue(gc.MediaType, MEDIATYPE))
+	}
+
+	if gc.Extension != nil {
+		exceptions = append(exceptions, gc.Extension.validate(wcscapabilities.ServiceMetadata)...)
+	}
+
+	seen := make(map[string]bool)
+	for _, axis := range gc.axes() {
+		if seen[axis] {
+			exceptions = append(exceptions, exception.InvalidAxisLabel(axis))
+		}
+		seen[axis] = true
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// ValidateDomain validates the subsets against the Envelope of the CoverageDescription
+// Every subsetted axis must be an axis of the Envelope and a trim or slice can't lie outside the extent of that axis.
+// Subsets in another CRS than the Envelope are not checked against the extent.
+// The scaled axes and the range components of the extensions need to be part of the coverage.
+func (gc *GetCoverage) ValidateDomain(d wcs201.CoverageDescription) ows.Exceptions {
+	envelope := d.BoundedBy.Envelope
+	if err := checkEnvelope(d); err != nil {
+		return ows.Exceptions{err}
+	}
+
+	var exceptions ows.Exceptions
+	for _, t := range gc.DimensionTrim {
+		i, ok := envelope.AxisIndex(t.Dimension.Axis)
+		if !ok {
+			exceptions = append(exceptions, exception.InvalidAxisLabel(t.Dimension.Axis))
+			continue
+		}
+		if crs := gc.subsettingCRS(t.Dimension); crs != `` && crs != envelope.SrsName {
+			continue
+		}
+		exceptions = append(exceptions, t.validate(envelope.LowerCorner[i], envelope.UpperCorner[i])...)
+	}
+	for _, s := range gc.DimensionSlice {
+		i, ok := envelope.AxisIndex(s.Dimension.Axis)
+		if !ok {
+			exceptions = append(exceptions, exception.InvalidAxisLabel(s.Dimension.Axis))
+			continue
+		}
+		if crs := gc.subsettingCRS(s.Dimension); crs != `` && crs != envelope.SrsName {
+			continue
+		}
+		exceptions = append(exceptions, s.validate(envelope.LowerCorner[i], envelope.UpperCorner[i])...)
+	}
+	if gc.Extension != nil {
+		exceptions = append(exceptions, gc.Extension.validateDomain(d)...)
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// checkEnvelope checks if the corners of the Envelope have a coordinate for every axis label
+func checkEnvelope(d wcs201.CoverageDescription) ows.Exception {
+	envelope := d.BoundedBy.Envelope
+	if len(envelope.LowerCorner) != len(envelope.AxisLabels) || len(envelope.UpperCorner) != len(envelope.AxisLabels) {
+		return ows.NoApplicableCode(fmt.Sprintf("The Envelope of the coverage %s has %d axis labels, but its corners have %d and %d coordinates",
+			d.CoverageID, len(envelope.AxisLabels), len(envelope.LowerCorner), len(envelope.UpperCorner)))
+	}
+	return nil
+}
+
+// subsettingCRS returns the CRS of the subset, that is the CRS of a CRS-qualified axis or else the SUBSETTINGCRS
+// An empty CRS means the native CRS of the coverage
+func (gc *GetCoverage) subsettingCRS(dimension Dimension) string {
+	if dimension.CRS == `` && gc.Extension != nil {
+		return gc.Extension.SubsettingCRS
+	}
+	return dimension.CRS
+}
+
+// axes returns the subsetted axes in the order of the trims and slices
+func (gc *GetCoverage) axes() []string {
+	var axes []string
+	for _, t := range gc.DimensionTrim {
+		axes = append(axes, t.Dimension.Axis)
+	}
+	for _, s := range gc.DimensionSlice {
+		axes = append(axes, s.Dimension.Axis)
+	}
+	return axes
+}
+
+// validate checks the trim against the extent of the axis, an omitted bound is unbounded
+func (t *DimensionTrim) validate(lower, upper string) ows.Exceptions {
+	var bounds []string
+	for _, b := range []*string{t.TrimLow, t.TrimHigh} {
+		if b == nil {
+			bounds = append(bounds, `*`)
+			continue
+		}
+		if _, ok := compareCoordinates(*b, lower); !ok {
+			return ows.Exceptions{exception.InvalidSubsetting(t.Dimension.Axis, fmt.Sprintf("%s can't be compared with the extent (%s,%s)", *b, lower, upper))}
+		}
+		bounds = append(bounds, *b)
+	}
+
+	if t.TrimLow != nil && t.TrimHigh != nil {
+		if c, _ := compareCoordinates(*t.TrimLow, *t.TrimHigh); c > 0 {
+			return ows.Exceptions{exception.InvalidSubsetting(t.Dimension.Axis, fmt.Sprintf("the lower bound %s is above the upper bound %s", *t.TrimLow, *t.TrimHigh))}
+		}
+	}
+	cl, cu := -1, 1
+	if t.TrimLow != nil {
+		cl, _ = compareCoordinates(*t.TrimLow, upper)
+	}
+	if t.TrimHigh != nil {
+		cu, _ = compareCoordinates(*t.TrimHigh, lower)
+	}
+	if cl > 0 || cu < 0 {
+		return ows.Exceptions{exception.InvalidSubsetting(t.Dimension.Axis, fmt.Sprintf("the trim (%s,%s) lies outside the extent (%s,%s)", bounds[0], bounds[1], lower, upper))}
+	}
+	return nil
+}
+
+// validate checks if the slice point lies within the extent of the axis
+func (s *DimensionSlice) validate(lower, upper string) ows.Exceptions {
+	cl, ok := compareCoordinates(s.SlicePoint, lower)
+	if !ok {
+		return ows.Exceptions{exception.InvalidSubsetting(s.Dimension.Axis, fmt.Sprintf("%s can't be compared with the extent (%s,%s)", s.SlicePoint, lower, upper))}
+	}
+	if cu, _ := compareCoordinates(s.SlicePoint, upper); cl < 0 || cu > 0 {
+		return ows.Exceptions{exception.InvalidSubsetting(s.Dimension.Axis, fmt.Sprintf("the slice point %s lies outside the extent (%s,%s)", s.SlicePoint, lower, upper))}
+	}
+	return nil
+}
+
+// timeLayouts are the ISO 8601 layouts accepted for the time positions
+var timeLayouts = []string{time.RFC3339Nano, `2006-01-02T15:04:05`, `2006-01-02T15:04`, `2006-01-02`, `2006-01`, `2006`}
+
+// compareCoordinates compares two coordinate values, that are both numbers or both ISO 8601 time positions
+// It returns -1, 0 or +1 and false when the values can't be compared
+func compareCoordinates(a, b string) (int, bool) {
+	a, b = strings.Trim(a, `"`), strings.Trim(b, `"`)
+
+	fa, erra := strconv.ParseFloat(a, 64)
+	fb, errb := strconv.ParseFloat(b, 64)
+	if erra == nil && errb == nil {
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	ta, oka := parseTime(a)
+	tb, okb := parseTime(b)
+	if !oka || !okb {
+		return 0, false
+	}
+	switch {
+	case ta.Before(tb):
+		return -1, true
+	case ta.After(tb):
+		return 1, true
+	}
+	return 0, true
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseOperationRequestKVP process the simple struct to a complex struct
+func (gc *GetCoverage) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Exceptions {
+	gckvp := orkvp.(*GetCoverageKVP)
+
+	exceptions := gc.BaseRequest.Build(gckvp.Service, gckvp.Version)
+	if gckvp.CoverageID == `` {
+		exceptions = append(exceptions, ows.MissingParameterValue(COVERAGEID))
+	}
+
+	gc.XMLName.Local = getcoverage
+	gc.CoverageID = gckvp.CoverageID
+	gc.Format = gckvp.Format
+	gc.MediaType = gckvp.MediaType
+
+	extension, err := gckvp.buildExtension()
+	exceptions = append(exceptions, err...)
+	gc.Extension = extension
+
+	for _, subset := range gckvp.Subset {
+		trim, slice, err := parseSubset(subset)
+		if err != nil {
+			exceptions = append(exceptions, err)
+			continue
+		}
+		if trim != nil {
+			gc.DimensionTrim = append(gc.DimensionTrim, *trim)
+		}
+		if slice != nil {
+			gc.DimensionSlice = append(gc.DimensionSlice, *slice)
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// ParseKVP builds a GetCoverage object based on the available query parameters
+func (gc *GetCoverage) ParseKVP(query url.Values) ows.Exceptions {
+	if len(query) == 0 {
+		// When there are no query values we know that at least
+		// the mandatory SERVICE, VERSION and REQUEST parameter is missing.
+		return ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}
+	}
+
+	gckvp := GetCoverageKVP{}
+	if err := gckvp.ParseKVP(query); err != nil {
+		return err
+	}
+
+	if err := gc.ParseOperationRequestKVP(&gckvp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseXML builds a GetCoverage object based on a XML document
+func (gc *GetCoverage) ParseXML(body []byte) ows.Exceptions {
+	var xmlattributes ows.XMLAttribute
+	if err := xml.Unmarshal(body, &xmlattributes); err != nil {
+		return ows.Exceptions{ows.MissingParameterValue()}
+	}
+	if err := xml.Unmarshal(body, &gc); err != nil {
+		return ows.Exceptions{ows.NoApplicableCode(err.Error())}
+	}
+	var n []xml.Attr
+	for _, a := range xmlattributes {
+		switch strings.ToUpper(a.Name.Local) {
+		case VERSION:
+		case SERVICE:
+		default:
+			n = append(n, a)
+		}
+	}
+	gc.BaseRequest.Attr = ows.StripDuplicateAttr(n)
+	return nil
+}
+
+// BuildKVP builds a new query string that will be proxied
+func (gc *GetCoverage) BuildKVP() url.Values {
+	gckvp := GetCoverageKVP{}
+	gckvp.ParseOperationRequest(gc)
+
+	return gckvp.BuildKVP()
+}
+
+// BuildXML builds a 'new' XML document 'based' on the 'original' XML document
+func (gc *GetCoverage) BuildXML() []byte {
+	si, _ := ows.CanonicalNamespaces.Marshal(gc, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// GetCoverage struct with the needed parameters/attributes needed for making a GetCoverage request
+// Struct based on http://schemas.opengis.net/wcs/2.0/wcsGetCoverage.xsd
+// The Extension is the first element, as defined by the RequestBaseType of wcsCommon.xsd
+type GetCoverage struct {
+	XMLName xml.Name `xml:"http://www.opengis.net/wcs/2.0 GetCoverage" yaml:"getcoverage"`
+	BaseRequest
+	Extension      *Extension       `xml:"http://www.opengis.net/wcs/2.0 Extension" yaml:"extension,omitempty"`
+	CoverageID     string           `xml:"http://www.opengis.net/wcs/2.0 CoverageId" yaml:"coverageid"`
+	DimensionTrim  []DimensionTrim  `xml:"http://www.opengis.net/wcs/2.0 DimensionTrim" yaml:"dimensiontrim,omitempty"`
+	DimensionSlice []DimensionSlice `xml:"http://www.opengis.net/wcs/2.0 DimensionSlice" yaml:"dimensionslice,omitempty"`
+	Format         string           `xml:"http://www.opengis.net/wcs/2.0 format,omitempty" yaml:"format,omitempty"`
+	MediaType      string           `xml:"http://www.opengis.net/wcs/2.0 mediaType,omitempty" yaml:"mediatype,omitempty"`
+}
+
+// Dimension is the subsetted axis, the CRS is only set for a CRS-qualified axis
+type Dimension struct {
+	CRS  string `xml:"crs,attr,omitempty" yaml:"crs,omitempty"`
+	Axis string `xml:",chardata" yaml:"axis"`
+}
+
+// DimensionTrim is a subset that keeps the dimension, a missing TrimLow or TrimHigh is unbounded
+// The values are numbers or ISO 8601 time positions
+type DimensionTrim struct {
+	Dimension Dimension `xml:"http://www.opengis.net/wcs/2.0 Dimension" yaml:"dimension"`
+	TrimLow   *string   `xml:"http://www.opengis.net/wcs/2.0 TrimLow" yaml:"trimlow,omitempty"`
+	TrimHigh  *string   `xml:"http://www.opengis.net/wcs/2.0 TrimHigh" yaml:"trimhigh,omitempty"`
+}
+
+// DimensionSlice is a subset that reduces the dimension of the coverage by one
+type DimensionSlice struct {
+	Dimension  Dimension `xml:"http://www.opengis.net/wcs/2.0 Dimension" yaml:"dimension"`
+	SlicePoint string    `xml:"http://www.opengis.net/wcs/2.0 SlicePoint" yaml:"slicepoint"`
+}
@@ -0,0 +1,248 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+)
+
+func sp(s string) *string {
+	return &s
+}
+
+func TestGetCoverageType(t *testing.T) {
+	gc := GetCoverage{}
+	if gc.Type() != `GetCoverage` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, `GetCoverage`, gc.Type())
+	}
+}
+
+func TestGetCoverageParseKVP(t *testing.T) {
+	var tests = []struct {
+		query       url.Values
+		getcoverage GetCoverage
+		exceptions  ows.Exceptions
+	}{
+		0: {query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`},
+			SUBSET: {`x(120000,125000)`, `y(480000,*)`}, FORMAT: {`image/tiff`}, MEDIATYPE: {`multipart/related`}},
+			getcoverage: GetCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: `dtm_05m`,
+				DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`120000`), TrimHigh: sp(`125000`)}, {Dimension: Dimension{Axis: `y`}, TrimLow: sp(`480000`)}},
+				Format:        `image/tiff`, MediaType: `multipart/related`}},
+		// Slices, CRS-qualified axes and ISO 8601 time axes
+		1: {query: map[string][]string{`service`: {`WCS`}, `version`: {`2.0.1`}, `request`: {`GetCoverage`}, `CoverageId`: {`temperature`},
+			`subset`: {`Lat,http://www.opengis.net/def/crs/EPSG/0/4326(52.1)`, `ansi("2006-08-01","2006-08-22T12:00:00Z")`, `h(0)`}},
+			getcoverage: GetCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: `temperature`,
+				DimensionTrim:  []DimensionTrim{{Dimension: Dimension{Axis: `ansi`}, TrimLow: sp(`2006-08-01`), TrimHigh: sp(`2006-08-22T12:00:00Z`)}},
+				DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `Lat`, CRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}, SlicePoint: `52.1`}, {Dimension: Dimension{Axis: `h`}, SlicePoint: `0`}}}},
+		2: {query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`}, SUBSET: {`x[1,2]`, `y(*)`, `z(1,2,3)`, `t(,2)`}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`x[1,2]`, SUBSET), ows.InvalidParameterValue(`y(*)`, SUBSET),
+				ows.InvalidParameterValue(`z(1,2,3)`, SUBSET), ows.InvalidParameterValue(`t(,2)`, SUBSET)}},
+		3: {query: map[string][]string{SERVICE: {`WCS`}, REQUEST: {`GetCoverage`}},
+			exceptions: ows.Exceptions{ows.MissingParameterValue(VERSION), ows.MissingParameterValue(COVERAGEID)}},
+		4: {query: map[string][]string{}, exceptions: ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}},
+	}
+
+	for k, test := range tests {
+		var gc GetCoverage
+		exceptions := gc.ParseKVP(test.query)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.getcoverage.XMLName.Local = `GetCoverage`
+		if !reflect.DeepEqual(gc, test.getcoverage) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.getcoverage, gc)
+		}
+	}
+}
+
+func TestGetCoverageBuildKVP(t *testing.T) {
+	var tests = []struct {
+		getcoverage GetCoverage
+		query       url.Values
+	}{
+		0: {getcoverage: GetCoverage{CoverageID: `temperature`,
+			DimensionTrim:  []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimHigh: sp(`125000`)}, {Dimension: Dimension{Axis: `ansi`}, TrimLow: sp(`2006-08-01`), TrimHigh: sp(`2006-08-22`)}},
+			DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `Lat`, CRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}, SlicePoint: `52.1`}},
+			Format:         `image/tiff`},
+			query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`temperature`},
+				SUBSET: {`x(*,125000)`, `ansi("2006-08-01","2006-08-22")`, `Lat,http://www.opengis.net/def/crs/EPSG/0/4326(52.1)`}, FORMAT: {`image/tiff`}}},
+		1: {getcoverage: GetCoverage{CoverageID: `dtm_05m`},
+			query: map[string][]string{SERVICE: {`WCS`}, VERSION: {`2.0.1`}, REQUEST: {`GetCoverage`}, COVERAGEID: {`dtm_05m`}}},
+	}
+
+	for k, test := range tests {
+		query := test.getcoverage.BuildKVP()
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
+
+var getCoverageXML = `<?xml version="1.0" encoding="UTF-8"?>
+<wcs:GetCoverage xmlns:wcs="http://www.opengis.net/wcs/2.0" service="WCS" version="2.0.1">
+ <wcs:CoverageId>temperature</wcs:CoverageId>
+ <wcs:DimensionTrim>
+  <wcs:Dimension>ansi</wcs:Dimension>
+  <wcs:TrimLow>2006-08-01</wcs:TrimLow>
+  <wcs:TrimHigh>2006-08-22</wcs:TrimHigh>
+ </wcs:DimensionTrim>
+ <wcs:DimensionTrim>
+  <wcs:Dimension>x</wcs:Dimension>
+  <wcs:TrimHigh>125000</wcs:TrimHigh>
+ </wcs:DimensionTrim>
+ <wcs:DimensionSlice>
+  <wcs:Dimension crs="http://www.opengis.net/def/crs/EPSG/0/4326">Lat</wcs:Dimension>
+  <wcs:SlicePoint>52.1</wcs:SlicePoint>
+ </wcs:DimensionSlice>
+ <wcs:format>image/tiff</wcs:format>
+ <wcs:mediaType>multipart/related</wcs:mediaType>
+</wcs:GetCoverage>`
+
+var getCoverageStruct = GetCoverage{BaseRequest: BaseRequest{Service: `WCS`, Version: `2.0.1`}, CoverageID: `temperature`,
+	DimensionTrim:  []DimensionTrim{{Dimension: Dimension{Axis: `ansi`}, TrimLow: sp(`2006-08-01`), TrimHigh: sp(`2006-08-22`)}, {Dimension: Dimension{Axis: `x`}, TrimHigh: sp(`125000`)}},
+	DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `Lat`, CRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}, SlicePoint: `52.1`}},
+	Format:         `image/tiff`, MediaType: `multipart/related`}
+
+func TestGetCoverageParseXML(t *testing.T) {
+	var tests = []struct {
+		body        []byte
+		getcoverage GetCoverage
+		exceptions  ows.Exceptions
+	}{
+		0: {body: []byte(getCoverageXML), getcoverage: getCoverageStruct},
+		1: {body: []byte(`no XML document, just a string`), exceptions: ows.Exceptions{ows.MissingParameterValue()}},
+	}
+
+	for k, test := range tests {
+		var gc GetCoverage
+		exceptions := gc.ParseXML(test.body)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if gc.XMLName.Local != `GetCoverage` || gc.CoverageID != test.getcoverage.CoverageID || !reflect.DeepEqual(gc.DimensionTrim, test.getcoverage.DimensionTrim) ||
+			!reflect.DeepEqual(gc.DimensionSlice, test.getcoverage.DimensionSlice) || gc.Format != test.getcoverage.Format || gc.MediaType != test.getcoverage.MediaType ||
+			gc.Service != test.getcoverage.Service || gc.Version != test.getcoverage.Version {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.getcoverage, gc)
+		}
+	}
+}
+
+func TestGetCoverageBuildXML(t *testing.T) {
+	var tests = []struct {
+		getcoverage GetCoverage
+		result      string
+	}{
+		0: {getcoverage: getCoverageStruct, result: getCoverageXML},
+	}
+
+	for k, test := range tests {
+		result := string(test.getcoverage.BuildXML())
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestGetCoverageValidate(t *testing.T) {
+	var c capabilities.Capabilities
+	c.ServiceMetadata.FormatSupported = []string{`image/tiff`, `application/gml+xml`}
+	c.Contents.CoverageSummary = []capabilities.CoverageSummary{{CoverageID: `dtm_05m`, CoverageSubtype: `RectifiedGridCoverage`}}
+
+	var tests = []struct {
+		getcoverage GetCoverage
+		exceptions  ows.Exceptions
+	}{
+		0: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, Format: `image/tiff`, MediaType: `multipart/related`}},
+		1: {getcoverage: GetCoverage{CoverageID: `unknown`, Format: `image/png`, MediaType: `text/html`},
+			exceptions: ows.Exceptions{exception.NoSuchCoverage(`unknown`), ows.InvalidParameterValue(`image/png`, FORMAT), ows.InvalidParameterValue(`text/html`, MEDIATYPE)}},
+		// an axis can only be subsetted once
+		2: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}}}, DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`}, SlicePoint: `1`}}},
+			exceptions: ows.Exceptions{exception.InvalidAxisLabel(`x`)}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.getcoverage.Validate(&c)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+	// capabilities of another type result in an exception instead of a panic
+	expected := ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WCS 2.0.1 capabilities`)}
+	if exceptions := tests[0].getcoverage.Validate(nil); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 3, expected, exceptions)
+	}
+}
+
+func TestGetCoverageValidateDomain(t *testing.T) {
+	description := wcs201.CoverageDescription{CoverageID: `temperature`, BoundedBy: wcs201.BoundedBy{Envelope: wcs201.Envelope{
+		SrsName:      `http://www.opengis.net/def/crs-compound?1=http://www.opengis.net/def/crs/EPSG/0/28992&2=http://www.opengis.net/def/crs/OGC/0/AnsiDate`,
+		AxisLabels:   wcs201.Labels{`x`, `y`, `ansi`},
+		SrsDimension: 3,
+		LowerCorner:  wcs201.Coordinates{`10000`, `300000`, `"2006-01-01T00:00:00Z"`},
+		UpperCorner:  wcs201.Coordinates{`280000`, `625000`, `"2006-12-31T00:00:00Z"`}}}}
+
+	var tests = []struct {
+		getcoverage GetCoverage
+		exceptions  ows.Exceptions
+	}{
+		0: {getcoverage: GetCoverage{DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`0`), TrimHigh: sp(`125000`)}, {Dimension: Dimension{Axis: `ansi`}, TrimLow: sp(`2006-08-01`)}},
+			DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `y`}, SlicePoint: `480000`}}}},
+		1: {getcoverage: GetCoverage{DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `Lat`}}}, DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `X`}, SlicePoint: `1`}}},
+			exceptions: ows.Exceptions{exception.InvalidAxisLabel(`Lat`), exception.InvalidAxisLabel(`X`)}},
+		2: {getcoverage: GetCoverage{DimensionTrim: []DimensionTrim{
+			{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`20000`), TrimHigh: sp(`10000`)},
+			{Dimension: Dimension{Axis: `y`}, TrimHigh: sp(`200000`)},
+			{Dimension: Dimension{Axis: `ansi`}, TrimLow: sp(`2007`)}}},
+			exceptions: ows.Exceptions{
+				exception.InvalidSubsetting(`x`, `the lower bound 20000 is above the upper bound 10000`),
+				exception.InvalidSubsetting(`y`, `the trim (*,200000) lies outside the extent (300000,625000)`),
+				exception.InvalidSubsetting(`ansi`, `the trim (2007,*) lies outside the extent ("2006-01-01T00:00:00Z","2006-12-31T00:00:00Z")`)}},
+		3: {getcoverage: GetCoverage{DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`}, SlicePoint: `5000`}, {Dimension: Dimension{Axis: `ansi`}, SlicePoint: `yesterday`}}},
+			exceptions: ows.Exceptions{
+				exception.InvalidSubsetting(`x`, `the slice point 5000 lies outside the extent (10000,280000)`),
+				exception.InvalidSubsetting(`ansi`, `yesterday can't be compared with the extent ("2006-01-01T00:00:00Z","2006-12-31T00:00:00Z")`)}},
+		// subsets in another CRS aren't checked against the extent
+		4: {getcoverage: GetCoverage{DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`, CRS: `http://www.opengis.net/def/crs/EPSG/0/4326`}, SlicePoint: `5`}}}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.getcoverage.ValidateDomain(description)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
+
+func TestGetCoverageValidateDomainEnvelope(t *testing.T) {
+	getcoverage := GetCoverage{DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `y`}, TrimLow: sp(`300000`)}}}
+
+	var tests = []struct {
+		envelope   wcs201.Envelope
+		exceptions ows.Exceptions
+	}{
+		0: {envelope: wcs201.Envelope{AxisLabels: wcs201.Labels{`x`, `y`}},
+			exceptions: ows.Exceptions{ows.NoApplicableCode(`The Envelope of the coverage dem has 2 axis labels, but its corners have 0 and 0 coordinates`)}},
+		1: {envelope: wcs201.Envelope{AxisLabels: wcs201.Labels{`x`, `y`}, LowerCorner: wcs201.Coordinates{`10000`, `300000`}, UpperCorner: wcs201.Coordinates{`280000`}},
+			exceptions: ows.Exceptions{ows.NoApplicableCode(`The Envelope of the coverage dem has 2 axis labels, but its corners have 2 and 1 coordinates`)}},
+	}
+
+	for k, test := range tests {
+		exceptions := getcoverage.ValidateDomain(wcs201.CoverageDescription{CoverageID: `dem`, BoundedBy: wcs201.BoundedBy{Envelope: test.envelope}})
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package request
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// GetCoverageKVP struct
+type GetCoverageKVP struct {
+	// Table 28 - GetCoverage request parameters
+	BaseRequestKVP
+	CoverageID string `yaml:"coverageid,omitempty"`
+	// Subset contains the SUBSET values as given, like x(10,20) or ansi("2006-08-01")
+	Subset    []string `yaml:"subset,omitempty"`
+	Format    string   `yaml:"format,omitempty"`
+	MediaType string   `yaml:"mediatype,omitempty"`
+	// WCS 2.0 extension parameters
+	ScaleFactor   string `yaml:"scalefactor,omitempty"`
+	ScaleAxes     string `yaml:"scaleaxes,omitempty"`
+	ScaleSize     string `yaml:"scalesize,omitempty"`
+	ScaleExtent   string `yaml:"scaleextent,omitempty"`
+	SubsettingCRS string `yaml:"subsettingcrs,omitempty"`
+	OutputCRS     string `yaml:"outputcrs,omitempty"`
+	Interpolation string `yaml:"interpolation,omitempty"`
+	RangeSubset   string `yaml:"rangesubset,omitempty"`
+}
+
+// ParseKVP builds a GetCoverageKVP object based on the available query parameters
+// SUBSET is the only parameter that can be repeated
+func (gckvp *GetCoverageKVP) ParseKVP(query url.Values) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for k, v := range query {
+		if strings.ToUpper(k) == SUBSET {
+			gckvp.Subset = append(gckvp.Subset, v...)
+			continue
+		}
+		if len(v) != 1 {
+			exceptions = append(exceptions, ows.InvalidParameterValue(strings.Join(v, ","), k))
+			continue
+		}
+		if gckvp.BaseRequestKVP.ParseKVP(strings.ToUpper(k), v[0]) {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case COVERAGEID:
+			gckvp.CoverageID = v[0]
+		case FORMAT:
+			gckvp.Format = v[0]
+		case MEDIATYPE:
+			gckvp.MediaType = v[0]
+		case SCALEFACTOR:
+			gckvp.ScaleFactor = v[0]
+		case SCALEAXES:
+			gckvp.ScaleAxes = v[0]
+		case SCALESIZE:
+			gckvp.ScaleSize = v[0]
+		case SCALEEXTENT:
+			gckvp.ScaleExtent = v[0]
+		case SUBSETTINGCRS:
+			gckvp.SubsettingCRS = v[0]
+		case OUTPUTCRS:
+			gckvp.OutputCRS = v[0]
+		case INTERPOLATION:
+			gckvp.Interpolation = v[0]
+		case RANGESUBSET:
+			gckvp.RangeSubset = v[0]
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	return nil
+}
+
+// ParseOperationRequest builds a GetCoverageKVP object based on a GetCoverage struct
+func (gckvp *GetCoverageKVP) ParseOperationRequest(or ows.OperationRequest) ows.Exceptions {
+	gc := or.(*GetCoverage)
+
+	gckvp.Request = getcoverage
+	gckvp.Version = Version
+	gckvp.Service = Service
+	gckvp.CoverageID = gc.CoverageID
+	gckvp.Format = gc.Format
+	gckvp.MediaType = gc.MediaType
+
+	gckvp.parseExtension(gc.Extension)
+
+	gckvp.Subset = nil
+	for _, t := range gc.DimensionTrim {
+		gckvp.Subset = append(gckvp.Subset, t.Dimension.kvp()+`(`+kvpBound(t.TrimLow)+`,`+kvpBound(t.TrimHigh)+`)`)
+	}
+	for _, s := range gc.DimensionSlice {
+		gckvp.Subset = append(gckvp.Subset, s.Dimension.kvp()+`(`+kvpValue(s.SlicePoint)+`)`)
+	}
+
+	return nil
+}
+
+// BuildKVP builds a url.Values query from a GetCoverageKVP object
+func (gckvp *GetCoverageKVP) BuildKVP() url.Values {
+	query := make(map[string][]string)
+	gckvp.BaseRequestKVP.BuildKVP(query)
+	query[COVERAGEID] = []string{gckvp.CoverageID}
+	if len(gckvp.Subset) > 0 {
+		query[SUBSET] = gckvp.Subset
+	}
+	if gckvp.Format != `` {
+		query[FORMAT] = []string{gckvp.Format}
+	}
+	if gckvp.MediaType != `` {
+		query[MEDIATYPE] = []string{gckvp.MediaType}
+	}
+	for _, p := range []struct{ key, value string }{
+		{SCALEFACTOR, gckvp.ScaleFactor},
+		{SCALEAXES, gckvp.ScaleAxes},
+		{SCALESIZE, gckvp.ScaleSize},
+		{SCALEEXTENT, gckvp.ScaleExtent},
+		{SUBSETTINGCRS, gckvp.SubsettingCRS},
+		{OUTPUTCRS, gckvp.OutputCRS},
+		{INTERPOLATION, gckvp.Interpolation},
+		{RANGESUBSET, gckvp.RangeSubset},
+	} {
+		if p.value != `` {
+			query[p.key] = []string{p.value}
+		}
+	}
+
+	return query
+}
+
+// subsetRegex matches axis[,crs](low,high) and axis[,crs](point)
+var subsetRegex = regexp.MustCompile(`^\s*([^,()\s]+)\s*(?:,\s*([^()\s]+)\s*)?\(([^()]*)\)\s*$`)
+
+// parseSubset parses a SUBSET value into a DimensionTrim or a DimensionSlice
+// The quotes around the time positions are removed and a * is an unbounded trim
+func parseSubset(subset string) (*DimensionTrim, *DimensionSlice, ows.Exception) {
+	match := subsetRegex.FindStringSubmatch(subset)
+	if match == nil {
+		return nil, nil, ows.InvalidParameterValue(subset, SUBSET)
+	}
+	dimension := Dimension{Axis: match[1], CRS: match[2]}
+
+	values := strings.Split(match[3], `,`)
+	for i := range values {
+		values[i] = strings.Trim(strings.TrimSpace(values[i]), `"`)
+	}
+
+	switch len(values) {
+	case 1:
+		if values[0] == `` || values[0] == `*` {
+			return nil, nil, ows.InvalidParameterValue(subset, SUBSET)
+		}
+		return nil, &DimensionSlice{Dimension: dimension, SlicePoint: values[0]}, nil
+	case 2:
+		if values[0] == `` || values[1] == `` {
+			return nil, nil, ows.InvalidParameterValue(subset, SUBSET)
+		}
+		trim := DimensionTrim{Dimension: dimension}
+		if values[0] != `*` {
+			trim.TrimLow = &values[0]
+		}
+		if values[1] != `*` {
+			trim.TrimHigh = &values[1]
+		}
+		return &trim, nil, nil
+	}
+	return nil, nil, ows.InvalidParameterValue(subset, SUBSET)
+}
+
+// kvp returns the axis of the SUBSET, with the CRS for a CRS-qualified axis
+func (d Dimension) kvp() string {
+	if d.CRS == `` {
+		return d.Axis
+	}
+	return d.Axis + `,` + d.CRS
+}
+
+// kvpBound returns the trim bound as KVP value, an unbounded trim is a *
+func kvpBound(bound *string) string {
+	if bound == nil {
+		return `*`
+	}
+	return kvpValue(*bound)
+}
+
+// kvpValue returns the value as KVP value, values that aren't a number (the time positions) are quoted
+func kvpValue(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return `"` + value + `"`
+}
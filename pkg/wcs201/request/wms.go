@@ -0,0 +1,299 @@
+package request
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+	wms130 "github.com/pdok/ogc-specifications/pkg/wms130/request"
+)
+
+// sldVersion of the StyledLayerDescriptor in the WMS requests
+const sldVersion = `1.1.0`
+
+// BuildGetMap builds the WMS 1.3.0 GetMap request that renders the requested coverage
+// The first two axes of the Envelope are the horizontal axes of the map in the axis order of the native CRS,
+// so the first one is the WIDTH and the second one the HEIGHT unless the native CRS has a northing easting axis order.
+// The BBOX are the trims of these axes, an unbounded or untrimmed axis takes the extent of the coverage.
+// The CRS is the OUTPUTCRS or else the native CRS of the coverage, trims in the OUTPUTCRS keep the easting
+// or northing of the axis they trim, so the BBOX is reordered when the OUTPUTCRS has another axis order.
+// The WIDTH and HEIGHT are the SCALESIZE of the horizontal axes, without a SCALESIZE it's the size of the trimmed grid.
+// The subsets of the other axes become the TIME, ELEVATION or DIM_<name> dimensions, a trim becomes a low/high interval.
+//
+// GetCoverage features that can't be mapped on a GetMap result in an OptionNotSupported exception:
+// a slice of a horizontal axis, a map always has both axes
+// a trim of a horizontal axis in another CRS than the map CRS
+// an OUTPUTCRS without trims of both horizontal axes in that CRS, the extent of the coverage is only known in the native CRS
+// the multipart MEDIATYPE, the WMS only returns the image
+// the SCALEFACTOR, SCALEAXES and SCALEEXTENT scaling
+// the INTERPOLATION and RANGESUBSET parameters
+func (gc *GetCoverage) BuildGetMap(d wcs201.CoverageDescription) (wms130.GetMap, ows.Exceptions) {
+	if exceptions := gc.ValidateDomain(d); exceptions != nil {
+		return wms130.GetMap{}, exceptions
+	}
+
+	envelope := d.BoundedBy.Envelope
+	if len(envelope.AxisLabels) < 2 {
+		return wms130.GetMap{}, ows.Exceptions{ows.NoApplicableCode(fmt.Sprintf("The coverage %s has no horizontal axes to map", d.CoverageID))}
+	}
+
+	crs := envelope.SrsName
+	if gc.Extension != nil && gc.Extension.OutputCRS != `` {
+		crs = gc.Extension.OutputCRS
+	}
+	exceptions := gc.unmappable(envelope, crs)
+	wmscrs, err := ows.ParseCRS(crs)
+	if err != nil || wmscrs.Namespace == `` {
+		exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("The CRS %s can't be used as WMS CRS", crs)))
+	}
+	if len(exceptions) > 0 {
+		return wms130.GetMap{}, exceptions
+	}
+
+	bbox, exceptions := gc.bbox(envelope, crs)
+	if exceptions != nil {
+		return wms130.GetMap{}, exceptions
+	}
+	size, exceptions := gc.size(d, bbox)
+	if exceptions != nil {
+		return wms130.GetMap{}, exceptions
+	}
+	// the axes of the Envelope, and so the bbox and size, are in the axis order of the native CRS
+	nativecrs, _ := ows.ParseCRS(envelope.SrsName)
+	if nativecrs.AxisOrder() == ows.NorthingEasting {
+		size.Width, size.Height = size.Height, size.Width
+	}
+
+	format := gc.Format
+	if format == `` {
+		format = d.ServiceParameters.NativeFormat
+	}
+
+	var gm wms130.GetMap
+	gm.XMLName.Local = `GetMap`
+	gm.BaseRequest = wms130.BaseRequest{Service: wms130.Service, Version: wms130.Version}
+	gm.StyledLayerDescriptor = wms130.StyledLayerDescriptor{
+		Version:    sldVersion,
+		NamedLayer: []wms130.NamedLayer{{Name: gc.CoverageID, NamedStyle: &wms130.NamedStyle{}}},
+	}
+	gm.CRS = wmscrs
+	gm.BoundingBox = bbox.ToXY(nativecrs)
+	gm.Output = wms130.Output{Size: size, Format: format}
+	gm.Dimensions, exceptions = gc.wmsDimensions(envelope)
+	if exceptions != nil {
+		return wms130.GetMap{}, exceptions
+	}
+
+	return gm, nil
+}
+
+// unmappable returns an OptionNotSupported exception for every GetCoverage feature that can't be mapped on the GetMap in the given CRS
+func (gc *GetCoverage) unmappable(envelope wcs201.Envelope, crs string) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, s := range gc.DimensionSlice {
+		if isHorizontal(envelope, s.Dimension.Axis) {
+			exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("The slice of the horizontal axis %s can't be mapped on a WMS GetMap", s.Dimension.Axis)))
+		}
+	}
+	for _, t := range gc.DimensionTrim {
+		if !isHorizontal(envelope, t.Dimension.Axis) {
+			continue
+		}
+		if subsettingcrs := gc.nativeSubsettingCRS(t.Dimension, envelope); subsettingcrs != crs {
+			exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("The trim of the horizontal axis %s in %s can't be mapped on a WMS GetMap in %s", t.Dimension.Axis, subsettingcrs, crs)))
+		}
+	}
+	if gc.MediaType != `` {
+		exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("The %s %s can't be mapped on a WMS GetMap", MEDIATYPE, gc.MediaType)))
+	}
+
+	if gc.Extension == nil {
+		return exceptions
+	}
+	if s := gc.Extension.Scaling; s != nil {
+		for _, p := range []struct {
+			key  string
+			used bool
+		}{
+			{SCALEFACTOR, s.ScaleByFactor != nil},
+			{SCALEAXES, s.ScaleAxesByFactor != nil},
+			{SCALEEXTENT, s.ScaleToExtent != nil},
+		} {
+			if p.used {
+				exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("%s can't be mapped on a WMS GetMap, only %s is supported", p.key, SCALESIZE)))
+			}
+		}
+	}
+	if gc.Extension.Interpolation != nil {
+		exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("%s can't be mapped on a WMS GetMap", INTERPOLATION)))
+	}
+	if gc.Extension.RangeSubset != nil {
+		exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("%s can't be mapped on a WMS GetMap", RANGESUBSET)))
+	}
+	return exceptions
+}
+
+// bbox returns the BBOX of the map from the trims of the horizontal axes, in the axis order of the Envelope
+// Without a trim or with an unbounded trim the extent of the coverage is used, which is only known in the native CRS
+func (gc *GetCoverage) bbox(envelope wcs201.Envelope, crs string) (ows.BoundingBox, ows.Exceptions) {
+	if len(envelope.AxisLabels) < 2 || len(envelope.LowerCorner) < 2 || len(envelope.UpperCorner) < 2 {
+		return ows.BoundingBox{}, ows.Exceptions{ows.NoApplicableCode(`The Envelope has no extent for the horizontal axes`)}
+	}
+	// a WMS BBOX only has the horizontal axes
+	bbox := ows.BoundingBox{LowerCorner: make(ows.Position, 2), UpperCorner: make(ows.Position, 2)}
+	var exceptions ows.Exceptions
+	for i, axis := range envelope.AxisLabels[:2] {
+		low, high := envelope.LowerCorner[i], envelope.UpperCorner[i]
+		native := true
+		for _, t := range gc.DimensionTrim {
+			if t.Dimension.Axis != axis {
+				continue
+			}
+			if t.TrimLow != nil {
+				low = *t.TrimLow
+			}
+			if t.TrimHigh != nil {
+				high = *t.TrimHigh
+			}
+			native = t.TrimLow == nil || t.TrimHigh == nil
+		}
+		if native && crs != envelope.SrsName {
+			exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("The extent of the axis %s in %s is unknown, a trim of both bounds is needed to map it on a WMS GetMap", axis, crs)))
+			continue
+		}
+
+		l, errl := strconv.ParseFloat(strings.Trim(low, `"`), 64)
+		h, errh := strconv.ParseFloat(strings.Trim(high, `"`), 64)
+		if errl != nil || errh != nil {
+			exceptions = append(exceptions, ows.OptionNotSupported(fmt.Sprintf("The extent (%s,%s) of the axis %s can't be mapped on a WMS BBOX", low, high, axis)))
+			continue
+		}
+		bbox.LowerCorner[i], bbox.UpperCorner[i] = l, h
+	}
+
+	if len(exceptions) > 0 {
+		return bbox, exceptions
+	}
+	return bbox, nil
+}
+
+// size returns the sizes of the horizontal axes of the map as WIDTH and HEIGHT, in the axis order of the Envelope
+// These are the SCALESIZE of the horizontal axes or the number of grid cells of the coverage within the BBOX
+func (gc *GetCoverage) size(d wcs201.CoverageDescription, bbox ows.BoundingBox) (wms130.Size, ows.Exceptions) {
+	envelope := d.BoundedBy.Envelope
+
+	var sizes [2]int
+	for i, axis := range envelope.AxisLabels[:2] {
+		if size, ok := gc.scaleSize(axis); ok {
+			sizes[i] = size
+			continue
+		}
+		// without a grid the size can't be derived and needs to be given
+		resolution, ok := gridResolution(d, i)
+		if !ok {
+			return wms130.Size{}, ows.Exceptions{ows.MissingParameterValue(SCALESIZE)}
+		}
+		sizes[i] = int(math.Round((bbox.UpperCorner[i] - bbox.LowerCorner[i]) / resolution))
+	}
+	return wms130.Size{Width: sizes[0], Height: sizes[1]}, nil
+}
+
+// scaleSize returns the SCALESIZE of the axis
+func (gc *GetCoverage) scaleSize(axis string) (int, bool) {
+	if gc.Extension == nil || gc.Extension.Scaling == nil || gc.Extension.Scaling.ScaleToSize == nil {
+		return 0, false
+	}
+	for _, s := range gc.Extension.Scaling.ScaleToSize.TargetAxisSize {
+		if s.Axis == axis {
+			return s.TargetSize, true
+		}
+	}
+	return 0, false
+}
+
+// wmsDimensions returns the subsets of the other axes than the horizontal axes with the WMS keys
+// TIME and ELEVATION keep their name, the other axes become sample dimensions with the DIM_ prefix
+func (gc *GetCoverage) wmsDimensions(envelope wcs201.Envelope) (map[string]string, ows.Exceptions) {
+	dimensions := make(map[string]string)
+	var exceptions ows.Exceptions
+	for _, t := range gc.DimensionTrim {
+		if isHorizontal(envelope, t.Dimension.Axis) {
+			continue
+		}
+		i, ok := envelope.AxisIndex(t.Dimension.Axis)
+		if !ok || i >= len(envelope.LowerCorner) || i >= len(envelope.UpperCorner) {
+			exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("The Envelope has no extent for the axis %s", t.Dimension.Axis)))
+			continue
+		}
+		low, high := envelope.LowerCorner[i], envelope.UpperCorner[i]
+		if t.TrimLow != nil {
+			low = *t.TrimLow
+		}
+		if t.TrimHigh != nil {
+			high = *t.TrimHigh
+		}
+		dimensions[wmsDimension(t.Dimension.Axis)] = strings.Trim(low, `"`) + `/` + strings.Trim(high, `"`)
+	}
+	for _, s := range gc.DimensionSlice {
+		dimensions[wmsDimension(s.Dimension.Axis)] = strings.Trim(s.SlicePoint, `"`)
+	}
+
+	if len(exceptions) > 0 {
+		return nil, exceptions
+	}
+	if len(dimensions) == 0 {
+		return nil, nil
+	}
+	return dimensions, nil
+}
+
+// wmsDimension returns the WMS key of the axis
+func wmsDimension(axis string) string {
+	switch name := strings.ToUpper(axis); name {
+	case wms130.TIME, wms130.ELEVATION:
+		return name
+	default:
+		return wms130.DIMPREFIX + name
+	}
+}
+
+// nativeSubsettingCRS returns the CRS of the subset, an empty subsetting CRS is the native CRS of the Envelope
+func (gc *GetCoverage) nativeSubsettingCRS(dimension Dimension, envelope wcs201.Envelope) string {
+	if crs := gc.subsettingCRS(dimension); crs != `` {
+		return crs
+	}
+	return envelope.SrsName
+}
+
+// isHorizontal checks if the axis is one of the first two axes of the Envelope
+func isHorizontal(envelope wcs201.Envelope, axis string) bool {
+	i, ok := envelope.AxisIndex(axis)
+	return ok && i < 2
+}
+
+// gridResolution returns the size of a grid cell along the axis, derived from the Envelope and the limits of the RectifiedGrid
+func gridResolution(d wcs201.CoverageDescription, i int) (float64, bool) {
+	grid := d.DomainSet.RectifiedGrid
+	envelope := d.BoundedBy.Envelope
+	if grid == nil || len(grid.Limits.GridEnvelope.Low) <= i || len(grid.Limits.GridEnvelope.High) <= i ||
+		len(envelope.LowerCorner) <= i || len(envelope.UpperCorner) <= i {
+		return 0, false
+	}
+
+	var values [4]float64
+	for k, v := range []string{envelope.LowerCorner[i], envelope.UpperCorner[i], grid.Limits.GridEnvelope.Low[i], grid.Limits.GridEnvelope.High[i]} {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		values[k] = f
+	}
+	cells := values[3] - values[2] + 1
+	if cells <= 0 {
+		return 0, false
+	}
+	return (values[1] - values[0]) / cells, true
+}
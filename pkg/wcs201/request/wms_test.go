@@ -0,0 +1,157 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wcs201/exception"
+	wcs201 "github.com/pdok/ogc-specifications/pkg/wcs201/response"
+	wms130 "github.com/pdok/ogc-specifications/pkg/wms130/request"
+)
+
+// wmsDescription returns the description of a coverage with 0.5m grid cells and optional extra axes
+func wmsDescription(grid bool, axes ...string) wcs201.CoverageDescription {
+	d := wcs201.CoverageDescription{CoverageID: `dtm_05m`,
+		BoundedBy: wcs201.BoundedBy{Envelope: wcs201.Envelope{SrsName: `http://www.opengis.net/def/crs/EPSG/0/28992`, AxisLabels: wcs201.Labels{`x`, `y`}, SrsDimension: 2,
+			LowerCorner: wcs201.Coordinates{`10000`, `300000`}, UpperCorner: wcs201.Coordinates{`280000`, `625000`}}},
+		RangeType:         wcs201.RangeType{DataRecord: wcs201.DataRecord{Field: []wcs201.Field{{Name: `height`}}}},
+		ServiceParameters: wcs201.ServiceParameters{CoverageSubtype: `RectifiedGridCoverage`, NativeFormat: `image/tiff`}}
+	for _, axis := range axes {
+		d.BoundedBy.Envelope.AxisLabels = append(d.BoundedBy.Envelope.AxisLabels, axis)
+		d.BoundedBy.Envelope.LowerCorner = append(d.BoundedBy.Envelope.LowerCorner, `"2019-01-01"`)
+		d.BoundedBy.Envelope.UpperCorner = append(d.BoundedBy.Envelope.UpperCorner, `"2021-01-01"`)
+		d.BoundedBy.Envelope.SrsDimension++
+	}
+	if grid {
+		d.DomainSet.RectifiedGrid = &wcs201.RectifiedGrid{Dimension: 2, AxisLabels: wcs201.Labels{`x`, `y`}}
+		d.DomainSet.RectifiedGrid.Limits.GridEnvelope = wcs201.GridEnvelope{Low: wcs201.Coordinates{`0`, `0`}, High: wcs201.Coordinates{`539999`, `649999`}}
+	}
+	return d
+}
+
+func TestGetCoverageBuildGetMap(t *testing.T) {
+	rd := `http://www.opengis.net/def/crs/EPSG/0/28992`
+	etrs := `http://www.opengis.net/def/crs/EPSG/0/3035`
+	wgs84 := `http://www.opengis.net/def/crs/EPSG/0/4326`
+
+	var tests = []struct {
+		getcoverage GetCoverage
+		description wcs201.CoverageDescription
+		query       url.Values
+		exceptions  ows.Exceptions
+	}{
+		// trims and scale size
+		0: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, Format: `image/png`,
+			DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`120000`), TrimHigh: sp(`121000`)}, {Dimension: Dimension{Axis: `y`}, TrimLow: sp(`480000`), TrimHigh: sp(`480500`)}},
+			Extension:     &Extension{Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 1000}, {Axis: `y`, TargetSize: 500}}}}}},
+			description: wmsDescription(false),
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`dtm_05m`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`120000.000000,480000.000000,121000.000000,480500.000000`}, wms130.WIDTH: {`1000`}, wms130.HEIGHT: {`500`},
+				wms130.FORMAT: {`image/png`}}},
+		// the size of the grid within the trims, an unbounded trim takes the extent of the coverage and the native format is the default
+		1: {getcoverage: GetCoverage{CoverageID: `dtm_05m`,
+			DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`279000`)}, {Dimension: Dimension{Axis: `y`}, TrimLow: sp(`480000`), TrimHigh: sp(`480500`)}}},
+			description: wmsDescription(true),
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`dtm_05m`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`279000.000000,480000.000000,280000.000000,480500.000000`}, wms130.WIDTH: {`2000`}, wms130.HEIGHT: {`1000`},
+				wms130.FORMAT: {`image/tiff`}}},
+		// the OUTPUTCRS with trims in that CRS, which has a northing easting axis order, the other axes become dimensions
+		// the trims of x and y are the easting and northing in the OUTPUTCRS, so x stays the WIDTH
+		2: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, Format: `image/png`,
+			DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`3900000`), TrimHigh: sp(`4000000`)}, {Dimension: Dimension{Axis: `y`}, TrimLow: sp(`3100000`), TrimHigh: sp(`3200000`)},
+				{Dimension: Dimension{Axis: `band`}, TrimLow: sp(`"2020-01-01"`)}},
+			DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `time`}, SlicePoint: `"2020-06-01"`}},
+			Extension: &Extension{SubsettingCRS: etrs, OutputCRS: etrs,
+				Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 256}, {Axis: `y`, TargetSize: 512}}}}}},
+			description: wmsDescription(false, `time`, `band`),
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`dtm_05m`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:3035`}, wms130.BBOX: {`3100000.000000,3900000.000000,3200000.000000,4000000.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`512`},
+				wms130.FORMAT: {`image/png`}, wms130.TIME: {`2020-06-01`}, `DIM_BAND`: {`2020-01-01/2021-01-01`}}},
+		// no grid and no SCALESIZE
+		3: {getcoverage: GetCoverage{CoverageID: `dtm_05m`}, description: wmsDescription(false),
+			exceptions: ows.Exceptions{ows.MissingParameterValue(SCALESIZE)}},
+		// the subsets are validated against the domain first
+		4: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `Lat`}, TrimLow: sp(`52`)}}},
+			description: wmsDescription(true),
+			exceptions:  ows.Exceptions{exception.InvalidAxisLabel(`Lat`)}},
+		// the GetCoverage features that can't be mapped
+		5: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, MediaType: Multipart,
+			DimensionTrim:  []DimensionTrim{{Dimension: Dimension{Axis: `y`, CRS: etrs}, TrimLow: sp(`3100000`), TrimHigh: sp(`3200000`)}},
+			DimensionSlice: []DimensionSlice{{Dimension: Dimension{Axis: `x`}, SlicePoint: `120000`}},
+			Extension: &Extension{Scaling: &Scaling{ScaleByFactor: &ScaleByFactor{ScaleFactor: 2}},
+				Interpolation: &Interpolation{GlobalInterpolation: `http://www.opengis.net/def/interpolation/OGC/1/linear`},
+				RangeSubset:   &RangeSubset{RangeItem: []RangeItem{{RangeComponent: `height`}}}}},
+			description: wmsDescription(true),
+			exceptions: ows.Exceptions{
+				ows.OptionNotSupported(`The slice of the horizontal axis x can't be mapped on a WMS GetMap`),
+				ows.OptionNotSupported(`The trim of the horizontal axis y in ` + etrs + ` can't be mapped on a WMS GetMap in ` + rd),
+				ows.OptionNotSupported(`The MEDIATYPE multipart/related can't be mapped on a WMS GetMap`),
+				ows.OptionNotSupported(`SCALEFACTOR can't be mapped on a WMS GetMap, only SCALESIZE is supported`),
+				ows.OptionNotSupported(`INTERPOLATION can't be mapped on a WMS GetMap`),
+				ows.OptionNotSupported(`RANGESUBSET can't be mapped on a WMS GetMap`)}},
+		// the extent of the coverage in the OUTPUTCRS is unknown
+		6: {getcoverage: GetCoverage{CoverageID: `dtm_05m`,
+			DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`3900000`), TrimHigh: sp(`4000000`)}},
+			Extension:     &Extension{SubsettingCRS: etrs, OutputCRS: etrs}},
+			description: wmsDescription(true),
+			exceptions:  ows.Exceptions{ows.OptionNotSupported(`The extent of the axis y in ` + etrs + ` is unknown, a trim of both bounds is needed to map it on a WMS GetMap`)}},
+		// the OUTPUTCRS EPSG:4326 has a lat lon axis order, the native EPSG:28992 an x y axis order
+		7: {getcoverage: GetCoverage{CoverageID: `dtm_05m`, Format: `image/png`,
+			DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `x`}, TrimLow: sp(`5`), TrimHigh: sp(`6`)}, {Dimension: Dimension{Axis: `y`}, TrimLow: sp(`52`), TrimHigh: sp(`52.5`)}},
+			Extension: &Extension{SubsettingCRS: wgs84, OutputCRS: wgs84,
+				Scaling: &Scaling{ScaleToSize: &ScaleToSize{TargetAxisSize: []TargetAxisSize{{Axis: `x`, TargetSize: 1000}, {Axis: `y`, TargetSize: 500}}}}}},
+			description: wmsDescription(true),
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`dtm_05m`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:4326`}, wms130.BBOX: {`52.000000,5.000000,52.500000,6.000000`}, wms130.WIDTH: {`1000`}, wms130.HEIGHT: {`500`},
+				wms130.FORMAT: {`image/png`}}},
+	}
+
+	for k, test := range tests {
+		gm, exceptions := test.getcoverage.BuildGetMap(test.description)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if query := gm.BuildKVP(); !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
+
+func TestGetCoverageBuildGetMapMalformedEnvelope(t *testing.T) {
+	gc := GetCoverage{CoverageID: `dtm_05m`, DimensionTrim: []DimensionTrim{{Dimension: Dimension{Axis: `time`}, TrimLow: sp(`2020-01-01`)}}}
+
+	// the corners are missing
+	d := wmsDescription(true, `time`)
+	d.BoundedBy.Envelope.LowerCorner = nil
+	d.BoundedBy.Envelope.UpperCorner = wcs201.Coordinates{`280000`}
+	expected := ows.Exceptions{ows.NoApplicableCode(`The Envelope of the coverage dtm_05m has 3 axis labels, but its corners have 0 and 1 coordinates`)}
+	if _, exceptions := gc.BuildGetMap(d); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, exceptions)
+	}
+
+	envelope := d.BoundedBy.Envelope
+	expected = ows.Exceptions{ows.NoApplicableCode(`The Envelope has no extent for the horizontal axes`)}
+	if _, exceptions := gc.bbox(envelope, envelope.SrsName); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 1, expected, exceptions)
+	}
+	expected = ows.Exceptions{ows.NoApplicableCode(`The Envelope has no extent for the axis time`)}
+	if _, exceptions := gc.wmsDimensions(envelope); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 2, expected, exceptions)
+	}
+	if _, ok := gridResolution(d, 1); ok {
+		t.Errorf("test: %d, expected no resolution", 3)
+	}
+
+	// the limits of the grid are missing
+	d = wmsDescription(true)
+	d.DomainSet.RectifiedGrid.Limits.GridEnvelope = wcs201.GridEnvelope{Low: wcs201.Coordinates{`0`}}
+	if _, ok := gridResolution(d, 1); ok {
+		t.Errorf("test: %d, expected no resolution", 4)
+	}
+}
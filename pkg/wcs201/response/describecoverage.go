@@ -0,0 +1,264 @@
+package wcs201
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+//
+const (
+	describecoverage = `DescribeCoverage`
+)
+
+// Type function needed for the interface
+func (cd *CoverageDescriptions) Type() string {
+	return describecoverage
+}
+
+// Service function needed for the interface
+func (cd *CoverageDescriptions) Service() string {
+	return Service
+}
+
+// Version function needed for the interface
+func (cd *CoverageDescriptions) Version() string {
+	return Version
+}
+
+// Validate function of the wcs201 spec
+// Checks if the Envelope and the RectifiedGrid of the CoverageDescriptions have the declared number of dimensions
+func (cd *CoverageDescriptions) Validate() ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, d := range cd.CoverageDescription {
+		exceptions = append(exceptions, d.validate()...)
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+func (d *CoverageDescription) validate() ows.Exceptions {
+	var exceptions ows.Exceptions
+	envelope := d.BoundedBy.Envelope
+	for _, c := range []struct {
+		name   string
+		length int
+	}{
+		{`axisLabels`, len(envelope.AxisLabels)},
+		{`uomLabels`, len(envelope.UomLabels)},
+		{`lowerCorner`, len(envelope.LowerCorner)},
+		{`upperCorner`, len(envelope.UpperCorner)},
+	} {
+		if c.length != envelope.SrsDimension {
+			exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("Coverage %s has %d %s, expected %d", d.CoverageID, c.length, c.name, envelope.SrsDimension)))
+		}
+	}
+
+	grid := d.DomainSet.RectifiedGrid
+	if grid == nil {
+		return exceptions
+	}
+	for _, c := range []struct {
+		name   string
+		length int
+	}{
+		{`grid axisLabels`, len(grid.AxisLabels)},
+		{`grid low`, len(grid.Limits.GridEnvelope.Low)},
+		{`grid high`, len(grid.Limits.GridEnvelope.High)},
+		{`origin coordinates`, len(grid.Origin.Point.Pos)},
+		{`offsetVectors`, len(grid.OffsetVector)},
+	} {
+		if c.length != grid.Dimension {
+			exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("Coverage %s has %d %s, expected %d", d.CoverageID, c.length, c.name, grid.Dimension)))
+		}
+	}
+	return exceptions
+}
+
+// BuildXML builds a CoverageDescriptions response object
+func (cd *CoverageDescriptions) BuildXML() []byte {
+	si, _ := ows.CanonicalNamespaces.Marshal(cd, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// ParseXML reads a WCS CoverageDescriptions document
+func (cd *CoverageDescriptions) ParseXML(doc []byte) error {
+	var descriptions CoverageDescriptions
+	if err := xml.Unmarshal(doc, &descriptions); err != nil {
+		return err
+	}
+	*cd = descriptions
+	return nil
+}
+
+// GetCoverageDescription returns the CoverageDescription with the given CoverageID
+func (cd *CoverageDescriptions) GetCoverageDescription(coverageid string) (CoverageDescription, bool) {
+	for _, d := range cd.CoverageDescription {
+		if d.CoverageID == coverageid {
+			return d, true
+		}
+	}
+	return CoverageDescription{}, false
+}
+
+// AxisIndex returns the index of the axis with the given label, the labels are case sensitive
+func (e *Envelope) AxisIndex(label string) (int, bool) {
+	for i, l := range e.AxisLabels {
+		if l == label {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Labels is a space separated list of labels, like the axisLabels and uomLabels
+type Labels []string
+
+// MarshalText joins the labels with a space
+func (l Labels) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(l, ` `)), nil
+}
+
+// UnmarshalText splits the text on the white space
+func (l *Labels) UnmarshalText(text []byte) error {
+	*l = strings.Fields(string(text))
+	return nil
+}
+
+// Coordinates is a space separated list of coordinate values, one for every axis
+// The values are kept as text, because a value can be a number or a (quoted) ISO 8601 time position
+type Coordinates []string
+
+// MarshalText joins the coordinates with a space
+func (c Coordinates) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(c, ` `)), nil
+}
+
+// UnmarshalText splits the text on the white space
+func (c *Coordinates) UnmarshalText(text []byte) error {
+	*c = strings.Fields(string(text))
+	return nil
+}
+
+// CoverageDescriptions base struct
+// Struct based on http://schemas.opengis.net/wcs/2.0/wcsDescribeCoverage.xsd
+type CoverageDescriptions struct {
+	XMLName             xml.Name              `xml:"http://www.opengis.net/wcs/2.0 CoverageDescriptions" yaml:"coveragedescriptions"`
+	SchemaLocation      string                `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr,omitempty" yaml:"schemalocation,omitempty"`
+	CoverageDescription []CoverageDescription `xml:"http://www.opengis.net/wcs/2.0 CoverageDescription" yaml:"coveragedescription"`
+}
+
+// CoverageDescription describes the domain, range and service parameters of a single coverage
+type CoverageDescription struct {
+	ID                string            `xml:"http://www.opengis.net/gml/3.2 id,attr" yaml:"id"`
+	BoundedBy         BoundedBy         `xml:"http://www.opengis.net/gml/3.2 boundedBy" yaml:"boundedby"`
+	CoverageID        string            `xml:"http://www.opengis.net/wcs/2.0 CoverageId" yaml:"coverageid"`
+	DomainSet         DomainSet         `xml:"http://www.opengis.net/gml/3.2 domainSet" yaml:"domainset"`
+	RangeType         RangeType         `xml:"http://www.opengis.net/gmlcov/1.0 rangeType" yaml:"rangetype"`
+	ServiceParameters ServiceParameters `xml:"http://www.opengis.net/wcs/2.0 ServiceParameters" yaml:"serviceparameters"`
+}
+
+// BoundedBy struct for the gml:boundedBy
+type BoundedBy struct {
+	Envelope Envelope `xml:"http://www.opengis.net/gml/3.2 Envelope" yaml:"envelope"`
+}
+
+// Envelope struct for the gml:Envelope with the axis and uom labels
+type Envelope struct {
+	SrsName      string      `xml:"srsName,attr" yaml:"srsname"`
+	AxisLabels   Labels      `xml:"axisLabels,attr" yaml:"axislabels"`
+	UomLabels    Labels      `xml:"uomLabels,attr" yaml:"uomlabels"`
+	SrsDimension int         `xml:"srsDimension,attr" yaml:"srsdimension"`
+	LowerCorner  Coordinates `xml:"http://www.opengis.net/gml/3.2 lowerCorner" yaml:"lowercorner"`
+	UpperCorner  Coordinates `xml:"http://www.opengis.net/gml/3.2 upperCorner" yaml:"uppercorner"`
+}
+
+// DomainSet struct for the gml:domainSet
+type DomainSet struct {
+	RectifiedGrid *RectifiedGrid `xml:"http://www.opengis.net/gml/3.2 RectifiedGrid" yaml:"rectifiedgrid,omitempty"`
+}
+
+// RectifiedGrid struct for the gml:RectifiedGrid
+type RectifiedGrid struct {
+	ID        string `xml:"http://www.opengis.net/gml/3.2 id,attr" yaml:"id"`
+	Dimension int    `xml:"dimension,attr" yaml:"dimension"`
+	Limits    struct {
+		GridEnvelope GridEnvelope `xml:"http://www.opengis.net/gml/3.2 GridEnvelope" yaml:"gridenvelope"`
+	} `xml:"http://www.opengis.net/gml/3.2 limits" yaml:"limits"`
+	AxisLabels Labels `xml:"http://www.opengis.net/gml/3.2 axisLabels" yaml:"axislabels"`
+	Origin     struct {
+		Point Point `xml:"http://www.opengis.net/gml/3.2 Point" yaml:"point"`
+	} `xml:"http://www.opengis.net/gml/3.2 origin" yaml:"origin"`
+	OffsetVector []OffsetVector `xml:"http://www.opengis.net/gml/3.2 offsetVector" yaml:"offsetvector"`
+}
+
+// GridEnvelope struct with the low and high grid coordinates
+type GridEnvelope struct {
+	Low  Coordinates `xml:"http://www.opengis.net/gml/3.2 low" yaml:"low"`
+	High Coordinates `xml:"http://www.opengis.net/gml/3.2 high" yaml:"high"`
+}
+
+// Point struct for the gml:Point
+type Point struct {
+	ID      string      `xml:"http://www.opengis.net/gml/3.2 id,attr" yaml:"id"`
+	SrsName string      `xml:"srsName,attr" yaml:"srsname"`
+	Pos     Coordinates `xml:"http://www.opengis.net/gml/3.2 pos" yaml:"pos"`
+}
+
+// OffsetVector struct for the gml:offsetVector
+type OffsetVector struct {
+	SrsName string      `xml:"srsName,attr,omitempty" yaml:"srsname,omitempty"`
+	Vector  Coordinates `xml:",chardata" yaml:"vector"`
+}
+
+// RangeType struct for the gmlcov:rangeType
+type RangeType struct {
+	DataRecord DataRecord `xml:"http://www.opengis.net/swe/2.0 DataRecord" yaml:"datarecord"`
+}
+
+// DataRecord struct for the swe:DataRecord with a field for every band of the coverage
+type DataRecord struct {
+	Field []Field `xml:"http://www.opengis.net/swe/2.0 field" yaml:"field"`
+}
+
+// Field struct for the swe:field
+type Field struct {
+	Name     string   `xml:"name,attr" yaml:"name"`
+	Quantity Quantity `xml:"http://www.opengis.net/swe/2.0 Quantity" yaml:"quantity"`
+}
+
+// Quantity struct for the swe:Quantity
+type Quantity struct {
+	Definition  string `xml:"definition,attr,omitempty" yaml:"definition,omitempty"`
+	Description string `xml:"http://www.opengis.net/swe/2.0 description,omitempty" yaml:"description,omitempty"`
+	NilValues   *struct {
+		NilValues struct {
+			NilValue []NilValue `xml:"http://www.opengis.net/swe/2.0 nilValue" yaml:"nilvalue"`
+		} `xml:"http://www.opengis.net/swe/2.0 NilValues" yaml:"nilvalues"`
+	} `xml:"http://www.opengis.net/swe/2.0 nilValues" yaml:"nilvalues,omitempty"`
+	Uom struct {
+		Code string `xml:"code,attr" yaml:"code"`
+	} `xml:"http://www.opengis.net/swe/2.0 uom" yaml:"uom"`
+	Constraint *struct {
+		AllowedValues struct {
+			Interval []Coordinates `xml:"http://www.opengis.net/swe/2.0 interval" yaml:"interval"`
+		} `xml:"http://www.opengis.net/swe/2.0 AllowedValues" yaml:"allowedvalues"`
+	} `xml:"http://www.opengis.net/swe/2.0 constraint" yaml:"constraint,omitempty"`
+}
+
+// NilValue struct for the swe:nilValue
+type NilValue struct {
+	Reason string `xml:"reason,attr" yaml:"reason"`
+	Value  string `xml:",chardata" yaml:"value"`
+}
+
+// ServiceParameters struct for the wcs:ServiceParameters
+type ServiceParameters struct {
+	CoverageSubtype string `xml:"http://www.opengis.net/wcs/2.0 CoverageSubtype" yaml:"coveragesubtype"`
+	NativeFormat    string `xml:"http://www.opengis.net/wcs/2.0 nativeFormat" yaml:"nativeformat"`
+}
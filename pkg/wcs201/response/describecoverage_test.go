@@ -0,0 +1,171 @@
+package wcs201
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+var ahnDescription = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<wcs:CoverageDescriptions xmlns:wcs="http://www.opengis.net/wcs/2.0" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:gmlcov="http://www.opengis.net/gmlcov/1.0" xmlns:swe="http://www.opengis.net/swe/2.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/wcs/2.0 http://schemas.opengis.net/wcs/2.0/wcsDescribeCoverage.xsd">
+  <wcs:CoverageDescription gml:id="dtm_05m">
+    <gml:boundedBy>
+      <gml:Envelope srsName="http://www.opengis.net/def/crs/EPSG/0/28992" axisLabels="x y" uomLabels="m m" srsDimension="2">
+        <gml:lowerCorner>10000 300000</gml:lowerCorner>
+        <gml:upperCorner>280000 625000</gml:upperCorner>
+      </gml:Envelope>
+    </gml:boundedBy>
+    <wcs:CoverageId>dtm_05m</wcs:CoverageId>
+    <gml:domainSet>
+      <gml:RectifiedGrid gml:id="grid_dtm_05m" dimension="2">
+        <gml:limits>
+          <gml:GridEnvelope>
+            <gml:low>0 0</gml:low>
+            <gml:high>539999 649999</gml:high>
+          </gml:GridEnvelope>
+        </gml:limits>
+        <gml:axisLabels>x y</gml:axisLabels>
+        <gml:origin>
+          <gml:Point gml:id="grid_origin_dtm_05m" srsName="http://www.opengis.net/def/crs/EPSG/0/28992">
+            <gml:pos>10000.25 624999.75</gml:pos>
+          </gml:Point>
+        </gml:origin>
+        <gml:offsetVector srsName="http://www.opengis.net/def/crs/EPSG/0/28992">0.5 0</gml:offsetVector>
+        <gml:offsetVector srsName="http://www.opengis.net/def/crs/EPSG/0/28992">0 -0.5</gml:offsetVector>
+      </gml:RectifiedGrid>
+    </gml:domainSet>
+    <gmlcov:rangeType>
+      <swe:DataRecord>
+        <swe:field name="height">
+          <swe:Quantity definition="http://www.opengis.net/def/dataType/OGC/0/float32">
+            <swe:description>Height above NAP</swe:description>
+            <swe:nilValues>
+              <swe:NilValues>
+                <swe:nilValue reason="http://www.opengis.net/def/nil/OGC/0/unknown">3.4028234663852886e+38</swe:nilValue>
+              </swe:NilValues>
+            </swe:nilValues>
+            <swe:uom code="m"/>
+            <swe:constraint>
+              <swe:AllowedValues>
+                <swe:interval>-3.4028235e+38 3.4028235e+38</swe:interval>
+              </swe:AllowedValues>
+            </swe:constraint>
+          </swe:Quantity>
+        </swe:field>
+      </swe:DataRecord>
+    </gmlcov:rangeType>
+    <wcs:ServiceParameters>
+      <wcs:CoverageSubtype>RectifiedGridCoverage</wcs:CoverageSubtype>
+      <wcs:nativeFormat>image/tiff</wcs:nativeFormat>
+    </wcs:ServiceParameters>
+  </wcs:CoverageDescription>
+</wcs:CoverageDescriptions>`)
+
+func TestCoverageDescriptionsParseXML(t *testing.T) {
+	var cd CoverageDescriptions
+	if err := cd.ParseXML(ahnDescription); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+
+	d, ok := cd.GetCoverageDescription(`dtm_05m`)
+	if !ok {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 1, `dtm_05m`, cd)
+		return
+	}
+	expected := Envelope{SrsName: `http://www.opengis.net/def/crs/EPSG/0/28992`, AxisLabels: Labels{`x`, `y`}, UomLabels: Labels{`m`, `m`}, SrsDimension: 2,
+		LowerCorner: Coordinates{`10000`, `300000`}, UpperCorner: Coordinates{`280000`, `625000`}}
+	if !reflect.DeepEqual(d.BoundedBy.Envelope, expected) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 2, expected, d.BoundedBy.Envelope)
+	}
+	if grid := d.DomainSet.RectifiedGrid; grid == nil || grid.Dimension != 2 || !reflect.DeepEqual(grid.Limits.GridEnvelope.High, Coordinates{`539999`, `649999`}) ||
+		!reflect.DeepEqual(grid.OffsetVector[1].Vector, Coordinates{`0`, `-0.5`}) || !reflect.DeepEqual(grid.Origin.Point.Pos, Coordinates{`10000.25`, `624999.75`}) {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 3, `RectifiedGrid`, grid)
+	}
+	if field := d.RangeType.DataRecord.Field; len(field) != 1 || field[0].Name != `height` || field[0].Quantity.Uom.Code != `m` || field[0].Quantity.NilValues == nil {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 4, `height`, field)
+	}
+	if d.ServiceParameters.NativeFormat != `image/tiff` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 5, `image/tiff`, d.ServiceParameters.NativeFormat)
+	}
+	if _, ok := cd.GetCoverageDescription(`unknown`); ok {
+		t.Errorf("test: %d, expected: %t,\n got: %t", 6, false, ok)
+	}
+
+	if err := cd.ParseXML([]byte(`no XML document, just a string`)); err == nil {
+		t.Errorf("test: %d, expected an error,\n got: nil", 7)
+	}
+}
+
+func TestCoverageDescriptionsRoundTrip(t *testing.T) {
+	var original CoverageDescriptions
+	if err := original.ParseXML(ahnDescription); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+
+	doc := original.BuildXML()
+	for _, element := range []string{`<wcs:CoverageDescriptions `, `<gml:Envelope srsName="http://www.opengis.net/def/crs/EPSG/0/28992" axisLabels="x y" uomLabels="m m" srsDimension="2">`,
+		`<gml:lowerCorner>10000 300000</gml:lowerCorner>`, `<gml:RectifiedGrid gml:id="grid_dtm_05m" dimension="2">`, `<swe:field name="height">`, `<wcs:nativeFormat>image/tiff</wcs:nativeFormat>`} {
+		if !strings.Contains(string(doc), element) {
+			t.Errorf("test: %d, expected: %s,\n got: %s", 1, element, doc)
+		}
+	}
+
+	var result CoverageDescriptions
+	if err := result.ParseXML(doc); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 2, err.Error())
+		return
+	}
+	if !reflect.DeepEqual(result, original) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 3, original, result)
+	}
+}
+
+func TestCoverageDescriptionsValidate(t *testing.T) {
+	var tests = []struct {
+		descriptions CoverageDescriptions
+		exceptions   ows.Exceptions
+	}{
+		0: {descriptions: CoverageDescriptions{CoverageDescription: []CoverageDescription{{CoverageID: `dtm`,
+			BoundedBy: BoundedBy{Envelope: Envelope{AxisLabels: Labels{`x`, `y`}, UomLabels: Labels{`m`, `m`}, SrsDimension: 2, LowerCorner: Coordinates{`0`, `0`}, UpperCorner: Coordinates{`1`, `1`}}}}}}},
+		1: {descriptions: CoverageDescriptions{CoverageDescription: []CoverageDescription{{CoverageID: `dtm`,
+			BoundedBy: BoundedBy{Envelope: Envelope{AxisLabels: Labels{`x`, `y`, `time`}, UomLabels: Labels{`m`, `m`}, SrsDimension: 2, LowerCorner: Coordinates{`0`, `0`}, UpperCorner: Coordinates{`1`, `1`}}},
+			DomainSet: DomainSet{RectifiedGrid: &RectifiedGrid{Dimension: 2, AxisLabels: Labels{`x`, `y`}, OffsetVector: []OffsetVector{{Vector: Coordinates{`1`, `0`}}}}}}}},
+			exceptions: ows.Exceptions{
+				ows.NoApplicableCode(`Coverage dtm has 3 axisLabels, expected 2`),
+				ows.NoApplicableCode(`Coverage dtm has 0 grid low, expected 2`),
+				ows.NoApplicableCode(`Coverage dtm has 0 grid high, expected 2`),
+				ows.NoApplicableCode(`Coverage dtm has 0 origin coordinates, expected 2`),
+				ows.NoApplicableCode(`Coverage dtm has 1 offsetVectors, expected 2`),
+			}},
+	}
+
+	for k, test := range tests {
+		if exceptions := test.descriptions.Validate(); !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
+
+func TestEnvelopeAxisIndex(t *testing.T) {
+	envelope := Envelope{AxisLabels: Labels{`Lat`, `Long`, `ansi`}}
+
+	var tests = []struct {
+		label string
+		index int
+		ok    bool
+	}{
+		0: {label: `Long`, index: 1, ok: true},
+		1: {label: `ansi`, index: 2, ok: true},
+		2: {label: `long`, index: -1},
+	}
+
+	for k, test := range tests {
+		if index, ok := envelope.AxisIndex(test.label); index != test.index || ok != test.ok {
+			t.Errorf("test: %d, expected: %d %t,\n got: %d %t", k, test.index, test.ok, index, ok)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package wcs201
 
 import (
 	"encoding/xml"
-	"regexp"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
@@ -15,8 +14,8 @@ const (
 
 //
 const (
-	Service = `WFS`
-	Version = `2.0.0`
+	Service = `WCS`
+	Version = `2.0.1`
 )
 
 // Contains the WFS200 struct
@@ -43,24 +42,23 @@ func (gc *GetCapabilities) Validate() ows.Exceptions {
 
 // BuildXML builds a GetCapabilities response object
 func (gc *GetCapabilities) BuildXML() []byte {
-	si, _ := xml.MarshalIndent(gc, "", "")
-	re := regexp.MustCompile(`><.*>`)
-	return []byte(xml.Header + re.ReplaceAllString(string(si), "/>"))
+	si, _ := ows.CanonicalNamespaces.Marshal(gc, "", " ")
+	return append([]byte(xml.Header), si...)
 }
 
 // GetCapabilities base struct
 type GetCapabilities struct {
-	XMLName               xml.Name `xml:"wcs:Capabilities"`
+	XMLName               xml.Name `xml:"http://www.opengis.net/wcs/2.0 Capabilities"`
 	Namespaces            `yaml:"namespaces"`
-	ServiceIdentification ServiceIdentification `xml:"ows:ServiceIdentification" yaml:"serviceidentification"`
-	ServiceProvider       ServiceProvider       `xml:"ows:ServiceProvider" yaml:"serviceprovider"`
+	ServiceIdentification ServiceIdentification `xml:"http://www.opengis.net/ows/2.0 ServiceIdentification" yaml:"serviceidentification"`
+	ServiceProvider       ServiceProvider       `xml:"http://www.opengis.net/ows/2.0 ServiceProvider" yaml:"serviceprovider"`
 	capabilities.Capabilities
 }
 
 // Namespaces struct containing the namespaces needed for the XML document
 type Namespaces struct {
 	XmlnsWCS           string `xml:"xmlns:wcs,attr" yaml:"wcs"`                                //http://www.opengis.net/wcs/2.0
-	XmlnsOWS           string `xml:"xmlns:ows,attr" yaml:"ows"`                                //http://www.opengis.net/ows/1.1
+	XmlnsOWS           string `xml:"xmlns:ows,attr" yaml:"ows"`                                //http://www.opengis.net/ows/2.0
 	XmlnsOGC           string `xml:"xmlns:ogc,attr" yaml:"ogc"`                                //http://www.opengis.net/ogc
 	XmlnsXSI           string `xml:"xmlns:xsi,attr" yaml:"xsi"`                                //http://www.w3.org/2001/XMLSchema-instance
 	XmlnsXlink         string `xml:"xmlns:xlink,attr" yaml:"xlink"`                            //http://www.w3.org/1999/xlink
@@ -72,54 +70,54 @@ type Namespaces struct {
 	XmlnsCrs           string `xml:"xmlns:crs,attr" yaml:"crs"`                                //http://www.opengis.net/wcs/crs/1.0
 	XmlnsInt           string `xml:"xmlns:int,attr" yaml:"int"`                                //http://www.opengis.net/wcs/interpolation/1.0
 	Version            string `xml:"version,attr" yaml:"version"`
-	SchemaLocation     string `xml:"xsi:schemaLocation,attr" yaml:"schemalocation"`
+	SchemaLocation     string `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr" yaml:"schemalocation"`
 }
 
 // ServiceIdentification struct should only be fill by the "template" configuration wcs201.yaml
 type ServiceIdentification struct {
-	Title       string        `xml:"ows:Title" yaml:"title"`
-	Abstract    string        `xml:"ows:Abstract" yaml:"abstract"`
-	Keywords    *ows.Keywords `xml:"ows:Keywords" yaml:"keywords"`
+	Title       string        `xml:"http://www.opengis.net/ows/2.0 Title" yaml:"title"`
+	Abstract    string        `xml:"http://www.opengis.net/ows/2.0 Abstract" yaml:"abstract"`
+	Keywords    *ows.Keywords `xml:"http://www.opengis.net/ows/2.0 Keywords" yaml:"keywords"`
 	ServiceType struct {
 		Text      string `xml:",chardata" yaml:"text"`
 		CodeSpace string `xml:"codeSpace,attr" yaml:"codespace"`
-	} `xml:"ows:ServiceType" yaml:"servicetype"`
-	ServiceTypeVersion []string `xml:"ows:ServiceTypeVersion" yaml:"servicetypeversion"`
-	Profile            []string `xml:"ows:Profile" yaml:"profile"`
-	Fees               string   `xml:"ows:Fees" yaml:"fees"`
-	AccessConstraints  string   `xml:"ows:AccessConstraints" yaml:"accessconstraints"`
+	} `xml:"http://www.opengis.net/ows/2.0 ServiceType" yaml:"servicetype"`
+	ServiceTypeVersion []string `xml:"http://www.opengis.net/ows/2.0 ServiceTypeVersion" yaml:"servicetypeversion"`
+	Profile            []string `xml:"http://www.opengis.net/ows/2.0 Profile" yaml:"profile"`
+	Fees               string   `xml:"http://www.opengis.net/ows/2.0 Fees" yaml:"fees"`
+	AccessConstraints  string   `xml:"http://www.opengis.net/ows/2.0 AccessConstraints" yaml:"accessconstraints"`
 }
 
 // ServiceProvider struct containing the provider/organization information should only be fill by the "template" configuration wcs201.yaml
 type ServiceProvider struct {
-	ProviderName string `xml:"ows:ProviderName" yaml:"providername"`
+	ProviderName string `xml:"http://www.opengis.net/ows/2.0 ProviderName" yaml:"providername"`
 	ProviderSite struct {
-		Type string `xml:"xlink:type,attr" yaml:"type"`
-		Href string `xml:"xlink:href,attr" yaml:"href"`
-	} `xml:"ows:ProviderSite" yaml:"providersite"`
+		Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+		Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	} `xml:"http://www.opengis.net/ows/2.0 ProviderSite" yaml:"providersite"`
 	ServiceContact struct {
-		IndividualName string `xml:"ows:IndividualName" yaml:"individualname"`
-		PositionName   string `xml:"ows:PositionName" yaml:"positionname"`
+		IndividualName string `xml:"http://www.opengis.net/ows/2.0 IndividualName" yaml:"individualname"`
+		PositionName   string `xml:"http://www.opengis.net/ows/2.0 PositionName" yaml:"positionname"`
 		ContactInfo    struct {
 			Phone struct {
-				Voice     string `xml:"ows:Voice" yaml:"voice"`
-				Facsimile string `xml:"ows:Facsimile" yaml:"facsimile"`
-			} `xml:"ows:Phone" yaml:"phone"`
+				Voice     string `xml:"http://www.opengis.net/ows/2.0 Voice" yaml:"voice"`
+				Facsimile string `xml:"http://www.opengis.net/ows/2.0 Facsimile" yaml:"facsimile"`
+			} `xml:"http://www.opengis.net/ows/2.0 Phone" yaml:"phone"`
 			Address struct {
-				DeliveryPoint         string `xml:"ows:DeliveryPoint" yaml:"deliverypoint"`
-				City                  string `xml:"ows:City" yaml:"city"`
-				AdministrativeArea    string `xml:"ows:AdministrativeArea" yaml:"administrativearea"`
-				PostalCode            string `xml:"ows:PostalCode" yaml:"postalcode"`
-				Country               string `xml:"ows:Country" yaml:"country"`
-				ElectronicMailAddress string `xml:"ows:ElectronicMailAddress" yaml:"electronicmailaddress"`
-			} `xml:"ows:Address" yaml:"address"`
+				DeliveryPoint         string `xml:"http://www.opengis.net/ows/2.0 DeliveryPoint" yaml:"deliverypoint"`
+				City                  string `xml:"http://www.opengis.net/ows/2.0 City" yaml:"city"`
+				AdministrativeArea    string `xml:"http://www.opengis.net/ows/2.0 AdministrativeArea" yaml:"administrativearea"`
+				PostalCode            string `xml:"http://www.opengis.net/ows/2.0 PostalCode" yaml:"postalcode"`
+				Country               string `xml:"http://www.opengis.net/ows/2.0 Country" yaml:"country"`
+				ElectronicMailAddress string `xml:"http://www.opengis.net/ows/2.0 ElectronicMailAddress" yaml:"electronicmailaddress"`
+			} `xml:"http://www.opengis.net/ows/2.0 Address" yaml:"address"`
 			OnlineResource struct {
-				Type string `xml:"xlink:type,attr" yaml:"type"`
-				Href string `xml:"xlink:href,attr" yaml:"href"`
-			} `xml:"ows:OnlineResource" yaml:"onlineresource"`
-			HoursOfService      string `xml:"ows:HoursOfService" yaml:"hoursofservice"`
-			ContactInstructions string `xml:"ows:ContactInstructions" yaml:"contactinstructions"`
-		} `xml:"ows:ContactInfo" yaml:"contactinfo"`
-		Role string `xml:"ows:Role" yaml:"role"`
-	} `xml:"ows:ServiceContact" yaml:"servicecontact"`
+				Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+				Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+			} `xml:"http://www.opengis.net/ows/2.0 OnlineResource" yaml:"onlineresource"`
+			HoursOfService      string `xml:"http://www.opengis.net/ows/2.0 HoursOfService" yaml:"hoursofservice"`
+			ContactInstructions string `xml:"http://www.opengis.net/ows/2.0 ContactInstructions" yaml:"contactinstructions"`
+		} `xml:"http://www.opengis.net/ows/2.0 ContactInfo" yaml:"contactinfo"`
+		Role string `xml:"http://www.opengis.net/ows/2.0 Role" yaml:"role"`
+	} `xml:"http://www.opengis.net/ows/2.0 ServiceContact" yaml:"servicecontact"`
 }
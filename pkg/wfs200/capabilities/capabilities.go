@@ -4,52 +4,59 @@ import (
 	"encoding/xml"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
+	"gopkg.in/yaml.v2"
 )
 
 // ParseXML func
+// The document can be a complete WFS_Capabilities document
 func (c *Capabilities) ParseXML(doc []byte) error {
+	var capabilities Capabilities
+	if err := xml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	*c = capabilities
 	return nil
 }
 
 // ParseYAMl func
 func (c *Capabilities) ParseYAMl(doc []byte) error {
+	var capabilities Capabilities
+	if err := yaml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	*c = capabilities
 	return nil
 }
 
 // Capabilities struct
 type Capabilities struct {
-	OperationsMetadata OperationsMetadata `xml:"ows:OperationsMetadata" yaml:"operationsmetadata"`
-	FeatureTypeList    FeatureTypeList    `xml:"wfs:FeatureTypeList" yaml:"featuretypelist"`
-	FilterCapabilities FilterCapabilities `xml:"fes:Filter_Capabilities" yaml:"filtercapabilities"`
+	OperationsMetadata OperationsMetadata `xml:"http://www.opengis.net/ows/1.1 OperationsMetadata" yaml:"operationsmetadata"`
+	FeatureTypeList    FeatureTypeList    `xml:"http://www.opengis.net/wfs/2.0 FeatureTypeList" yaml:"featuretypelist"`
+	FilterCapabilities FilterCapabilities `xml:"http://www.opengis.net/fes/2.0 Filter_Capabilities" yaml:"filtercapabilities"`
 }
 
 // Method in separated struct so to use it as a Pointer
 type Method struct {
-	Type string `xml:"xlink:type,attr" yaml:"type"`
-	Href string `xml:"xlink:href,attr" yaml:"href"`
+	Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+	Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
 }
 
 // OperationsMetadata struct for the WFS 2.0.0
 type OperationsMetadata struct {
-	XMLName   xml.Name    `xml:"ows:OperationsMetadata"`
-	Operation []Operation `xml:"ows:Operation"`
-	Parameter struct {
-		Name          string `xml:"name,attr" yaml:"name"`
-		AllowedValues struct {
-			Value []string `xml:"ows:Value" yaml:"value"`
-		} `xml:"ows:AllowedValues" yaml:"allowedvalues"`
-	} `xml:"ows:Parameter" yaml:"parameter"`
-	Constraint           []Constraint          `xml:"ows:Constraint" yaml:"constraint"`
-	ExtendedCapabilities *ExtendedCapabilities `xml:"ows:ExtendedCapabilities" yaml:"extendedcapabilities"`
+	XMLName              xml.Name              `xml:"http://www.opengis.net/ows/1.1 OperationsMetadata"`
+	Operation            []Operation           `xml:"http://www.opengis.net/ows/1.1 Operation" yaml:"operation"`
+	Parameter            []Parameter           `xml:"http://www.opengis.net/ows/1.1 Parameter" yaml:"parameter"`
+	Constraint           []Constraint          `xml:"http://www.opengis.net/ows/1.1 Constraint" yaml:"constraint"`
+	ExtendedCapabilities *ExtendedCapabilities `xml:"http://www.opengis.net/ows/1.1 ExtendedCapabilities" yaml:"extendedcapabilities"`
 }
 
 // Constraint struct for the WFS 2.0.0
 type Constraint struct {
 	Text          string         `xml:",chardata"`
 	Name          string         `xml:"name,attr" yaml:"name"`
-	NoValues      *string        `xml:"ows:NoValues" yaml:"novalues"`
-	DefaultValue  *string        `xml:"ows:DefaultValue" yaml:"defaultvalue"`
-	AllowedValues *AllowedValues `xml:"ows:AllowedValues" yaml:"allowedvalues"`
+	NoValues      *string        `xml:"http://www.opengis.net/ows/1.1 NoValues" yaml:"novalues"`
+	DefaultValue  *string        `xml:"http://www.opengis.net/ows/1.1 DefaultValue" yaml:"defaultvalue"`
+	AllowedValues *AllowedValues `xml:"http://www.opengis.net/ows/1.1 AllowedValues" yaml:"allowedvalues"`
 }
 
 // Operation struct for the WFS 2.0.0
@@ -57,21 +64,22 @@ type Operation struct {
 	Name string `xml:"name,attr"`
 	DCP  struct {
 		HTTP struct {
-			Get  *Method `xml:"ows:Get,omitempty" yaml:"get,omitempty"`
-			Post *Method `xml:"ows:Post,omitempty" yaml:"post,omitempty"`
-		} `xml:"ows:HTTP" yaml:"http"`
-	} `xml:"ows:DCP" yaml:"dcp"`
-	Parameter []struct {
-		Name          string `xml:"name,attr"`
-		AllowedValues struct {
-			Value []string `xml:"ows:Value"`
-		} `xml:"ows:AllowedValues"`
-	} `xml:"ows:Parameter"`
+			Get  *Method `xml:"http://www.opengis.net/ows/1.1 Get,omitempty" yaml:"get,omitempty"`
+			Post *Method `xml:"http://www.opengis.net/ows/1.1 Post,omitempty" yaml:"post,omitempty"`
+		} `xml:"http://www.opengis.net/ows/1.1 HTTP" yaml:"http"`
+	} `xml:"http://www.opengis.net/ows/1.1 DCP" yaml:"dcp"`
+	Parameter []Parameter `xml:"http://www.opengis.net/ows/1.1 Parameter" yaml:"parameter"`
+}
+
+// Parameter struct for the WFS 2.0.0
+type Parameter struct {
+	Name          string        `xml:"name,attr" yaml:"name"`
+	AllowedValues AllowedValues `xml:"http://www.opengis.net/ows/1.1 AllowedValues" yaml:"allowedvalues"`
 }
 
 // AllowedValues struct so it can be used as a pointer
 type AllowedValues struct {
-	Value []string `xml:"ows:Value" yaml:"value"`
+	Value []string `xml:"http://www.opengis.net/ows/1.1 Value" yaml:"value"`
 }
 
 // ExtendedCapabilities struct for the WFS 2.0.0
@@ -79,45 +87,48 @@ type ExtendedCapabilities struct {
 	ExtendedCapabilities struct {
 		Text        string `xml:",chardata"`
 		MetadataURL struct {
-			Type      string `xml:"xsi:type,attr" yaml:"type"`
-			URL       string `xml:"inspire_common:URL" yaml:"url"`
-			MediaType string `xml:"inspire_common:MediaType" yaml:"mediatype"`
-		} `xml:"inspire_common:MetadataUrl" yaml:"metadataurl"`
+			Type      string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr" yaml:"type"`
+			URL       string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 URL" yaml:"url"`
+			MediaType string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MediaType" yaml:"mediatype"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MetadataUrl" yaml:"metadataurl"`
 		SupportedLanguages struct {
 			DefaultLanguage struct {
-				Language string `xml:"inspire_common:Language" yaml:"language"`
-			} `xml:"inspire_common:DefaultLanguage" yaml:"defaultlanguage"`
-		} `xml:"inspire_common:SupportedLanguages" yaml:"supportedlanguages"`
+				Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+			} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 DefaultLanguage" yaml:"defaultlanguage"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 SupportedLanguages" yaml:"supportedlanguages"`
 		ResponseLanguage struct {
-			Language string `xml:"inspire_common:Language" yaml:"language"`
-		} `xml:"inspire_common:ResponseLanguage" yaml:"responselanguage"`
+			Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 ResponseLanguage" yaml:"responselanguage"`
 		SpatialDataSetIdentifier struct {
-			Code string `xml:"inspire_common:Code" yaml:"code"`
-		} `xml:"inspire_dls:SpatialDataSetIdentifier" yaml:"spatialdatasetidentifier"`
-	} `xml:"inspire_dls:ExtendedCapabilities" yaml:"extendedcapabilities"`
+			Code string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Code" yaml:"code"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/inspire_dls/1.0 SpatialDataSetIdentifier" yaml:"spatialdatasetidentifier"`
+	} `xml:"http://inspire.ec.europa.eu/schemas/inspire_dls/1.0 ExtendedCapabilities" yaml:"extendedcapabilities"`
 }
 
 // FeatureTypeList struct for the WFS 2.0.0
 type FeatureTypeList struct {
-	XMLName     xml.Name      `xml:"wfs:FeatureTypeList"`
-	FeatureType []FeatureType `xml:"wfs:FeatureType" yaml:"featuretype"`
+	XMLName     xml.Name      `xml:"http://www.opengis.net/wfs/2.0 FeatureTypeList"`
+	FeatureType []FeatureType `xml:"http://www.opengis.net/wfs/2.0 FeatureType" yaml:"featuretype"`
 }
 
 // FeatureType struct for the WFS 2.0.0
 type FeatureType struct {
-	Name          string        `xml:"wfs:Name" yaml:"name"`
-	Title         string        `xml:"wfs:Title" yaml:"title"`
-	Abstract      string        `xml:"wfs:Abstract" yaml:"abstract"`
-	Keywords      *ows.Keywords `xml:"ows:Keywords" yaml:"keywords"`
-	DefaultCRS    *ows.CRS      `xml:"wfs:DefaultCRS" yaml:"defaultcrs"`
-	OtherCRS      *[]ows.CRS    `xml:"wfs:OtherCRS" yaml:"othercrs"`
-	OutputFormats struct {
-		Format []string `xml:"wfs:Format" yaml:"format"`
-	} `xml:"wfs:OutputFormats" yaml:"outputformats"`
-	WGS84BoundingBox ows.BoundingBox `xml:"ows:WGS84BoundingBox" yaml:"wgs84boundingbox"`
+	Name             string          `xml:"http://www.opengis.net/wfs/2.0 Name" yaml:"name"`
+	Title            string          `xml:"http://www.opengis.net/wfs/2.0 Title" yaml:"title"`
+	Abstract         string          `xml:"http://www.opengis.net/wfs/2.0 Abstract" yaml:"abstract"`
+	Keywords         *ows.Keywords   `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords"`
+	DefaultCRS       *ows.CRS        `xml:"http://www.opengis.net/wfs/2.0 DefaultCRS" yaml:"defaultcrs"`
+	OtherCRS         *[]ows.CRS      `xml:"http://www.opengis.net/wfs/2.0 OtherCRS" yaml:"othercrs"`
+	OutputFormats    OutputFormats   `xml:"http://www.opengis.net/wfs/2.0 OutputFormats" yaml:"outputformats"`
+	WGS84BoundingBox ows.BoundingBox `xml:"http://www.opengis.net/ows/1.1 WGS84BoundingBox" yaml:"wgs84boundingbox"`
 	MetadataURL      struct {
-		Href string `xml:"xlink:href,attr" yaml:"href"`
-	} `xml:"wfs:MetadataURL" yaml:"metadataurl"`
+		Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	} `xml:"http://www.opengis.net/wfs/2.0 MetadataURL" yaml:"metadataurl"`
+}
+
+// OutputFormats struct for the WFS 2.0.0 FeatureType
+type OutputFormats struct {
+	Format []string `xml:"http://www.opengis.net/wfs/2.0 Format" yaml:"format"`
 }
 
 // FilterCapabilities struct for the WFS 2.0.0
@@ -125,37 +136,37 @@ type FilterCapabilities struct {
 	Conformance struct {
 		Constraint []struct {
 			Name         string `xml:"name,attr" yaml:"name"`
-			NoValues     string `xml:"ows:NoValues" yaml:"novalues"`
-			DefaultValue string `xml:"ows:DefaultValue" yaml:"defaultvalue"`
-		} `xml:"fes:Constraint" yaml:"constraint"`
-	} `xml:"fes:Conformance" yaml:"conformance"`
+			NoValues     string `xml:"http://www.opengis.net/ows/1.1 NoValues" yaml:"novalues"`
+			DefaultValue string `xml:"http://www.opengis.net/ows/1.1 DefaultValue" yaml:"defaultvalue"`
+		} `xml:"http://www.opengis.net/fes/2.0 Constraint" yaml:"constraint"`
+	} `xml:"http://www.opengis.net/fes/2.0 Conformance" yaml:"conformance"`
 	IDCapabilities struct {
 		ResourceIdentifier struct {
 			Name string `xml:"name,attr" yaml:"name"`
-		} `xml:"fes:ResourceIdentifier" yaml:"resourceidentifier"`
-	} `xml:"fes:Id_Capabilities" yaml:"idcapabilities"`
+		} `xml:"http://www.opengis.net/fes/2.0 ResourceIdentifier" yaml:"resourceidentifier"`
+	} `xml:"http://www.opengis.net/fes/2.0 Id_Capabilities" yaml:"idcapabilities"`
 	ScalarCapabilities struct {
-		LogicalOperators    string `xml:"fes:LogicalOperators" yaml:"logicaloperators"`
+		LogicalOperators    string `xml:"http://www.opengis.net/fes/2.0 LogicalOperators" yaml:"logicaloperators"`
 		ComparisonOperators struct {
 			ComparisonOperator []struct {
 				Name string `xml:"name,attr"`
-			} `xml:"fes:ComparisonOperator" yaml:"comparisonoperator"`
-		} `xml:"fes:ComparisonOperators" yaml:"comparisonoperators"`
-	} `xml:"fes:Scalar_Capabilities" yaml:"scalarcapabilities"`
+			} `xml:"http://www.opengis.net/fes/2.0 ComparisonOperator" yaml:"comparisonoperator"`
+		} `xml:"http://www.opengis.net/fes/2.0 ComparisonOperators" yaml:"comparisonoperators"`
+	} `xml:"http://www.opengis.net/fes/2.0 Scalar_Capabilities" yaml:"scalarcapabilities"`
 	SpatialCapabilities struct {
 		GeometryOperands struct {
 			GeometryOperand []struct {
 				Name string `xml:"name,attr"`
-			} `xml:"fes:GeometryOperand"`
-		} `xml:"fes:GeometryOperands"`
+			} `xml:"http://www.opengis.net/fes/2.0 GeometryOperand"`
+		} `xml:"http://www.opengis.net/fes/2.0 GeometryOperands"`
 		SpatialOperators struct {
 			SpatialOperator []struct {
 				Name string `xml:"name,attr"`
-			} `xml:"fes:SpatialOperator"`
-		} `xml:"fes:SpatialOperators"`
-	} `xml:"fes:Spatial_Capabilities"`
+			} `xml:"http://www.opengis.net/fes/2.0 SpatialOperator"`
+		} `xml:"http://www.opengis.net/fes/2.0 SpatialOperators"`
+	} `xml:"http://www.opengis.net/fes/2.0 Spatial_Capabilities"`
 	// NO TemporalCapabilities!!!
-	TemporalCapabilities *TemporalCapabilities `xml:"fes:Temporal_Capabilities" yaml:"temporalcapabilities"`
+	TemporalCapabilities *TemporalCapabilities `xml:"http://www.opengis.net/fes/2.0 Temporal_Capabilities" yaml:"temporalcapabilities"`
 }
 
 // TemporalCapabilities define but not used
@@ -163,11 +174,11 @@ type TemporalCapabilities struct {
 	TemporalOperands struct {
 		TemporalOperand []struct {
 			Name string `xml:"name,attr" yaml:"name"`
-		} `xml:"fes:TemporalOperand" yaml:"temporaloperand"`
-	} `xml:"fes:TemporalOperands" yaml:"temporaloperands"`
+		} `xml:"http://www.opengis.net/fes/2.0 TemporalOperand" yaml:"temporaloperand"`
+	} `xml:"http://www.opengis.net/fes/2.0 TemporalOperands" yaml:"temporaloperands"`
 	TemporalOperators struct {
 		TemporalOperator []struct {
 			Name string `xml:"name,attr,omitempty" yaml:"name,omitempty"`
-		} `xml:"fes:TemporalOperator" yaml:"temporaloperator"`
-	} `xml:"fes:TemporalOperators" yaml:"temporaloperators"`
+		} `xml:"http://www.opengis.net/fes/2.0 TemporalOperator" yaml:"temporaloperator"`
+	} `xml:"http://www.opengis.net/fes/2.0 TemporalOperators" yaml:"temporaloperators"`
 }
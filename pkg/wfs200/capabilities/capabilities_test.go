@@ -0,0 +1,322 @@
+package capabilities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// capabilities document with the WFS namespace as default namespace like GeoServer does
+var geoserverCapabilities = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<wfs:WFS_Capabilities version="2.0.0" xmlns="http://www.opengis.net/wfs/2.0" xmlns:wfs="http://www.opengis.net/wfs/2.0" xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:fes="http://www.opengis.net/fes/2.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/wfs/2.0 http://schemas.opengis.net/wfs/2.0/wfs.xsd">
+  <ows:ServiceIdentification>
+    <ows:Title>GeoServer Web Feature Service</ows:Title>
+    <ows:ServiceType>WFS</ows:ServiceType>
+    <ows:ServiceTypeVersion>2.0.0</ows:ServiceTypeVersion>
+  </ows:ServiceIdentification>
+  <ows:OperationsMetadata>
+    <ows:Operation name="GetCapabilities">
+      <ows:DCP>
+        <ows:HTTP>
+          <ows:Get xlink:href="http://localhost:8080/geoserver/wfs"/>
+          <ows:Post xlink:href="http://localhost:8080/geoserver/wfs"/>
+        </ows:HTTP>
+      </ows:DCP>
+      <ows:Parameter name="AcceptVersions">
+        <ows:AllowedValues>
+          <ows:Value>1.0.0</ows:Value>
+          <ows:Value>1.1.0</ows:Value>
+          <ows:Value>2.0.0</ows:Value>
+        </ows:AllowedValues>
+      </ows:Parameter>
+    </ows:Operation>
+    <ows:Operation name="GetFeature">
+      <ows:DCP>
+        <ows:HTTP>
+          <ows:Get xlink:href="http://localhost:8080/geoserver/wfs"/>
+        </ows:HTTP>
+      </ows:DCP>
+      <ows:Parameter name="outputFormat">
+        <ows:AllowedValues>
+          <ows:Value>application/gml+xml; version=3.2</ows:Value>
+          <ows:Value>application/json</ows:Value>
+        </ows:AllowedValues>
+      </ows:Parameter>
+    </ows:Operation>
+    <ows:Parameter name="version">
+      <ows:AllowedValues>
+        <ows:Value>2.0.0</ows:Value>
+      </ows:AllowedValues>
+    </ows:Parameter>
+    <ows:Constraint name="ImplementsBasicWFS">
+      <ows:NoValues/>
+      <ows:DefaultValue>TRUE</ows:DefaultValue>
+    </ows:Constraint>
+    <ows:Constraint name="CountDefault">
+      <ows:NoValues/>
+      <ows:DefaultValue>1000</ows:DefaultValue>
+    </ows:Constraint>
+  </ows:OperationsMetadata>
+  <FeatureTypeList>
+    <FeatureType xmlns:topp="http://www.openplans.org/topp">
+      <Name>topp:states</Name>
+      <Title>USA Population</Title>
+      <Abstract>This is some census data on the states.</Abstract>
+      <ows:Keywords>
+        <ows:Keyword>census</ows:Keyword>
+        <ows:Keyword>united</ows:Keyword>
+      </ows:Keywords>
+      <DefaultCRS>urn:ogc:def:crs:EPSG::4326</DefaultCRS>
+      <OtherCRS>urn:ogc:def:crs:EPSG::3857</OtherCRS>
+      <ows:WGS84BoundingBox>
+        <ows:LowerCorner>-124.731422 24.955967</ows:LowerCorner>
+        <ows:UpperCorner>-66.969849 49.371735</ows:UpperCorner>
+      </ows:WGS84BoundingBox>
+      <MetadataURL xlink:href="http://localhost/metadata/states"/>
+    </FeatureType>
+  </FeatureTypeList>
+  <fes:Filter_Capabilities>
+    <fes:Conformance>
+      <fes:Constraint name="ImplementsQuery">
+        <ows:NoValues/>
+        <ows:DefaultValue>TRUE</ows:DefaultValue>
+      </fes:Constraint>
+    </fes:Conformance>
+    <fes:Id_Capabilities>
+      <fes:ResourceIdentifier name="fes:ResourceId"/>
+    </fes:Id_Capabilities>
+    <fes:Scalar_Capabilities>
+      <fes:LogicalOperators/>
+      <fes:ComparisonOperators>
+        <fes:ComparisonOperator name="PropertyIsEqualTo"/>
+        <fes:ComparisonOperator name="PropertyIsLike"/>
+      </fes:ComparisonOperators>
+    </fes:Scalar_Capabilities>
+    <fes:Spatial_Capabilities>
+      <fes:GeometryOperands>
+        <fes:GeometryOperand name="gml:Envelope"/>
+      </fes:GeometryOperands>
+      <fes:SpatialOperators>
+        <fes:SpatialOperator name="BBOX"/>
+      </fes:SpatialOperators>
+    </fes:Spatial_Capabilities>
+  </fes:Filter_Capabilities>
+</wfs:WFS_Capabilities>`)
+
+// capabilities document with other prefixes and the INSPIRE extended capabilities
+var inspireCapabilities = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<WFS_Capabilities version="2.0.0" xmlns="http://www.opengis.net/wfs/2.0" xmlns:o="http://www.opengis.net/ows/1.1" xmlns:f="http://www.opengis.net/fes/2.0" xmlns:xl="http://www.w3.org/1999/xlink" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:ic="http://inspire.ec.europa.eu/schemas/common/1.0" xmlns:dls="http://inspire.ec.europa.eu/schemas/inspire_dls/1.0">
+  <o:OperationsMetadata>
+    <o:Operation name="DescribeFeatureType">
+      <o:DCP><o:HTTP><o:Get xl:type="simple" xl:href="https://service.pdok.nl/wfs"/></o:HTTP></o:DCP>
+    </o:Operation>
+    <o:ExtendedCapabilities>
+      <dls:ExtendedCapabilities>
+        <ic:MetadataUrl xsi:type="ic:resourceLocatorType">
+          <ic:URL>https://www.nationaalgeoregister.nl/metadata</ic:URL>
+          <ic:MediaType>application/vnd.ogc.csw.GetRecordByIdResponse_xml</ic:MediaType>
+        </ic:MetadataUrl>
+        <ic:SupportedLanguages>
+          <ic:DefaultLanguage><ic:Language>dut</ic:Language></ic:DefaultLanguage>
+        </ic:SupportedLanguages>
+        <ic:ResponseLanguage><ic:Language>dut</ic:Language></ic:ResponseLanguage>
+        <dls:SpatialDataSetIdentifier><ic:Code>a1b2c3</ic:Code></dls:SpatialDataSetIdentifier>
+      </dls:ExtendedCapabilities>
+    </o:ExtendedCapabilities>
+  </o:OperationsMetadata>
+  <FeatureTypeList>
+    <FeatureType>
+      <Name>au:AdministrativeUnit</Name>
+      <Title>AdministrativeUnit</Title>
+      <DefaultCRS>urn:ogc:def:crs:EPSG::28992</DefaultCRS>
+      <OtherCRS>urn:ogc:def:crs:EPSG::4258</OtherCRS>
+      <OtherCRS>urn:ogc:def:crs:EPSG::3035</OtherCRS>
+      <OutputFormats>
+        <Format>application/gml+xml; version=3.2</Format>
+      </OutputFormats>
+      <o:WGS84BoundingBox dimensions="2">
+        <o:LowerCorner>3.2 50.7</o:LowerCorner>
+        <o:UpperCorner>7.2 53.5</o:UpperCorner>
+      </o:WGS84BoundingBox>
+    </FeatureType>
+  </FeatureTypeList>
+</WFS_Capabilities>`)
+
+func TestCapabilitiesParseXML(t *testing.T) {
+	var tests = []struct {
+		doc    []byte
+		result func(c Capabilities) []string
+	}{
+		0: {doc: geoserverCapabilities, result: func(c Capabilities) []string {
+			return []string{
+				c.OperationsMetadata.Operation[0].Name,
+				c.OperationsMetadata.Operation[0].DCP.HTTP.Get.Href,
+				c.OperationsMetadata.Operation[0].DCP.HTTP.Post.Href,
+				c.OperationsMetadata.Operation[0].Parameter[0].AllowedValues.Value[2],
+				c.OperationsMetadata.Operation[1].Parameter[0].AllowedValues.Value[1],
+				c.OperationsMetadata.Parameter[0].Name,
+				c.OperationsMetadata.Constraint[1].Name,
+				*c.OperationsMetadata.Constraint[1].DefaultValue,
+				c.FeatureTypeList.FeatureType[0].Name,
+				c.FeatureTypeList.FeatureType[0].Abstract,
+				c.FeatureTypeList.FeatureType[0].Keywords.Keyword[1],
+				c.FeatureTypeList.FeatureType[0].DefaultCRS.String(),
+				(*c.FeatureTypeList.FeatureType[0].OtherCRS)[0].String(),
+				c.FeatureTypeList.FeatureType[0].MetadataURL.Href,
+				c.FilterCapabilities.Conformance.Constraint[0].DefaultValue,
+				c.FilterCapabilities.IDCapabilities.ResourceIdentifier.Name,
+				c.FilterCapabilities.ScalarCapabilities.ComparisonOperators.ComparisonOperator[1].Name,
+				c.FilterCapabilities.SpatialCapabilities.GeometryOperands.GeometryOperand[0].Name,
+				c.FilterCapabilities.SpatialCapabilities.SpatialOperators.SpatialOperator[0].Name,
+			}
+		}},
+		1: {doc: inspireCapabilities, result: func(c Capabilities) []string {
+			e := c.OperationsMetadata.ExtendedCapabilities.ExtendedCapabilities
+			return []string{
+				c.OperationsMetadata.Operation[0].DCP.HTTP.Get.Type,
+				c.OperationsMetadata.Operation[0].DCP.HTTP.Get.Href,
+				e.MetadataURL.Type,
+				e.MetadataURL.URL,
+				e.MetadataURL.MediaType,
+				e.SupportedLanguages.DefaultLanguage.Language,
+				e.ResponseLanguage.Language,
+				e.SpatialDataSetIdentifier.Code,
+				c.FeatureTypeList.FeatureType[0].Name,
+				c.FeatureTypeList.FeatureType[0].DefaultCRS.String(),
+				(*c.FeatureTypeList.FeatureType[0].OtherCRS)[1].String(),
+				c.FeatureTypeList.FeatureType[0].OutputFormats.Format[0],
+				c.FeatureTypeList.FeatureType[0].WGS84BoundingBox.Dimensions,
+			}
+		}},
+	}
+
+	var expected = [][]string{
+		0: {`GetCapabilities`, `http://localhost:8080/geoserver/wfs`, `http://localhost:8080/geoserver/wfs`, `2.0.0`, `application/json`,
+			`version`, `CountDefault`, `1000`, `topp:states`, `This is some census data on the states.`, `united`, `EPSG:4326`, `EPSG:3857`,
+			`http://localhost/metadata/states`, `TRUE`, `fes:ResourceId`, `PropertyIsLike`, `gml:Envelope`, `BBOX`},
+		1: {`simple`, `https://service.pdok.nl/wfs`, `ic:resourceLocatorType`, `https://www.nationaalgeoregister.nl/metadata`,
+			`application/vnd.ogc.csw.GetRecordByIdResponse_xml`, `dut`, `dut`, `a1b2c3`, `au:AdministrativeUnit`, `EPSG:28992`, `EPSG:3035`,
+			`application/gml+xml; version=3.2`, `2`},
+	}
+
+	for k, test := range tests {
+		var c Capabilities
+		if err := c.ParseXML(test.doc); err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		result := test.result(c)
+		if !reflect.DeepEqual(result, expected[k]) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, expected[k], result)
+		}
+	}
+
+	var c Capabilities
+	bbox := ows.BoundingBox{LowerCorner: ows.Position{-124.731422, 24.955967}, UpperCorner: ows.Position{-66.969849, 49.371735}}
+	if c.ParseXML(geoserverCapabilities); !c.FeatureTypeList.FeatureType[0].WGS84BoundingBox.Equal(bbox) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 2, bbox, c.FeatureTypeList.FeatureType[0].WGS84BoundingBox)
+	}
+	if err := c.ParseXML([]byte(`no XML document, just a string`)); err == nil {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 3, err)
+	}
+}
+
+// TestCapabilitiesRoundTrip parses, marshals and parses the documents again, the result should be the same
+// and the marshalled document uses the canonical prefixes whatever prefixes the original document used
+func TestCapabilitiesRoundTrip(t *testing.T) {
+	for k, doc := range [][]byte{geoserverCapabilities, inspireCapabilities} {
+		var original Capabilities
+		if err := original.ParseXML(doc); err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+
+		b, err := ows.CanonicalNamespaces.Marshal(original, ``, ``)
+		if err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		for _, element := range []string{`<ows:OperationsMetadata>`, `<ows:Operation name=`, `<wfs:FeatureTypeList>`, `<wfs:FeatureType>`, `<ows:WGS84BoundingBox`, `<ows:LowerCorner>`} {
+			if !strings.Contains(string(b), element) {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, element, string(b))
+			}
+		}
+
+		var result Capabilities
+		if err := result.ParseXML(b); err != nil {
+			t.Errorf("test: %d, expected no error,\n got: %s", k, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(original, result) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, original, result)
+		}
+	}
+}
+
+func TestCapabilitiesParseYAMl(t *testing.T) {
+	doc := []byte(`operationsmetadata:
+  operation:
+  - name: GetFeature
+    dcp:
+      http:
+        get:
+          type: simple
+          href: http://localhost/wfs
+    parameter:
+    - name: outputFormat
+      allowedvalues:
+        value:
+        - application/json
+featuretypelist:
+  featuretype:
+  - name: topp:states
+    title: USA Population
+    keywords:
+      keyword:
+      - census
+    defaultcrs: urn:ogc:def:crs:EPSG::4326
+    othercrs:
+    - EPSG:3857
+    outputformats:
+      format:
+      - application/json
+    wgs84boundingbox:
+      lowercorner: -124.731422 24.955967
+      uppercorner: -66.969849 49.371735
+filtercapabilities:
+  scalarcapabilities:
+    comparisonoperators:
+      comparisonoperator:
+      - name: PropertyIsEqualTo`)
+
+	var c Capabilities
+	if err := c.ParseYAMl(doc); err != nil {
+		t.Fatalf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+
+	result := []string{
+		c.OperationsMetadata.Operation[0].Name,
+		c.OperationsMetadata.Operation[0].DCP.HTTP.Get.Href,
+		c.OperationsMetadata.Operation[0].Parameter[0].AllowedValues.Value[0],
+		c.FeatureTypeList.FeatureType[0].Name,
+		c.FeatureTypeList.FeatureType[0].Keywords.Keyword[0],
+		c.FeatureTypeList.FeatureType[0].DefaultCRS.String(),
+		(*c.FeatureTypeList.FeatureType[0].OtherCRS)[0].String(),
+		c.FeatureTypeList.FeatureType[0].OutputFormats.Format[0],
+		c.FilterCapabilities.ScalarCapabilities.ComparisonOperators.ComparisonOperator[0].Name,
+	}
+	expected := []string{`GetFeature`, `http://localhost/wfs`, `application/json`, `topp:states`, `census`, `EPSG:4326`, `EPSG:3857`, `application/json`, `PropertyIsEqualTo`}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, result)
+	}
+
+	bbox := ows.BoundingBox{LowerCorner: ows.Position{-124.731422, 24.955967}, UpperCorner: ows.Position{-66.969849, 49.371735}}
+	if !c.FeatureTypeList.FeatureType[0].WGS84BoundingBox.Equal(bbox) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 1, bbox, c.FeatureTypeList.FeatureType[0].WGS84BoundingBox)
+	}
+	if err := c.ParseYAMl([]byte(`featuretypelist: [`)); err == nil {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 2, err)
+	}
+}
@@ -0,0 +1,76 @@
+package capabilities
+
+import (
+	"strings"
+)
+
+// GetFeature output formats that can be advertised with the FeatureType OutputFormats
+// or the outputFormat parameter of the GetFeature operation
+const (
+	OutputFormatGML32   = `application/gml+xml; version=3.2`
+	OutputFormatGeoJSON = `application/json`
+)
+
+// aliases for the GeoJSON outputformat commonly used by clients
+var geojsonOutputFormats = []string{OutputFormatGeoJSON, `json`, `application/geo+json`, `geojson`}
+
+// IsGeoJSON checks if the given outputformat requests a GeoJSON response
+func IsGeoJSON(format string) bool {
+	for _, f := range geojsonOutputFormats {
+		if normalizeOutputFormat(format) == normalizeOutputFormat(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports checks if the given outputformat is advertised
+func (o OutputFormats) Supports(format string) bool {
+	for _, f := range o.Format {
+		if normalizeOutputFormat(f) == normalizeOutputFormat(format) {
+			return true
+		}
+		if IsGeoJSON(f) && IsGeoJSON(format) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFeatureType returns the FeatureType with the given name
+func (c *Capabilities) GetFeatureType(name string) (FeatureType, bool) {
+	for _, ft := range c.FeatureTypeList.FeatureType {
+		if ft.Name == name {
+			return ft, true
+		}
+	}
+	return FeatureType{}, false
+}
+
+// OutputFormatSupported checks if the outputformat is supported for the given FeatureType.
+// When the FeatureType doesn't advertise any OutputFormats the allowed values of the
+// GetFeature outputFormat parameter are used and when those are missing the WFS 2.0.0
+// default GML 3.2 outputformat.
+func (c *Capabilities) OutputFormatSupported(typename, format string) bool {
+	if ft, ok := c.GetFeatureType(typename); ok && len(ft.OutputFormats.Format) > 0 {
+		return ft.OutputFormats.Supports(format)
+	}
+
+	for _, o := range c.OperationsMetadata.Operation {
+		if o.Name != `GetFeature` {
+			continue
+		}
+		for _, p := range o.Parameter {
+			if strings.EqualFold(p.Name, `outputFormat`) && len(p.AllowedValues.Value) > 0 {
+				return OutputFormats{Format: p.AllowedValues.Value}.Supports(format)
+			}
+		}
+	}
+
+	return OutputFormats{Format: []string{OutputFormatGML32}}.Supports(format)
+}
+
+// normalizeOutputFormat so 'application/gml+xml; version=3.2' and 'application/gml+xml;version=3.2' are equal
+func normalizeOutputFormat(format string) string {
+	return strings.ToLower(strings.Join(strings.Fields(format), ``))
+}
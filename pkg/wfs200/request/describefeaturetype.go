@@ -75,7 +75,7 @@ func (dft *DescribeFeatureType) ParseKVP(query url.Values) ows.Exceptions {
 				dft.XMLName.Local = describefeaturetype
 			}
 		case TYPENAME:
-			dft.BaseDescribeFeatureTypeRequest.TypeName = &v[0] //TODO maybe process as a comma separated list
+			dft.BaseDescribeFeatureTypeRequest.TypeName = &v[0] // a comma separated list is processed by TypeNames()
 		case OUTPUTFORMAT:
 			// TODO nothing for now always assume the default text/xml; subtype=gml/3.2
 		}
@@ -99,6 +99,20 @@ func (dft *DescribeFeatureType) BuildKVP() url.Values {
 	return querystring
 }
 
+// TypeNames returns the requested typenames, because the TYPENAME can be a comma separated list
+func (dft *DescribeFeatureType) TypeNames() []string {
+	var typenames []string
+	if dft.BaseDescribeFeatureTypeRequest.TypeName == nil {
+		return typenames
+	}
+	for _, typename := range strings.Split(*dft.BaseDescribeFeatureTypeRequest.TypeName, `,`) {
+		if t := strings.TrimSpace(typename); t != `` {
+			typenames = append(typenames, t)
+		}
+	}
+	return typenames
+}
+
 // BuildXML builds a 'new' XML document 'based' on the 'original' XML document
 func (dft *DescribeFeatureType) BuildXML() []byte {
 	si, _ := xml.MarshalIndent(dft, "", "")
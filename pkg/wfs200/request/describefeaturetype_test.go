@@ -209,6 +209,32 @@ func TestDescribeFeatureTypeBuildXML(t *testing.T) {
 	}
 }
 
+func TestDescribeFeatureTypeTypeNames(t *testing.T) {
+	var tests = []struct {
+		dft       DescribeFeatureType
+		typenames []string
+	}{
+		0: {dft: DescribeFeatureType{}},
+		1: {dft: DescribeFeatureType{BaseDescribeFeatureTypeRequest: BaseDescribeFeatureTypeRequest{TypeName: sp(`acme:anvils`)}},
+			typenames: []string{`acme:anvils`}},
+		2: {dft: DescribeFeatureType{BaseDescribeFeatureTypeRequest: BaseDescribeFeatureTypeRequest{TypeName: sp(`acme:anvils, acme:rockets,`)}},
+			typenames: []string{`acme:anvils`, `acme:rockets`}},
+	}
+
+	for k, test := range tests {
+		typenames := test.dft.TypeNames()
+		if len(typenames) != len(test.typenames) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.typenames, typenames)
+			continue
+		}
+		for i := range typenames {
+			if typenames[i] != test.typenames[i] {
+				t.Errorf("test: %d, expected: %v,\n got: %v", k, test.typenames, typenames)
+			}
+		}
+	}
+}
+
 // ----------
 // Benchmarks
 // ----------
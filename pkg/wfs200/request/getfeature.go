@@ -2,7 +2,6 @@ package request
 
 import (
 	"encoding/xml"
-	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 	"github.com/pdok/ogc-specifications/pkg/utils"
+	"github.com/pdok/ogc-specifications/pkg/wfs200/capabilities"
 	"github.com/pdok/ogc-specifications/pkg/wfs200/exception"
 )
 
@@ -47,10 +47,36 @@ func (gf *GetFeature) Type() string {
 	return getfeature
 }
 
-// Validate returns GetFeature
+// Validate validates the GetFeature against the capabilities
+// Checks if the requested TypeNames are known and support the requested OutputFormat, and if the srsName of the BBOX is a CRS
+// The capabilities are a *capabilities.Capabilities, other capabilities result in a NoApplicableCode exception
 func (gf *GetFeature) Validate(c ows.Capabilities) ows.Exceptions {
+	var exceptions ows.Exceptions
 
-	//getfeaturecap := c.(capabilities.Capabilities)
+	wfsCapabilities, ok := c.(*capabilities.Capabilities)
+	if !ok {
+		return ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WFS 2.0.0 capabilities`)}
+	}
+
+	for _, typename := range gf.Query.typeNames() {
+		if _, ok := wfsCapabilities.GetFeatureType(typename); !ok {
+			exceptions = append(exceptions, ows.InvalidParameterValue(typename, TYPENAMES))
+			continue
+		}
+		if gf.OutputFormat != nil && !wfsCapabilities.OutputFormatSupported(typename, *gf.OutputFormat) {
+			exceptions = append(exceptions, ows.InvalidParameterValue(*gf.OutputFormat, OUTPUTFORMAT))
+		}
+	}
+
+	if gf.Query.Filter != nil && gf.Query.Filter.BBOX != nil {
+		if _, exception := gf.Query.Filter.BBOX.crs(); exception != nil {
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
 	return nil
 }
 
@@ -193,7 +219,9 @@ func (gf *GetFeature) ParseKVP(query url.Values) ows.Exceptions {
 				}
 			case BBOX:
 				var geobbox GEOBBOX
-				geobbox.UnmarshalText(q[k][0])
+				if exception := geobbox.UnmarshalText(q[k][0]); exception != nil {
+					return ows.Exceptions{exception}
+				}
 				if gf.Query.Filter != nil {
 					gf.Query.Filter.BBOX = &geobbox
 				} else {
@@ -322,7 +350,7 @@ func (q *Query) BuildQueryString() url.Values {
 			// fes:AbstractAdhocQueryExpressionType type (see ISO 19143, 6.3.2)
 		case SRSNAME:
 			if q.SrsName != nil {
-				querystring[TYPENAMES] = []string{*q.SrsName}
+				querystring[SRSNAME] = []string{*q.SrsName}
 			}
 		case FILTER:
 			if q.Filter != nil {
@@ -344,6 +372,18 @@ func (q *Query) BuildQueryString() url.Values {
 	return querystring
 }
 
+// typeNames returns the TypeNames as a list
+// the KVP encoding can contain a comma separated list and/or a parenthesized list for multiple queries
+func (q *Query) typeNames() []string {
+	var typenames []string
+	for _, t := range strings.FieldsFunc(q.TypeNames, func(r rune) bool { return r == ',' || r == '(' || r == ')' }) {
+		if t = strings.TrimSpace(t); t != `` {
+			typenames = append(typenames, t)
+		}
+	}
+	return typenames
+}
+
 // Query struct for parsing the WFS filter xml
 type Query struct {
 	TypeNames    string    `xml:"typeNames,attr" yaml:"typenames"`
@@ -654,42 +694,61 @@ type GEOBBOX struct {
 }
 
 // UnmarshalText a string to a GEOBBOX object
+// The lower corner is followed by the upper corner and optionally the srsName, like 10,10,0,20,20,5,urn:ogc:def:crs:EPSG::7415
 func (gb *GEOBBOX) UnmarshalText(q string) ows.Exception {
 	regex := regexp.MustCompile(`,`)
 	result := regex.Split(q, -1)
-	if len(result) == 4 || len(result) == 5 {
+	if len(result) < 4 {
+		return nil
+	}
 
-		var lx, ly, ux, uy float64
-		var err error
+	var srsname *string
+	if len(result)%2 == 1 {
+		srsname = &result[len(result)-1]
+		result = result[:len(result)-1]
+	}
 
-		if lx, err = strconv.ParseFloat(result[0], 64); err != nil {
-			return exception.InvalidValue(BBOX)
-		}
-		if ly, err = strconv.ParseFloat(result[1], 64); err != nil {
+	coords := make(ows.Position, len(result))
+	for i, v := range result {
+		c, err := strconv.ParseFloat(v, 64)
+		if err != nil {
 			return exception.InvalidValue(BBOX)
 		}
-		if ux, err = strconv.ParseFloat(result[2], 64); err != nil {
-			return exception.InvalidValue(BBOX)
+		coords[i] = c
+	}
+	half := len(coords) / 2
+	lower, upper := coords[:half:half], coords[half:]
+
+	if srsname != nil {
+		var crs ows.CRS
+		if exception := crs.ParseString(*srsname); exception != nil {
+			return ows.InvalidParameterValue(*srsname, BBOX)
 		}
-		if uy, err = strconv.ParseFloat(result[3], 64); err != nil {
+		if !lower.MatchesCRS(crs) {
 			return exception.InvalidValue(BBOX)
 		}
-
-		gb.Envelope.LowerCorner = ows.Position{lx, ly}
-		gb.Envelope.UpperCorner = ows.Position{ux, uy}
-	}
-	if len(result) == 5 {
-		gb.SrsName = &result[4]
+		// the corners are given in the axis order of the srsName
+		lower, upper = lower.ToXY(crs), upper.ToXY(crs)
+		gb.SrsName = srsname
 	}
+	gb.Envelope.LowerCorner = lower
+	gb.Envelope.UpperCorner = upper
 	return nil
 }
 
 // MarshalText build a KVP string of a GEOBBOX object
+// With a srsName the corners are written in the axis order of the srsName, a srsName that isn't a CRS has no
+// axis order so the corners are written in x y order. Validate reports such a srsName.
 func (gb *GEOBBOX) MarshalText() string {
 	regex := regexp.MustCompile(` `)
+	lower, upper := gb.Envelope.LowerCorner, gb.Envelope.UpperCorner
+	if crs, exception := gb.crs(); gb.SrsName != nil && exception == nil {
+		lower, upper = lower.ToAuthority(crs), upper.ToAuthority(crs)
+	}
 	var str string
-	if len(gb.Envelope.LowerCorner) >= 2 && len(gb.Envelope.UpperCorner) >= 2 && gb.Envelope.LowerCorner != gb.Envelope.UpperCorner {
-		str = fmt.Sprintf("%f,%f,%f,%f", gb.Envelope.LowerCorner[0], gb.Envelope.LowerCorner[1], gb.Envelope.UpperCorner[0], gb.Envelope.UpperCorner[1])
+	if len(lower) >= 2 && len(lower) == len(upper) && !lower.Equal(upper) {
+		bbox := ows.BoundingBox{LowerCorner: lower, UpperCorner: upper}
+		str = bbox.BuildKVP()
 	}
 	if len(str) > 0 && gb.SrsName != nil {
 		str = str + ` ` + *gb.SrsName
@@ -697,6 +756,18 @@ func (gb *GEOBBOX) MarshalText() string {
 	return regex.ReplaceAllString(str, `,`)
 }
 
+// crs returns the srsName of the GEOBBOX as CRS
+// An InvalidParameterValue with the srsName is returned when it isn't a CRS
+func (gb *GEOBBOX) crs() (ows.CRS, ows.Exception) {
+	var crs ows.CRS
+	if gb.SrsName != nil {
+		if exception := crs.ParseString(*gb.SrsName); exception != nil {
+			return ows.CRS{}, ows.InvalidParameterValue(*gb.SrsName, BBOX)
+		}
+	}
+	return crs, nil
+}
+
 // SortBy for Query
 type SortBy struct {
 	SortProperty *[]SortProperty `xml:"SortProperty" yaml:"sortproperty"`
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wfs200/capabilities"
 	"github.com/pdok/ogc-specifications/pkg/wfs200/exception"
 )
 
@@ -443,14 +444,19 @@ func TestUnmarshalTextGeoBOXX(t *testing.T) {
 		Exception ows.Exception
 	}{
 		0: {Query: "18.54,-72.3544,18.62,-72.2564", Expected: GEOBBOX{Envelope: Envelope{LowerCorner: ows.Position{18.54, -72.3544}, UpperCorner: ows.Position{18.62, -72.2564}}}},
-		1: {Query: "49.1874,-123.2778,49.3504,-122.8892,urn:ogc:def:crs:EPSG::4326", Expected: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4326"), Envelope: Envelope{LowerCorner: ows.Position{49.1874, -123.2778}, UpperCorner: ows.Position{49.3504, -122.8892}}}},
+		1: {Query: "49.1874,-123.2778,49.3504,-122.8892,urn:ogc:def:crs:EPSG::4326", Expected: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4326"), Envelope: Envelope{LowerCorner: ows.Position{-123.2778, 49.1874}, UpperCorner: ows.Position{-122.8892, 49.3504}}}},
 		2: {Query: "", Expected: GEOBBOX{}},
 		3: {Query: "18.54;-72.3544;18.62;-72.2564", Expected: GEOBBOX{}},
 		// Needs a beter solution
-		4: {Query: "error,-72.3544,18.62,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
-		5: {Query: "18.54,error,18.62,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
-		6: {Query: "18.54,-72.3544,error,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
-		7: {Query: "18.54,-72.3544,18.62,error", Exception: exception.InvalidValue(`BBOX`)},
+		4:  {Query: "error,-72.3544,18.62,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
+		5:  {Query: "18.54,error,18.62,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
+		6:  {Query: "18.54,-72.3544,error,-72.2564", Exception: exception.InvalidValue(`BBOX`)},
+		7:  {Query: "18.54,-72.3544,18.62,error", Exception: exception.InvalidValue(`BBOX`)},
+		8:  {Query: "120000,480000,121000,480500,urn:ogc:def:crs:EPSG::28992", Expected: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::28992"), Envelope: Envelope{LowerCorner: ows.Position{120000, 480000}, UpperCorner: ows.Position{121000, 480500}}}},
+		9:  {Query: "120000,480000,-10,121000,480500,50", Expected: GEOBBOX{Envelope: Envelope{LowerCorner: ows.Position{120000, 480000, -10}, UpperCorner: ows.Position{121000, 480500, 50}}}},
+		10: {Query: "52.1,5.3,-10,52.2,5.4,50,urn:ogc:def:crs:EPSG::4979", Expected: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4979"), Envelope: Envelope{LowerCorner: ows.Position{5.3, 52.1, -10}, UpperCorner: ows.Position{5.4, 52.2, 50}}}},
+		11: {Query: "120000,480000,-10,121000,480500,50,urn:ogc:def:crs:EPSG::28992", Exception: exception.InvalidValue(`BBOX`)},
+		12: {Query: "120000,480000,121000,480500,garbage", Exception: ows.InvalidParameterValue(`garbage`, `BBOX`)},
 	}
 
 	for k, a := range tests {
@@ -463,7 +469,7 @@ func TestUnmarshalTextGeoBOXX(t *testing.T) {
 			}
 		}
 
-		if gb.Envelope != a.Expected.Envelope {
+		if !gb.Envelope.LowerCorner.Equal(a.Expected.Envelope.LowerCorner) || !gb.Envelope.UpperCorner.Equal(a.Expected.Envelope.UpperCorner) {
 			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, a.Expected.Envelope, gb.Envelope)
 		}
 		if gb.SrsName != nil {
@@ -480,9 +486,11 @@ func TestMarshalTextGeoBOXX(t *testing.T) {
 		Expected string
 	}{
 		0: {Expected: "18.540000,-72.354400,18.620000,-72.256400", GeoBBox: GEOBBOX{Envelope: Envelope{LowerCorner: ows.Position{18.54, -72.3544}, UpperCorner: ows.Position{18.62, -72.2564}}}},
-		1: {Expected: "49.187400,-123.277800,49.350400,-122.889200,urn:ogc:def:crs:EPSG::4326", GeoBBox: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4326"), Envelope: Envelope{LowerCorner: ows.Position{49.1874, -123.2778}, UpperCorner: ows.Position{49.3504, -122.8892}}}},
+		1: {Expected: "49.187400,-123.277800,49.350400,-122.889200,urn:ogc:def:crs:EPSG::4326", GeoBBox: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4326"), Envelope: Envelope{LowerCorner: ows.Position{-123.2778, 49.1874}, UpperCorner: ows.Position{-122.8892, 49.3504}}}},
 		2: {Expected: "", GeoBBox: GEOBBOX{}},
 		3: {Expected: "", GeoBBox: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4326")}},
+		4: {Expected: "120000.000000,480000.000000,121000.000000,480500.000000,urn:ogc:def:crs:EPSG::28992", GeoBBox: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::28992"), Envelope: Envelope{LowerCorner: ows.Position{120000, 480000}, UpperCorner: ows.Position{121000, 480500}}}},
+		5: {Expected: "52.100000,5.300000,-10.000000,52.200000,5.400000,50.000000,urn:ogc:def:crs:EPSG::4979", GeoBBox: GEOBBOX{SrsName: sp("urn:ogc:def:crs:EPSG::4979"), Envelope: Envelope{LowerCorner: ows.Position{5.3, 52.1, -10}, UpperCorner: ows.Position{5.4, 52.2, 50}}}},
 	}
 
 	for k, a := range tests {
@@ -493,6 +501,50 @@ func TestMarshalTextGeoBOXX(t *testing.T) {
 	}
 }
 
+func TestGetFeatureValidate(t *testing.T) {
+	c := capabilities.Capabilities{
+		FeatureTypeList: capabilities.FeatureTypeList{FeatureType: []capabilities.FeatureType{
+			{Name: `ns:default`},
+			{Name: `ns:geojson`, OutputFormats: capabilities.OutputFormats{Format: []string{capabilities.OutputFormatGML32, capabilities.OutputFormatGeoJSON}}},
+		}},
+	}
+
+	var tests = []struct {
+		getfeature GetFeature
+		exceptions ows.Exceptions
+	}{
+		0: {getfeature: GetFeature{Query: Query{TypeNames: `ns:default`}}},
+		1: {getfeature: GetFeature{BaseGetFeatureRequest: BaseGetFeatureRequest{OutputFormat: sp(`application/gml+xml;version=3.2`)}, Query: Query{TypeNames: `ns:default,ns:geojson`}}},
+		2: {getfeature: GetFeature{BaseGetFeatureRequest: BaseGetFeatureRequest{OutputFormat: sp(`application/json`)}, Query: Query{TypeNames: `ns:geojson`}}},
+		3: {getfeature: GetFeature{BaseGetFeatureRequest: BaseGetFeatureRequest{OutputFormat: sp(`json`)}, Query: Query{TypeNames: `(ns:geojson)`}}},
+		4: {getfeature: GetFeature{BaseGetFeatureRequest: BaseGetFeatureRequest{OutputFormat: sp(`application/json`)}, Query: Query{TypeNames: `ns:default`}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`application/json`, OUTPUTFORMAT)}},
+		5: {getfeature: GetFeature{Query: Query{TypeNames: `ns:unknown`}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`ns:unknown`, TYPENAMES)}},
+		6: {getfeature: GetFeature{Query: Query{TypeNames: `ns:default`, Filter: &Filter{SpatialOperator: SpatialOperator{BBOX: &GEOBBOX{SrsName: sp(`garbage`)}}}}},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`garbage`, BBOX)}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.getfeature.Validate(&c)
+		if len(exceptions) != len(test.exceptions) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.exceptions, exceptions)
+			continue
+		}
+		for i := range exceptions {
+			if exceptions[i] != test.exceptions[i] {
+				t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.exceptions[i], exceptions[i])
+			}
+		}
+	}
+
+	// capabilities of another type result in an exception instead of a panic
+	expected := ows.NoApplicableCode(`The capabilities are not WFS 2.0.0 capabilities`)
+	if exceptions := tests[0].getfeature.Validate(nil); len(exceptions) != 1 || exceptions[0] != expected {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 7, expected, exceptions)
+	}
+}
+
 // ----------
 // Benchmarks
 // ----------
@@ -1,49 +1,404 @@
 package response
 
-import "encoding/xml"
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
 
-// Schema struct
-// TODO
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wfs200/capabilities"
+)
+
+// Namespaces and schema locations used in the DescribeFeatureType XSD
+const (
+	XSDNamespace      = ows.XSDNamespace
+	GMLNamespace      = ows.GML32Namespace
+	GMLSchemaLocation = `http://schemas.opengis.net/gml/3.2.1/gml.xsd`
+)
+
+// Unbounded can be used as PropertyType MaxOccurs for maxOccurs="unbounded"
+const Unbounded = -1
+
+// FeatureType describes a feature type of the application schema
+type FeatureType struct {
+	// Name is the local name of the FeatureType, for example 'building'
+	Name      string
+	Namespace string
+	Prefix    string
+	// SubstitutionGroup of the feature type element, defaults to gml:AbstractFeature
+	SubstitutionGroup string
+	Properties        []PropertyType
+}
+
+// PropertyType describes a property of a FeatureType.
+// The Type is a XSD type like xsd:string, when the GeometryType is set (Point, MultiPolygon, ...)
+// the Type is the GML 3.2 property type of that geometry.
+// A MaxOccurs of 0 results in the default of 1, use Unbounded for maxOccurs="unbounded".
+type PropertyType struct {
+	Name         string
+	Type         string
+	GeometryType string
+	Nillable     bool
+	MinOccurs    int
+	MaxOccurs    int
+}
+
+// TypeName returns the qualified name of the FeatureType, for example 'topp:states'
+func (ft *FeatureType) TypeName() string {
+	if ft.Prefix == `` {
+		return ft.Name
+	}
+	return ft.Prefix + `:` + ft.Name
+}
+
+// Property returns the PropertyType with the given name
+func (ft *FeatureType) Property(name string) (PropertyType, bool) {
+	for _, p := range ft.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return PropertyType{}, false
+}
+
+// GeometryProperties returns the geometry properties of the FeatureType
+func (ft *FeatureType) GeometryProperties() []PropertyType {
+	var geometries []PropertyType
+	for _, p := range ft.Properties {
+		if p.IsGeometry() {
+			geometries = append(geometries, p)
+		}
+	}
+	return geometries
+}
+
+// IsGeometry checks if the property is a GML geometry property
+func (pt *PropertyType) IsGeometry() bool {
+	if pt.GeometryType != `` {
+		return true
+	}
+	_, ok := gmlGeometryTypes[localType(pt.Type)]
+	return ok && strings.HasPrefix(pt.Type, `gml:`)
+}
+
+// xsdType returns the qualified XSD type of the property
+func (pt *PropertyType) xsdType() string {
+	if pt.GeometryType != `` {
+		return geometryPropertyTypes[pt.GeometryType]
+	}
+	if pt.Type == `` {
+		return `xsd:string`
+	}
+	if !strings.Contains(pt.Type, `:`) {
+		return `xsd:` + pt.Type
+	}
+	return pt.Type
+}
+
+// jsonType returns the type as used by the application/json DescribeFeatureType, for example xsd:int or gml:Point
+func (pt *PropertyType) jsonType() string {
+	if pt.GeometryType != `` {
+		return `gml:` + pt.GeometryType
+	}
+	return pt.xsdType()
+}
+
+func (pt *PropertyType) maxOccurs() string {
+	switch {
+	case pt.MaxOccurs == Unbounded:
+		return `unbounded`
+	case pt.MaxOccurs < 1:
+		return `1`
+	}
+	return strconv.Itoa(pt.MaxOccurs)
+}
+
+// geometryPropertyTypes maps the GeoJSON geometry types on the GML 3.2 property types
+var geometryPropertyTypes = map[string]string{
+	Point:           `gml:PointPropertyType`,
+	MultiPoint:      `gml:MultiPointPropertyType`,
+	LineString:      `gml:CurvePropertyType`,
+	MultiLineString: `gml:MultiCurvePropertyType`,
+	Polygon:         `gml:SurfacePropertyType`,
+	MultiPolygon:    `gml:MultiSurfacePropertyType`,
+}
+
+// gmlGeometryTypes maps the GML 3.2 (and the deprecated GML 3.1) geometry property types on
+// the GeoJSON geometry types, an empty geometry type is a geometry property of an unspecified type
+var gmlGeometryTypes = map[string]string{
+	`PointPropertyType`:              Point,
+	`MultiPointPropertyType`:         MultiPoint,
+	`CurvePropertyType`:              LineString,
+	`LineStringPropertyType`:         LineString,
+	`MultiCurvePropertyType`:         MultiLineString,
+	`MultiLineStringPropertyType`:    MultiLineString,
+	`SurfacePropertyType`:            Polygon,
+	`PolygonPropertyType`:            Polygon,
+	`MultiSurfacePropertyType`:       MultiPolygon,
+	`MultiPolygonPropertyType`:       MultiPolygon,
+	`GeometryPropertyType`:           ``,
+	`MultiGeometryPropertyType`:      ``,
+	`GeometricPrimitivePropertyType`: ``,
+}
+
+// DescribeFeatureType response containing the FeatureTypes that are described
+type DescribeFeatureType struct {
+	FeatureTypes []FeatureType
+}
+
+// Select returns a DescribeFeatureType with only the requested typenames in the order they are requested.
+// Without any typenames all FeatureTypes are described.
+// The typenames can be qualified (topp:states) or not (states).
+func (dft *DescribeFeatureType) Select(typenames ...string) (DescribeFeatureType, ows.Exceptions) {
+	if len(typenames) == 0 {
+		return DescribeFeatureType{FeatureTypes: dft.FeatureTypes}, nil
+	}
+
+	var selection DescribeFeatureType
+	var exceptions ows.Exceptions
+	for _, typename := range typenames {
+		ft, ok := dft.featureType(typename)
+		if !ok {
+			exceptions = append(exceptions, ows.InvalidParameterValue(typename, `TYPENAME`))
+			continue
+		}
+		selection.FeatureTypes = append(selection.FeatureTypes, ft)
+	}
+	if len(exceptions) > 0 {
+		return DescribeFeatureType{}, exceptions
+	}
+	return selection, nil
+}
+
+func (dft *DescribeFeatureType) featureType(typename string) (FeatureType, bool) {
+	for _, ft := range dft.FeatureTypes {
+		if ft.TypeName() == typename || ft.Name == typename {
+			return ft, true
+		}
+	}
+	return FeatureType{}, false
+}
+
+// Build builds the DescribeFeatureType response for the given outputformat,
+// this will be application/json or the default GML 3.2 application schema
+func (dft *DescribeFeatureType) Build(outputformat, endpoint string) []byte {
+	if capabilities.IsGeoJSON(outputformat) {
+		return dft.BuildJSON()
+	}
+	return dft.BuildXSD(endpoint)
+}
+
+// BuildXSD builds the GML 3.2 application schema of the FeatureTypes.
+// A XML schema only has a single targetNamespace, the FeatureTypes in other namespaces
+// are imported with a DescribeFeatureType request on the given endpoint for those FeatureTypes.
+func (dft *DescribeFeatureType) BuildXSD(endpoint string) []byte {
+	var namespaces []string
+	byNamespace := make(map[string][]FeatureType)
+	for _, ft := range dft.FeatureTypes {
+		if _, ok := byNamespace[ft.Namespace]; !ok {
+			namespaces = append(namespaces, ft.Namespace)
+		}
+		byNamespace[ft.Namespace] = append(byNamespace[ft.Namespace], ft)
+	}
+
+	schema := Schema{
+		// the gml and the feature type prefixes are only used in attribute values, so those are declared explicitly
+		Attr:               []xml.Attr{{Name: xml.Name{Local: `xmlns:gml`}, Value: GMLNamespace}},
+		ElementFormDefault: `qualified`,
+		Import:             []Import{{Namespace: GMLNamespace, SchemaLocation: GMLSchemaLocation}},
+	}
+
+	for i, namespace := range namespaces {
+		featuretypes := byNamespace[namespace]
+		prefix := featuretypes[0].Prefix
+		if i == 0 && prefix == `` && namespace != `` {
+			// the element type references the targetNamespace, so it needs a prefix
+			prefix = dft.generatePrefix()
+		}
+		if prefix != `` {
+			schema.Attr = append(schema.Attr, xml.Attr{Name: xml.Name{Local: `xmlns:` + prefix}, Value: namespace})
+		}
+
+		if i > 0 {
+			schema.Import = append(schema.Import, Import{Namespace: namespace, SchemaLocation: describeFeatureTypeURL(endpoint, featuretypes)})
+			continue
+		}
+
+		schema.TargetNamespace = namespace
+		for _, ft := range featuretypes {
+			schema.ComplexType = append(schema.ComplexType, ft.complexType())
+			schema.Element = append(schema.Element, Element{
+				Name:              ft.Name,
+				SubstitutionGroup: ft.substitutionGroup(),
+				Type:              qualify(prefix, ft.Name+`Type`),
+			})
+		}
+	}
+
+	si, _ := ows.CanonicalNamespaces.Marshal(schema, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// generatePrefix returns a prefix like ns1 that isn't used by any of the FeatureTypes
+func (dft *DescribeFeatureType) generatePrefix() string {
+	for n := 1; ; n++ {
+		prefix := `ns` + strconv.Itoa(n)
+		used := false
+		for _, ft := range dft.FeatureTypes {
+			if ft.Prefix == prefix {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return prefix
+		}
+	}
+}
+
+func (ft *FeatureType) complexType() ComplexType {
+	ct := ComplexType{Name: ft.Name + `Type`}
+	ct.ComplexContent.Extension.Base = `gml:AbstractFeatureType`
+	for _, p := range ft.Properties {
+		ct.ComplexContent.Extension.Sequence.Element = append(ct.ComplexContent.Extension.Sequence.Element, Element{
+			MaxOccurs: p.maxOccurs(),
+			MinOccurs: strconv.Itoa(p.MinOccurs),
+			Name:      p.Name,
+			Nillable:  strconv.FormatBool(p.Nillable),
+			Type:      p.xsdType(),
+		})
+	}
+	return ct
+}
+
+func (ft *FeatureType) substitutionGroup() string {
+	if ft.SubstitutionGroup == `` {
+		return `gml:AbstractFeature`
+	}
+	return ft.SubstitutionGroup
+}
+
+func qualify(prefix, name string) string {
+	if prefix == `` {
+		return name
+	}
+	return prefix + `:` + name
+}
+
+// describeFeatureTypeURL builds the DescribeFeatureType request used as schemaLocation for importing a namespace
+func describeFeatureTypeURL(endpoint string, featuretypes []FeatureType) string {
+	var typenames []string
+	for _, ft := range featuretypes {
+		typenames = append(typenames, ft.TypeName())
+	}
+
+	q := url.Values{}
+	q.Set(`SERVICE`, Service)
+	q.Set(`VERSION`, Version)
+	q.Set(`REQUEST`, `DescribeFeatureType`)
+	q.Set(`TYPENAME`, strings.Join(typenames, `,`))
+	if featuretypes[0].Prefix != `` {
+		q.Set(`NAMESPACES`, `xmlns(`+featuretypes[0].Prefix+`,`+featuretypes[0].Namespace+`)`)
+	}
+
+	if strings.Contains(endpoint, `?`) {
+		return endpoint + `&` + q.Encode()
+	}
+	return endpoint + `?` + q.Encode()
+}
+
+// BuildJSON builds the application/json DescribeFeatureType response like GeoServer does
+func (dft *DescribeFeatureType) BuildJSON() []byte {
+	doc := jsonSchema{ElementFormDefault: `qualified`, FeatureTypes: []jsonFeatureType{}}
+	if len(dft.FeatureTypes) > 0 {
+		doc.TargetNamespace = dft.FeatureTypes[0].Namespace
+		doc.TargetPrefix = dft.FeatureTypes[0].Prefix
+	}
+
+	for _, ft := range dft.FeatureTypes {
+		jft := jsonFeatureType{TypeName: ft.Name, Properties: []jsonPropertyType{}}
+		for _, p := range ft.Properties {
+			maxOccurs := p.MaxOccurs
+			if maxOccurs == 0 {
+				maxOccurs = 1
+			}
+			t := p.jsonType()
+			jft.Properties = append(jft.Properties, jsonPropertyType{
+				Name:      p.Name,
+				MaxOccurs: maxOccurs,
+				MinOccurs: p.MinOccurs,
+				Nillable:  p.Nillable,
+				Type:      t,
+				LocalType: localType(t),
+			})
+		}
+		doc.FeatureTypes = append(doc.FeatureTypes, jft)
+	}
+
+	b, _ := json.Marshal(doc)
+	return b
+}
+
+type jsonSchema struct {
+	ElementFormDefault string            `json:"elementFormDefault"`
+	TargetNamespace    string            `json:"targetNamespace"`
+	TargetPrefix       string            `json:"targetPrefix"`
+	FeatureTypes       []jsonFeatureType `json:"featureTypes"`
+}
+
+type jsonFeatureType struct {
+	TypeName   string             `json:"typeName"`
+	Properties []jsonPropertyType `json:"properties"`
+}
+
+// jsonPropertyType MaxOccurs is -1 for unbounded
+type jsonPropertyType struct {
+	Name      string `json:"name"`
+	MaxOccurs int    `json:"maxOccurs"`
+	MinOccurs int    `json:"minOccurs"`
+	Nillable  bool   `json:"nillable"`
+	Type      string `json:"type"`
+	LocalType string `json:"localType"`
+}
+
+// Schema struct for the XSD of a DescribeFeatureType response
 type Schema struct {
-	XMLName                     xml.Name `xml:"schema"`
-	Text                        string   `xml:",chardata"`
-	Xsd                         string   `xml:"xsd,attr"`
-	Digitaaltopografischbestand string   `xml:"digitaaltopografischbestand,attr"`
-	Gml                         string   `xml:"gml,attr"`
-	Wfs                         string   `xml:"wfs,attr"`
-	ElementFormDefault          string   `xml:"elementFormDefault,attr"`
-	TargetNamespace             string   `xml:"targetNamespace,attr"`
-	Import                      struct {
-		Text           string `xml:",chardata"`
-		Namespace      string `xml:"namespace,attr"`
-		SchemaLocation string `xml:"schemaLocation,attr"`
-	} `xml:"import"`
-	ComplexType []struct {
-		Text           string `xml:",chardata"`
-		Name           string `xml:"name,attr"`
-		ComplexContent struct {
-			Text      string `xml:",chardata"`
-			Extension struct {
-				Text     string `xml:",chardata"`
-				Base     string `xml:"base,attr"`
-				Sequence struct {
-					Text    string `xml:",chardata"`
-					Element []struct {
-						Text      string `xml:",chardata"`
-						MaxOccurs string `xml:"maxOccurs,attr"`
-						MinOccurs string `xml:"minOccurs,attr"`
-						Name      string `xml:"name,attr"`
-						Nillable  string `xml:"nillable,attr"`
-						Type      string `xml:"type,attr"`
-					} `xml:"element"`
-				} `xml:"sequence"`
-			} `xml:"extension"`
-		} `xml:"complexContent"`
-	} `xml:"complexType"`
-	Element []struct {
-		Text              string `xml:",chardata"`
-		Name              string `xml:"name,attr"`
-		SubstitutionGroup string `xml:"substitutionGroup,attr"`
-		Type              string `xml:"type,attr"`
-	} `xml:"element"`
+	XMLName            xml.Name      `xml:"http://www.w3.org/2001/XMLSchema schema"`
+	Attr               []xml.Attr    `xml:",attr"`
+	ElementFormDefault string        `xml:"elementFormDefault,attr"`
+	TargetNamespace    string        `xml:"targetNamespace,attr,omitempty"`
+	Import             []Import      `xml:"http://www.w3.org/2001/XMLSchema import"`
+	ComplexType        []ComplexType `xml:"http://www.w3.org/2001/XMLSchema complexType"`
+	Element            []Element     `xml:"http://www.w3.org/2001/XMLSchema element"`
+}
+
+// Import struct for the xsd:import
+type Import struct {
+	Namespace      string `xml:"namespace,attr"`
+	SchemaLocation string `xml:"schemaLocation,attr"`
+}
+
+// ComplexType struct for the feature type definition extending the gml:AbstractFeatureType
+type ComplexType struct {
+	Name           string `xml:"name,attr"`
+	ComplexContent struct {
+		Extension struct {
+			Base     string `xml:"base,attr"`
+			Sequence struct {
+				Element []Element `xml:"http://www.w3.org/2001/XMLSchema element"`
+			} `xml:"http://www.w3.org/2001/XMLSchema sequence"`
+		} `xml:"http://www.w3.org/2001/XMLSchema extension"`
+	} `xml:"http://www.w3.org/2001/XMLSchema complexContent"`
+}
+
+// Element struct for the xsd:element of the feature type and its properties
+type Element struct {
+	MaxOccurs         string `xml:"maxOccurs,attr,omitempty"`
+	MinOccurs         string `xml:"minOccurs,attr,omitempty"`
+	Name              string `xml:"name,attr"`
+	Nillable          string `xml:"nillable,attr,omitempty"`
+	SubstitutionGroup string `xml:"substitutionGroup,attr,omitempty"`
+	Type              string `xml:"type,attr"`
 }
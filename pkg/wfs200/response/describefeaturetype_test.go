@@ -0,0 +1,148 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+var states = FeatureType{Name: `states`, Namespace: `http://www.openplans.org/topp`, Prefix: `topp`,
+	Properties: []PropertyType{
+		{Name: `the_geom`, GeometryType: MultiPolygon, Nillable: true},
+		{Name: `STATE_NAME`, Type: `xsd:string`, Nillable: true},
+		{Name: `PERSONS`, Type: `double`, MinOccurs: 1},
+		{Name: `CITIES`, Type: `xsd:string`, MaxOccurs: Unbounded},
+	}}
+
+var roads = FeatureType{Name: `roads`, Namespace: `http://www.openplans.org/topp`, Prefix: `topp`,
+	Properties: []PropertyType{{Name: `the_geom`, GeometryType: LineString}}}
+
+var buildings = FeatureType{Name: `buildings`, Namespace: `http://www.acme.com/tiger`, Prefix: `tiger`,
+	Properties: []PropertyType{{Name: `location`, GeometryType: Point}}}
+
+func TestDescribeFeatureTypeSelect(t *testing.T) {
+	dft := DescribeFeatureType{FeatureTypes: []FeatureType{states, roads, buildings}}
+
+	var tests = []struct {
+		typenames  []string
+		result     []string
+		exceptions ows.Exceptions
+	}{
+		0: {result: []string{`topp:states`, `topp:roads`, `tiger:buildings`}},
+		1: {typenames: []string{`tiger:buildings`, `states`}, result: []string{`tiger:buildings`, `topp:states`}},
+		2: {typenames: []string{`topp:states`, `topp:unknown`}, exceptions: ows.Exceptions{ows.InvalidParameterValue(`topp:unknown`, `TYPENAME`)}},
+	}
+
+	for k, test := range tests {
+		selection, exceptions := dft.Select(test.typenames...)
+		if len(exceptions) != len(test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		for i := range exceptions {
+			if exceptions[i].Error() != test.exceptions[i].Error() || exceptions[i].Code() != test.exceptions[i].Code() {
+				t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions[i], exceptions[i])
+			}
+		}
+		if len(selection.FeatureTypes) != len(test.result) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.result, selection.FeatureTypes)
+			continue
+		}
+		for i, ft := range selection.FeatureTypes {
+			if ft.TypeName() != test.result[i] {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result[i], ft.TypeName())
+			}
+		}
+	}
+}
+
+func TestDescribeFeatureTypeBuildXSD(t *testing.T) {
+	var tests = []struct {
+		dft    DescribeFeatureType
+		result string
+	}{
+		0: {dft: DescribeFeatureType{FeatureTypes: []FeatureType{states}},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:topp="http://www.openplans.org/topp" elementFormDefault="qualified" targetNamespace="http://www.openplans.org/topp">
+ <xsd:import namespace="http://www.opengis.net/gml/3.2" schemaLocation="http://schemas.opengis.net/gml/3.2.1/gml.xsd"/>
+ <xsd:complexType name="statesType">
+  <xsd:complexContent>
+   <xsd:extension base="gml:AbstractFeatureType">
+    <xsd:sequence>
+     <xsd:element maxOccurs="1" minOccurs="0" name="the_geom" nillable="true" type="gml:MultiSurfacePropertyType"/>
+     <xsd:element maxOccurs="1" minOccurs="0" name="STATE_NAME" nillable="true" type="xsd:string"/>
+     <xsd:element maxOccurs="1" minOccurs="1" name="PERSONS" nillable="false" type="xsd:double"/>
+     <xsd:element maxOccurs="unbounded" minOccurs="0" name="CITIES" nillable="false" type="xsd:string"/>
+    </xsd:sequence>
+   </xsd:extension>
+  </xsd:complexContent>
+ </xsd:complexType>
+ <xsd:element name="states" substitutionGroup="gml:AbstractFeature" type="topp:statesType"/>
+</xsd:schema>`},
+		// FeatureTypes in other namespaces are imported
+		1: {dft: DescribeFeatureType{FeatureTypes: []FeatureType{roads, buildings}},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:topp="http://www.openplans.org/topp" xmlns:tiger="http://www.acme.com/tiger" elementFormDefault="qualified" targetNamespace="http://www.openplans.org/topp">
+ <xsd:import namespace="http://www.opengis.net/gml/3.2" schemaLocation="http://schemas.opengis.net/gml/3.2.1/gml.xsd"/>
+ <xsd:import namespace="http://www.acme.com/tiger" schemaLocation="http://localhost/wfs?NAMESPACES=xmlns%28tiger%2Chttp%3A%2F%2Fwww.acme.com%2Ftiger%29&amp;REQUEST=DescribeFeatureType&amp;SERVICE=WFS&amp;TYPENAME=tiger%3Abuildings&amp;VERSION=2.0.0"/>
+ <xsd:complexType name="roadsType">
+  <xsd:complexContent>
+   <xsd:extension base="gml:AbstractFeatureType">
+    <xsd:sequence>
+     <xsd:element maxOccurs="1" minOccurs="0" name="the_geom" nillable="false" type="gml:CurvePropertyType"/>
+    </xsd:sequence>
+   </xsd:extension>
+  </xsd:complexContent>
+ </xsd:complexType>
+ <xsd:element name="roads" substitutionGroup="gml:AbstractFeature" type="topp:roadsType"/>
+</xsd:schema>`},
+		// a prefix is generated for a namespace without one
+		2: {dft: DescribeFeatureType{FeatureTypes: []FeatureType{{Name: `parcels`, Namespace: `http://www.acme.com/cadastre`,
+			Properties: []PropertyType{{Name: `area`, Type: `double`}}}}},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:ns1="http://www.acme.com/cadastre" elementFormDefault="qualified" targetNamespace="http://www.acme.com/cadastre">
+ <xsd:import namespace="http://www.opengis.net/gml/3.2" schemaLocation="http://schemas.opengis.net/gml/3.2.1/gml.xsd"/>
+ <xsd:complexType name="parcelsType">
+  <xsd:complexContent>
+   <xsd:extension base="gml:AbstractFeatureType">
+    <xsd:sequence>
+     <xsd:element maxOccurs="1" minOccurs="0" name="area" nillable="false" type="xsd:double"/>
+    </xsd:sequence>
+   </xsd:extension>
+  </xsd:complexContent>
+ </xsd:complexType>
+ <xsd:element name="parcels" substitutionGroup="gml:AbstractFeature" type="ns1:parcelsType"/>
+</xsd:schema>`},
+	}
+
+	for k, test := range tests {
+		result := string(test.dft.Build(`application/gml+xml; version=3.2`, `http://localhost/wfs`))
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestDescribeFeatureTypeBuildJSON(t *testing.T) {
+	var tests = []struct {
+		dft    DescribeFeatureType
+		result string
+	}{
+		0: {dft: DescribeFeatureType{},
+			result: `{"elementFormDefault":"qualified","targetNamespace":"","targetPrefix":"","featureTypes":[]}`},
+		1: {dft: DescribeFeatureType{FeatureTypes: []FeatureType{states, buildings}},
+			result: `{"elementFormDefault":"qualified","targetNamespace":"http://www.openplans.org/topp","targetPrefix":"topp","featureTypes":[` +
+				`{"typeName":"states","properties":[{"name":"the_geom","maxOccurs":1,"minOccurs":0,"nillable":true,"type":"gml:MultiPolygon","localType":"MultiPolygon"},` +
+				`{"name":"STATE_NAME","maxOccurs":1,"minOccurs":0,"nillable":true,"type":"xsd:string","localType":"string"},` +
+				`{"name":"PERSONS","maxOccurs":1,"minOccurs":1,"nillable":false,"type":"xsd:double","localType":"double"},` +
+				`{"name":"CITIES","maxOccurs":-1,"minOccurs":0,"nillable":false,"type":"xsd:string","localType":"string"}]},` +
+				`{"typeName":"buildings","properties":[{"name":"location","maxOccurs":1,"minOccurs":0,"nillable":false,"type":"gml:Point","localType":"Point"}]}]}`},
+	}
+
+	for k, test := range tests {
+		result := string(test.dft.Build(`application/json`, ``))
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package response
 
 import (
 	"encoding/xml"
-	"regexp"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 	"github.com/pdok/ogc-specifications/pkg/wfs200/capabilities"
@@ -43,17 +42,16 @@ func (gc *GetCapabilities) Validate() ows.Exceptions {
 
 // BuildXML builds a GetCapabilities response object
 func (gc *GetCapabilities) BuildXML() []byte {
-	si, _ := xml.MarshalIndent(gc, "", "")
-	re := regexp.MustCompile(`><.*>`)
-	return []byte(xml.Header + re.ReplaceAllString(string(si), "/>"))
+	si, _ := ows.CanonicalNamespaces.Marshal(gc, "", " ")
+	return append([]byte(xml.Header), si...)
 }
 
 // GetCapabilities base struct
 type GetCapabilities struct {
-	XMLName               xml.Name `xml:"wfs:WFS_Capabilities"`
+	XMLName               xml.Name `xml:"http://www.opengis.net/wfs/2.0 WFS_Capabilities"`
 	Namespaces            `yaml:"namespaces"`
-	ServiceIdentification ServiceIdentification `xml:"ows:ServiceIdentification" yaml:"serviceidentification"`
-	ServiceProvider       ServiceProvider       `xml:"ows:ServiceProvider" yaml:"serviceprovider"`
+	ServiceIdentification ServiceIdentification `xml:"http://www.opengis.net/ows/1.1 ServiceIdentification" yaml:"serviceidentification"`
+	ServiceProvider       ServiceProvider       `xml:"http://www.opengis.net/ows/1.1 ServiceProvider" yaml:"serviceprovider"`
 	capabilities.Capabilities
 }
 
@@ -67,58 +65,58 @@ type Namespaces struct {
 	XmlnsFes           string `xml:"xmlns:fes,attr" yaml:"fes"`                                          //http://www.opengis.net/fes/2.0
 	XmlnsInspireCommon string `xml:"xmlns:inspire_common,attr,omitempty" yaml:"inspirecommon,omitempty"` //http://inspire.ec.europa.eu/schemas/common/1.0
 	XmlnsInspireDls    string `xml:"xmlns:inspire_dls,attr,omitempty" yaml:"inspiredls,omitempty"`       //http://inspire.ec.europa.eu/schemas/inspire_dls/1.0
-	XmlnsPrefix        string `xml:"xmlns:{{.Prefix}},attr" yaml:"prefix"`                               //namespace_uri placeholder
+	XmlnsPrefix        string `xml:"xmlns:{{.Prefix}},attr,omitempty" yaml:"prefix"`                     //namespace_uri placeholder
 	Version            string `xml:"version,attr" yaml:"version"`
-	SchemaLocation     string `xml:"xsi:schemaLocation,attr" yaml:"schemalocation"`
+	SchemaLocation     string `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr" yaml:"schemalocation"`
 }
 
 // ServiceIdentification struct should only be fill by the "template" configuration wfs200.yaml
 type ServiceIdentification struct {
-	XMLName     xml.Name      `xml:"ows:ServiceIdentification"`
-	Title       string        `xml:"ows:Title" yaml:"title"`
-	Abstract    string        `xml:"ows:Abstract" yaml:"abstract"`
-	Keywords    *ows.Keywords `xml:"ows:Keywords" yaml:"keywords"`
+	XMLName     xml.Name      `xml:"http://www.opengis.net/ows/1.1 ServiceIdentification"`
+	Title       string        `xml:"http://www.opengis.net/ows/1.1 Title" yaml:"title"`
+	Abstract    string        `xml:"http://www.opengis.net/ows/1.1 Abstract" yaml:"abstract"`
+	Keywords    *ows.Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords"`
 	ServiceType struct {
 		Text      string `xml:",chardata" yaml:"text"`
 		CodeSpace string `xml:"codeSpace,attr" yaml:"codespace"`
-	} `xml:"ows:ServiceType"`
-	ServiceTypeVersion string `xml:"ows:ServiceTypeVersion" yaml:"servicetypeversion"`
-	Fees               string `xml:"ows:Fees" yaml:"fees"`
-	AccessConstraints  string `xml:"ows:AccessConstraints" yaml:"accesscontraints"`
+	} `xml:"http://www.opengis.net/ows/1.1 ServiceType"`
+	ServiceTypeVersion string `xml:"http://www.opengis.net/ows/1.1 ServiceTypeVersion" yaml:"servicetypeversion"`
+	Fees               string `xml:"http://www.opengis.net/ows/1.1 Fees" yaml:"fees"`
+	AccessConstraints  string `xml:"http://www.opengis.net/ows/1.1 AccessConstraints" yaml:"accesscontraints"`
 }
 
 // ServiceProvider struct containing the provider/organization information should only be fill by the "template" configuration wfs200.yaml
 type ServiceProvider struct {
-	XMLName      xml.Name `xml:"ows:ServiceProvider"`
-	ProviderName string   `xml:"ows:ProviderName" yaml:"providername"`
+	XMLName      xml.Name `xml:"http://www.opengis.net/ows/1.1 ServiceProvider"`
+	ProviderName string   `xml:"http://www.opengis.net/ows/1.1 ProviderName" yaml:"providername"`
 	ProviderSite struct {
-		Type string `xml:"xlink:type,attr" yaml:"type"`
-		Href string `xml:"xlink:href,attr" yaml:"href"`
-	} `xml:"ows:ProviderSite" yaml:"providersite"`
+		Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+		Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	} `xml:"http://www.opengis.net/ows/1.1 ProviderSite" yaml:"providersite"`
 	ServiceContact struct {
-		IndividualName string `xml:"ows:IndividualName" yaml:"individualname"`
-		PositionName   string `xml:"ows:PositionName" yaml:"positionname"`
+		IndividualName string `xml:"http://www.opengis.net/ows/1.1 IndividualName" yaml:"individualname"`
+		PositionName   string `xml:"http://www.opengis.net/ows/1.1 PositionName" yaml:"positionname"`
 		ContactInfo    struct {
 			Text  string `xml:",chardata"`
 			Phone struct {
-				Voice     string `xml:"ows:Voice" yaml:"voice"`
-				Facsimile string `xml:"ows:Facsimile" yaml:"facsmile"`
-			} `xml:"ows:Phone" yaml:"phone"`
+				Voice     string `xml:"http://www.opengis.net/ows/1.1 Voice" yaml:"voice"`
+				Facsimile string `xml:"http://www.opengis.net/ows/1.1 Facsimile" yaml:"facsmile"`
+			} `xml:"http://www.opengis.net/ows/1.1 Phone" yaml:"phone"`
 			Address struct {
-				DeliveryPoint         string `xml:"ows:DeliveryPoint" yaml:"deliverypoint"`
-				City                  string `xml:"ows:City" yaml:"city"`
-				AdministrativeArea    string `xml:"ows:AdministrativeArea" yaml:"administrativearea"`
-				PostalCode            string `xml:"ows:PostalCode" yaml:"postalcode"`
-				Country               string `xml:"ows:Country" yaml:"country"`
-				ElectronicMailAddress string `xml:"ows:ElectronicMailAddress" yaml:"electronicmailaddress"`
-			} `xml:"ows:Address" yaml:"address"`
+				DeliveryPoint         string `xml:"http://www.opengis.net/ows/1.1 DeliveryPoint" yaml:"deliverypoint"`
+				City                  string `xml:"http://www.opengis.net/ows/1.1 City" yaml:"city"`
+				AdministrativeArea    string `xml:"http://www.opengis.net/ows/1.1 AdministrativeArea" yaml:"administrativearea"`
+				PostalCode            string `xml:"http://www.opengis.net/ows/1.1 PostalCode" yaml:"postalcode"`
+				Country               string `xml:"http://www.opengis.net/ows/1.1 Country" yaml:"country"`
+				ElectronicMailAddress string `xml:"http://www.opengis.net/ows/1.1 ElectronicMailAddress" yaml:"electronicmailaddress"`
+			} `xml:"http://www.opengis.net/ows/1.1 Address" yaml:"address"`
 			OnlineResource struct {
-				Type string `xml:"xlink:type,attr" yaml:"type"`
-				Href string `xml:"xlink:href,attr" yaml:"href"`
-			} `xml:"ows:OnlineResource" yaml:"onlineresource"`
-			HoursOfService      string `xml:"ows:HoursOfService" yaml:"hoursofservice"`
-			ContactInstructions string `xml:"ows:ContactInstructions" yaml:"contactinstructions"`
-		} `xml:"ows:ContactInfo" yaml:"contactinfo"`
-		Role string `xml:"ows:Role" yaml:"role"`
-	} `xml:"ows:ServiceContact" yaml:"servicecontact"`
+				Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+				Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+			} `xml:"http://www.opengis.net/ows/1.1 OnlineResource" yaml:"onlineresource"`
+			HoursOfService      string `xml:"http://www.opengis.net/ows/1.1 HoursOfService" yaml:"hoursofservice"`
+			ContactInstructions string `xml:"http://www.opengis.net/ows/1.1 ContactInstructions" yaml:"contactinstructions"`
+		} `xml:"http://www.opengis.net/ows/1.1 ContactInfo" yaml:"contactinfo"`
+		Role string `xml:"http://www.opengis.net/ows/1.1 Role" yaml:"role"`
+	} `xml:"http://www.opengis.net/ows/1.1 ServiceContact" yaml:"servicecontact"`
 }
@@ -0,0 +1,336 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// GetFeature KVP keys used for building the paging links
+const (
+	startindex = `STARTINDEX`
+	count      = `COUNT`
+)
+
+// GeoJSON geometry types
+const (
+	Point           = `Point`
+	MultiPoint      = `MultiPoint`
+	LineString      = `LineString`
+	MultiLineString = `MultiLineString`
+	Polygon         = `Polygon`
+	MultiPolygon    = `MultiPolygon`
+)
+
+// FeatureCollection contains the result of a GetFeature request
+type FeatureCollection struct {
+	// NumberMatched is nil when the number of matched features is 'unknown'
+	NumberMatched *int
+	TimeStamp     *time.Time
+	// SrsName as requested with the SRSNAME parameter, the geometries are expected
+	// in this CRS with the axis order of the CRS authority (like GML)
+	SrsName  *string
+	Links    []Link
+	Features []Feature
+}
+
+// NumberReturned is the number of features in the FeatureCollection
+func (fc *FeatureCollection) NumberReturned() int {
+	return len(fc.Features)
+}
+
+// Feature in struct for repeatability
+type Feature struct {
+	ID         string
+	Geometry   *Geometry
+	Properties []Property
+}
+
+// Property of a Feature with the XSD or GML type it has in the application schema,
+// for example xsd:string, xsd:int or gml:PointPropertyType.
+// A nil Value is a nilled property.
+type Property struct {
+	Name     string
+	Type     string
+	Value    *string
+	Geometry *Geometry
+}
+
+// Geometry in struct for repeatability
+// The depth of the Coordinates depends on the geometry Type:
+// Point: Coordinates[0][0][0]
+// LineString and MultiPoint: Coordinates[0][0]
+// Polygon and MultiLineString: Coordinates[0]
+// MultiPolygon: Coordinates
+type Geometry struct {
+	Type        string
+	Coordinates [][][]ows.Position
+}
+
+// Link used for the paging of a FeatureCollection
+type Link struct {
+	Href  string `json:"href"`
+	Rel   string `json:"rel"`
+	Type  string `json:"type,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// Paging adds the next and previous links based on the KVP of the original GetFeature request
+func (fc *FeatureCollection) Paging(endpoint string, query url.Values, outputformat string) {
+	index, size := 0, fc.NumberReturned()
+	for k, v := range query {
+		switch strings.ToUpper(k) {
+		case startindex:
+			if i, err := strconv.Atoi(v[0]); err == nil {
+				index = i
+			}
+		case count:
+			if i, err := strconv.Atoi(v[0]); err == nil {
+				size = i
+			}
+		}
+	}
+
+	if size <= 0 {
+		return
+	}
+
+	if index > 0 {
+		previous := index - size
+		if previous < 0 {
+			previous = 0
+		}
+		fc.Links = append(fc.Links, Link{Href: pageURL(endpoint, query, previous, size), Rel: `previous`, Type: outputformat, Title: `previous page`})
+	}
+
+	hasNext := fc.NumberReturned() >= size
+	if fc.NumberMatched != nil {
+		hasNext = index+size < *fc.NumberMatched
+	}
+	if hasNext {
+		fc.Links = append(fc.Links, Link{Href: pageURL(endpoint, query, index+size, size), Rel: `next`, Type: outputformat, Title: `next page`})
+	}
+}
+
+func pageURL(endpoint string, query url.Values, index, size int) string {
+	q := url.Values{}
+	for k, v := range query {
+		switch strings.ToUpper(k) {
+		case startindex, count:
+		default:
+			q[k] = v
+		}
+	}
+	q.Set(startindex, strconv.Itoa(index))
+	q.Set(count, strconv.Itoa(size))
+
+	if strings.Contains(endpoint, `?`) {
+		return endpoint + `&` + q.Encode()
+	}
+	return endpoint + `?` + q.Encode()
+}
+
+// BuildGeoJSON builds a GeoJSON FeatureCollection
+// RFC 7946 only allows WGS84 longitude/latitude coordinates, so for the (default) CRS84
+// and EPSG:4326 no crs member is added. For other CRSs the (pre RFC 7946) named crs member
+// is added, note that this isn't RFC 7946 compliant but only 'by prior arrangement'.
+// GeoJSON coordinates are always in x y order, so the coordinates of a CRS with a
+// northing easting axis order, like EPSG:4326 and EPSG:4258, are swapped.
+// A srsName that can't be parsed is used as the name of the crs member as is.
+func (fc *FeatureCollection) BuildGeoJSON() []byte {
+	var crs ows.CRS
+	var crsname string
+	if fc.SrsName != nil {
+		if err := crs.ParseString(*fc.SrsName); err != nil {
+			crsname = *fc.SrsName
+		} else if !isGeoJSONDefault(crs) {
+			crsname = crs.Identifier()
+		}
+	}
+	swap := crs.AxisOrder() == ows.NorthingEasting
+
+	doc := geojsonFeatureCollection{
+		Type:           `FeatureCollection`,
+		NumberMatched:  fc.NumberMatched,
+		NumberReturned: fc.NumberReturned(),
+		Links:          fc.Links,
+		Features:       []geojsonFeature{},
+	}
+	if fc.TimeStamp != nil {
+		doc.TimeStamp = fc.TimeStamp.UTC().Format(time.RFC3339)
+	}
+	if crsname != `` {
+		doc.CRS = &geojsonCRS{Type: `name`}
+		doc.CRS.Properties.Name = crsname
+	}
+
+	for _, f := range fc.Features {
+		feature := geojsonFeature{Type: `Feature`, ID: f.ID, Geometry: f.Geometry.geojson(swap)}
+		for _, p := range f.Properties {
+			feature.Properties = append(feature.Properties, geojsonProperty{name: p.Name, value: p.geojson(swap)})
+		}
+		doc.Features = append(doc.Features, feature)
+	}
+
+	b, _ := json.Marshal(doc)
+	return b
+}
+
+// geojson returns the JSON value of the property based on the XSD type
+func (p *Property) geojson(swap bool) interface{} {
+	if p.Geometry != nil {
+		return p.Geometry.geojson(swap)
+	}
+	if p.Value == nil {
+		return nil
+	}
+
+	v := *p.Value
+	switch localType(p.Type) {
+	case `boolean`:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case `int`, `integer`, `long`, `short`, `byte`,
+		`nonNegativeInteger`, `positiveInteger`, `nonPositiveInteger`, `negativeInteger`,
+		`unsignedLong`, `unsignedInt`, `unsignedShort`, `unsignedByte`:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	case `decimal`, `double`, `float`:
+		// NaN and INF aren't valid JSON numbers, those will be returned as a string
+		if f, err := strconv.ParseFloat(v, 64); err == nil && !strings.ContainsAny(strings.ToUpper(v), `NI`) {
+			return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	}
+	return v
+}
+
+// localType strips the namespace prefix from a type, xsd:int -> int
+func localType(t string) string {
+	if i := strings.LastIndex(t, `:`); i > -1 {
+		return t[i+1:]
+	}
+	return t
+}
+
+func (g *Geometry) geojson(swap bool) *geojsonGeometry {
+	if g == nil {
+		return nil
+	}
+
+	coordinates := make([][][][]float64, len(g.Coordinates))
+	for i, polygon := range g.Coordinates {
+		coordinates[i] = make([][][]float64, len(polygon))
+		for j, ring := range polygon {
+			coordinates[i][j] = make([][]float64, len(ring))
+			for k, p := range ring {
+				// all coordinates are kept, like the height, only the horizontal axes are swapped
+				c := append([]float64{}, p...)
+				if swap && len(c) >= 2 {
+					c[0], c[1] = c[1], c[0]
+				}
+				coordinates[i][j][k] = c
+			}
+		}
+	}
+
+	geometry := geojsonGeometry{Type: g.Type}
+	switch g.Type {
+	case Point:
+		if len(coordinates) > 0 && len(coordinates[0]) > 0 && len(coordinates[0][0]) > 0 {
+			geometry.Coordinates = coordinates[0][0][0]
+		}
+	case LineString, MultiPoint:
+		if len(coordinates) > 0 && len(coordinates[0]) > 0 {
+			geometry.Coordinates = coordinates[0][0]
+		}
+	case Polygon, MultiLineString:
+		if len(coordinates) > 0 {
+			geometry.Coordinates = coordinates[0]
+		}
+	default:
+		geometry.Coordinates = coordinates
+	}
+	return &geometry
+}
+
+// isGeoJSONDefault checks if the CRS is the GeoJSON default CRS, that is CRS84 or the equivalent EPSG:4326
+// An empty CRS is the default too
+func isGeoJSONDefault(crs ows.CRS) bool {
+	if crs.Namespace == `` {
+		return true
+	}
+	same, _ := crs.Equivalent(ows.CRS84)
+	return same
+}
+
+type geojsonFeatureCollection struct {
+	Type           string           `json:"type"`
+	NumberMatched  *int             `json:"numberMatched,omitempty"`
+	NumberReturned int              `json:"numberReturned"`
+	TimeStamp      string           `json:"timeStamp,omitempty"`
+	CRS            *geojsonCRS      `json:"crs,omitempty"`
+	Links          []Link           `json:"links,omitempty"`
+	Features       []geojsonFeature `json:"features"`
+}
+
+type geojsonCRS struct {
+	Type       string `json:"type"`
+	Properties struct {
+		Name string `json:"name"`
+	} `json:"properties"`
+}
+
+type geojsonFeature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Geometry   *geojsonGeometry  `json:"geometry"`
+	Properties geojsonProperties `json:"properties"`
+}
+
+type geojsonGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type geojsonProperty struct {
+	name  string
+	value interface{}
+}
+
+// geojsonProperties keeps the order of the properties from the application schema
+type geojsonProperties []geojsonProperty
+
+// MarshalJSON geojsonProperties
+func (gp geojsonProperties) MarshalJSON() ([]byte, error) {
+	if gp == nil {
+		return []byte(`null`), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, p := range gp {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(p.name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(p.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
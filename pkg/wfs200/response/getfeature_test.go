@@ -0,0 +1,111 @@
+package response
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+func sp(s string) *string {
+	return &s
+}
+
+func ip(i int) *int {
+	return &i
+}
+
+func TestFeatureCollectionBuildGeoJSON(t *testing.T) {
+	timestamp := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		fc     FeatureCollection
+		result string
+	}{
+		0: {fc: FeatureCollection{},
+			result: `{"type":"FeatureCollection","numberReturned":0,"features":[]}`},
+		// EPSG:4326 latitude/longitude is swapped to longitude/latitude
+		1: {fc: FeatureCollection{NumberMatched: ip(10), TimeStamp: &timestamp, SrsName: sp(`urn:ogc:def:crs:EPSG::4326`),
+			Features: []Feature{{ID: `building.1`, Geometry: &Geometry{Type: Point, Coordinates: [][][]ows.Position{{{{52.1, 5.2}}}}},
+				Properties: []Property{
+					{Name: `name`, Type: `xsd:string`, Value: sp(`Dom`)},
+					{Name: `height`, Type: `xsd:double`, Value: sp(`112.32`)},
+					{Name: `floors`, Type: `xs:int`, Value: sp(`12`)},
+					{Name: `public`, Type: `boolean`, Value: sp(`true`)},
+					{Name: `built`, Type: `xsd:date`, Value: sp(`1321-01-01`)},
+					{Name: `demolished`, Type: `xsd:date`},
+				}}}},
+			result: `{"type":"FeatureCollection","numberMatched":10,"numberReturned":1,"timeStamp":"2020-04-01T12:00:00Z","features":[{"type":"Feature","id":"building.1","geometry":{"type":"Point","coordinates":[5.2,52.1]},"properties":{"name":"Dom","height":112.32,"floors":12,"public":true,"built":"1321-01-01","demolished":null}}]}`},
+		// Non WGS84 CRS results in a named crs member
+		2: {fc: FeatureCollection{SrsName: sp(`urn:ogc:def:crs:EPSG::28992`),
+			Features: []Feature{{ID: `road.1`, Geometry: &Geometry{Type: LineString, Coordinates: [][][]ows.Position{{{{1, 2}, {3, 4}}}}},
+				Properties: []Property{{Name: `lanes`, Type: `xsd:int`, Value: sp(`two`)}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":1,"crs":{"type":"name","properties":{"name":"urn:ogc:def:crs:EPSG::28992"}},"features":[{"type":"Feature","id":"road.1","geometry":{"type":"LineString","coordinates":[[1,2],[3,4]]},"properties":{"lanes":"two"}}]}`},
+		// CRS84 is the GeoJSON default
+		3: {fc: FeatureCollection{SrsName: sp(`urn:ogc:def:crs:OGC:1.3:CRS84`),
+			Features: []Feature{{Geometry: &Geometry{Type: Polygon, Coordinates: [][][]ows.Position{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}}},
+				{Properties: []Property{{Name: `location`, Type: `gml:PointPropertyType`, Geometry: &Geometry{Type: Point, Coordinates: [][][]ows.Position{{{{5, 52}}}}}}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":2,"features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]},"properties":null},{"type":"Feature","geometry":null,"properties":{"location":{"type":"Point","coordinates":[5,52]}}}]}`},
+		// the height is kept, only the latitude/longitude is swapped
+		4: {fc: FeatureCollection{SrsName: sp(`urn:ogc:def:crs:EPSG::4326`),
+			Features: []Feature{{Geometry: &Geometry{Type: LineString, Coordinates: [][][]ows.Position{{{{52.1, 5.2, 10.5}, {52.2, 5.3, 12}}}}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":1,"features":[{"type":"Feature","geometry":{"type":"LineString","coordinates":[[5.2,52.1,10.5],[5.3,52.2,12]]},"properties":null}]}`},
+		5: {fc: FeatureCollection{SrsName: sp(`urn:ogc:def:crs:EPSG::7415`),
+			Features: []Feature{{Geometry: &Geometry{Type: Point, Coordinates: [][][]ows.Position{{{{155000, 463000, -4.5}}}}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":1,"crs":{"type":"name","properties":{"name":"urn:ogc:def:crs:EPSG::7415"}},"features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[155000,463000,-4.5]},"properties":null}]}`},
+		// ETRS89 is swapped to longitude/latitude, but isn't the GeoJSON default
+		6: {fc: FeatureCollection{SrsName: sp(`EPSG:4258`),
+			Features: []Feature{{Geometry: &Geometry{Type: Point, Coordinates: [][][]ows.Position{{{{52.1, 5.2}}}}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":1,"crs":{"type":"name","properties":{"name":"urn:ogc:def:crs:EPSG::4258"}},"features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[5.2,52.1]},"properties":null}]}`},
+		// a srsName that can't be parsed is kept as is
+		7: {fc: FeatureCollection{SrsName: sp(`unknown`),
+			Features: []Feature{{Geometry: &Geometry{Type: Point, Coordinates: [][][]ows.Position{{{{52.1, 5.2}}}}}}}},
+			result: `{"type":"FeatureCollection","numberReturned":1,"crs":{"type":"name","properties":{"name":"unknown"}},"features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[52.1,5.2]},"properties":null}]}`},
+	}
+
+	for k, test := range tests {
+		result := string(test.fc.BuildGeoJSON())
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestFeatureCollectionPaging(t *testing.T) {
+	features := func(n int) []Feature {
+		return make([]Feature, n)
+	}
+
+	var tests = []struct {
+		fc    FeatureCollection
+		query url.Values
+		links []Link
+	}{
+		0: {fc: FeatureCollection{Features: features(2)},
+			query: url.Values{`REQUEST`: {`GetFeature`}, `COUNT`: {`2`}},
+			links: []Link{{Href: `http://localhost/wfs?COUNT=2&REQUEST=GetFeature&STARTINDEX=2`, Rel: `next`, Type: `application/json`, Title: `next page`}}},
+		1: {fc: FeatureCollection{NumberMatched: ip(5), Features: features(1)},
+			query: url.Values{`REQUEST`: {`GetFeature`}, `count`: {`2`}, `startindex`: {`4`}},
+			links: []Link{{Href: `http://localhost/wfs?COUNT=2&REQUEST=GetFeature&STARTINDEX=2`, Rel: `previous`, Type: `application/json`, Title: `previous page`}}},
+		2: {fc: FeatureCollection{NumberMatched: ip(5), Features: features(2)},
+			query: url.Values{`REQUEST`: {`GetFeature`}, `COUNT`: {`2`}, `STARTINDEX`: {`1`}},
+			links: []Link{{Href: `http://localhost/wfs?COUNT=2&REQUEST=GetFeature&STARTINDEX=0`, Rel: `previous`, Type: `application/json`, Title: `previous page`},
+				{Href: `http://localhost/wfs?COUNT=2&REQUEST=GetFeature&STARTINDEX=3`, Rel: `next`, Type: `application/json`, Title: `next page`}}},
+		3: {fc: FeatureCollection{Features: features(1)},
+			query: url.Values{`REQUEST`: {`GetFeature`}, `COUNT`: {`2`}}},
+	}
+
+	for k, test := range tests {
+		test.fc.Paging(`http://localhost/wfs`, test.query, `application/json`)
+		if len(test.fc.Links) != len(test.links) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.links, test.fc.Links)
+			continue
+		}
+		for i := range test.links {
+			if test.fc.Links[i] != test.links[i] {
+				t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.links[i], test.fc.Links[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package response
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GML 3.1 namespace, used by the application schemas of WFS 1.1.0 services
+const gml31Namespace = `http://www.opengis.net/gml`
+
+// maximum depth for following type extensions and substitution groups, this guards against circular definitions
+const maxSchemaDepth = 32
+
+// ParseXML reads the FeatureTypes from an application schema, like the XSD of a DescribeFeatureType response.
+// FeatureTypes are the (non abstract) global elements that are in the substitutionGroup of gml:AbstractFeature
+// (or gml:_Feature for GML 3.1), directly or through other global elements, or that have a type that extends
+// the gml:AbstractFeatureType. Only the given document is read, included or imported schemas are not resolved.
+func (dft *DescribeFeatureType) ParseXML(doc []byte) error {
+	root, err := parseXSDNode(doc)
+	if err != nil {
+		return err
+	}
+	if root.name.Space != XSDNamespace || root.name.Local != `schema` {
+		return errors.New(`not a XML schema, expected element type <schema> but have <` + root.name.Local + `>`)
+	}
+
+	s := newXSDSchema(root)
+	var featuretypes []FeatureType
+	for _, el := range s.order {
+		if el.attr[`abstract`] == `true` || !s.isFeature(el, 0) {
+			continue
+		}
+		ft := FeatureType{Name: el.attr[`name`], Namespace: s.targetNamespace, Prefix: s.prefixes[s.targetNamespace]}
+		if sg, ok := el.attr[`substitutionGroup`]; ok {
+			ft.SubstitutionGroup = s.qualify(el.resolve(sg))
+		}
+		if ct := s.elementComplexType(el); ct != nil {
+			ft.Properties = s.properties(ct, 0)
+		}
+		featuretypes = append(featuretypes, ft)
+	}
+
+	dft.FeatureTypes = featuretypes
+	return nil
+}
+
+// xsdNode is a element of the XML schema with the namespace declarations in its scope,
+// those are needed for resolving the QName values of the type, base, ref and substitutionGroup attributes
+type xsdNode struct {
+	name     xml.Name
+	attr     map[string]string
+	xmlns    []xml.Attr
+	scope    map[string]string
+	children []*xsdNode
+}
+
+func parseXSDNode(doc []byte) (*xsdNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+
+	var root *xsdNode
+	var stack []*xsdNode
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := t.(type) {
+		case xml.StartElement:
+			n := &xsdNode{name: e.Name, attr: make(map[string]string), scope: make(map[string]string)}
+			if len(stack) > 0 {
+				for k, v := range stack[len(stack)-1].scope {
+					n.scope[k] = v
+				}
+			}
+			for _, a := range e.Attr {
+				switch {
+				case a.Name.Space == `xmlns`:
+					n.scope[a.Name.Local] = a.Value
+					n.xmlns = append(n.xmlns, a)
+				case a.Name.Space == `` && a.Name.Local == `xmlns`:
+					n.scope[``] = a.Value
+				case a.Name.Space == ``:
+					n.attr[a.Name.Local] = a.Value
+				}
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New(`no XML document`)
+	}
+	return root, nil
+}
+
+// resolve the QName with the namespace declarations in scope
+func (n *xsdNode) resolve(qname string) xml.Name {
+	qname = strings.TrimSpace(qname)
+	if i := strings.Index(qname, `:`); i > -1 {
+		return xml.Name{Space: n.scope[qname[:i]], Local: qname[i+1:]}
+	}
+	return xml.Name{Space: n.scope[``], Local: qname}
+}
+
+// is checks if the node is the given XML schema element
+func (n *xsdNode) is(local string) bool {
+	return n.name.Space == XSDNamespace && n.name.Local == local
+}
+
+// child returns the first XML schema child element with the given name
+func (n *xsdNode) child(local string) *xsdNode {
+	for _, c := range n.children {
+		if c.is(local) {
+			return c
+		}
+	}
+	return nil
+}
+
+type xsdSchema struct {
+	targetNamespace string
+	// prefixes contains the first declared prefix of a namespace
+	prefixes     map[string]string
+	elements     map[string]*xsdNode
+	complexTypes map[string]*xsdNode
+	simpleTypes  map[string]*xsdNode
+	// order of the global elements in the document
+	order []*xsdNode
+}
+
+func newXSDSchema(root *xsdNode) xsdSchema {
+	s := xsdSchema{
+		targetNamespace: root.attr[`targetNamespace`],
+		prefixes:        make(map[string]string),
+		elements:        make(map[string]*xsdNode),
+		complexTypes:    make(map[string]*xsdNode),
+		simpleTypes:     make(map[string]*xsdNode),
+	}
+	for _, a := range root.xmlns {
+		if _, ok := s.prefixes[a.Value]; !ok {
+			s.prefixes[a.Value] = a.Name.Local
+		}
+	}
+
+	for _, c := range root.children {
+		switch {
+		case c.is(`element`):
+			s.elements[c.attr[`name`]] = c
+			s.order = append(s.order, c)
+		case c.is(`complexType`):
+			s.complexTypes[c.attr[`name`]] = c
+		case c.is(`simpleType`):
+			s.simpleTypes[c.attr[`name`]] = c
+		}
+	}
+	return s
+}
+
+func isGMLNamespace(namespace string) bool {
+	return namespace == GMLNamespace || namespace == gml31Namespace
+}
+
+// qualify returns the QName with the prefix as used by this package, xsd and gml for
+// the XML schema and GML namespaces and the prefixes as declared in the schema for the others
+func (s *xsdSchema) qualify(name xml.Name) string {
+	switch {
+	case name.Space == XSDNamespace:
+		return `xsd:` + name.Local
+	case isGMLNamespace(name.Space):
+		return `gml:` + name.Local
+	}
+	return qualify(s.prefixes[name.Space], name.Local)
+}
+
+// local returns the global element or type node of the targetNamespace
+func (s *xsdSchema) local(nodes map[string]*xsdNode, name xml.Name) *xsdNode {
+	if name.Space != s.targetNamespace {
+		return nil
+	}
+	return nodes[name.Local]
+}
+
+func (s *xsdSchema) isFeature(el *xsdNode, depth int) bool {
+	if depth > maxSchemaDepth {
+		return false
+	}
+	if sg, ok := el.attr[`substitutionGroup`]; ok {
+		name := el.resolve(sg)
+		if isGMLNamespace(name.Space) && (name.Local == `AbstractFeature` || name.Local == `_Feature`) {
+			return true
+		}
+		if parent := s.local(s.elements, name); parent != nil && s.isFeature(parent, depth+1) {
+			return true
+		}
+	}
+	if ct := s.elementComplexType(el); ct != nil {
+		return s.extendsFeatureType(ct, 0)
+	}
+	return false
+}
+
+func (s *xsdSchema) extendsFeatureType(ct *xsdNode, depth int) bool {
+	if depth > maxSchemaDepth {
+		return false
+	}
+	cc := ct.child(`complexContent`)
+	if cc == nil {
+		return false
+	}
+	for _, c := range cc.children {
+		if !c.is(`extension`) && !c.is(`restriction`) {
+			continue
+		}
+		base := c.resolve(c.attr[`base`])
+		if isGMLNamespace(base.Space) && base.Local == `AbstractFeatureType` {
+			return true
+		}
+		if parent := s.local(s.complexTypes, base); parent != nil {
+			return s.extendsFeatureType(parent, depth+1)
+		}
+	}
+	return false
+}
+
+// elementComplexType returns the named or anonymous complexType of the element
+func (s *xsdSchema) elementComplexType(el *xsdNode) *xsdNode {
+	if t, ok := el.attr[`type`]; ok {
+		return s.local(s.complexTypes, el.resolve(t))
+	}
+	return el.child(`complexType`)
+}
+
+// properties of the complexType including those of the extended complexTypes of the targetNamespace
+func (s *xsdSchema) properties(ct *xsdNode, depth int) []PropertyType {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+
+	var properties []PropertyType
+	for _, c := range ct.children {
+		switch {
+		case c.is(`complexContent`):
+			for _, d := range c.children {
+				if !d.is(`extension`) && !d.is(`restriction`) {
+					continue
+				}
+				if parent := s.local(s.complexTypes, d.resolve(d.attr[`base`])); parent != nil && d.is(`extension`) {
+					properties = append(properties, s.properties(parent, depth+1)...)
+				}
+				properties = append(properties, s.particles(d, 0)...)
+			}
+		case c.is(`sequence`), c.is(`choice`), c.is(`all`):
+			properties = append(properties, s.particles(c, 0)...)
+		}
+	}
+	return properties
+}
+
+// particles returns the properties of the elements in the (nested) sequence, choice and all
+func (s *xsdSchema) particles(n *xsdNode, depth int) []PropertyType {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+
+	var properties []PropertyType
+	for _, c := range n.children {
+		switch {
+		case c.is(`element`):
+			properties = append(properties, s.property(c))
+		case c.is(`sequence`), c.is(`choice`), c.is(`all`):
+			properties = append(properties, s.particles(c, depth+1)...)
+		}
+	}
+	return properties
+}
+
+func (s *xsdSchema) property(el *xsdNode) PropertyType {
+	p := PropertyType{Name: el.attr[`name`], MinOccurs: 1, MaxOccurs: 1}
+
+	definition := el
+	if ref, ok := el.attr[`ref`]; ok {
+		name := el.resolve(ref)
+		p.Name = name.Local
+		if global := s.local(s.elements, name); global != nil {
+			definition = global
+		}
+	}
+
+	switch {
+	case definition.attr[`type`] != ``:
+		p.Type = s.typeName(definition.resolve(definition.attr[`type`]), 0)
+	case definition.child(`simpleType`) != nil:
+		p.Type = s.simpleTypeBase(definition.child(`simpleType`), 0)
+	default:
+		// an anonymous complexType or no type at all
+		p.Type = `xsd:anyType`
+	}
+	if strings.HasPrefix(p.Type, `gml:`) {
+		p.GeometryType = gmlGeometryTypes[localType(p.Type)]
+	}
+
+	nillable := strings.TrimSpace(definition.attr[`nillable`])
+	if n, ok := el.attr[`nillable`]; ok {
+		nillable = strings.TrimSpace(n)
+	}
+	p.Nillable = nillable == `true` || nillable == `1`
+
+	if min, err := strconv.Atoi(strings.TrimSpace(el.attr[`minOccurs`])); err == nil {
+		p.MinOccurs = min
+	}
+	if max := strings.TrimSpace(el.attr[`maxOccurs`]); max == `unbounded` {
+		p.MaxOccurs = Unbounded
+	} else if i, err := strconv.Atoi(max); err == nil {
+		p.MaxOccurs = i
+	}
+	return p
+}
+
+// typeName returns the qualified type, simpleTypes of the targetNamespace are resolved to their base type
+func (s *xsdSchema) typeName(name xml.Name, depth int) string {
+	if st := s.local(s.simpleTypes, name); st != nil && depth < maxSchemaDepth {
+		return s.simpleTypeBase(st, depth+1)
+	}
+	return s.qualify(name)
+}
+
+func (s *xsdSchema) simpleTypeBase(st *xsdNode, depth int) string {
+	r := st.child(`restriction`)
+	if r == nil {
+		// list and union types
+		return `xsd:string`
+	}
+	if base, ok := r.attr[`base`]; ok {
+		return s.typeName(r.resolve(base), depth)
+	}
+	if nested := r.child(`simpleType`); nested != nil && depth < maxSchemaDepth {
+		return s.simpleTypeBase(nested, depth+1)
+	}
+	return `xsd:string`
+}
@@ -0,0 +1,151 @@
+package response
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeFeatureTypeParseXML(t *testing.T) {
+	var tests = []struct {
+		doc          []byte
+		featuretypes []FeatureType
+		err          string
+	}{
+		// XSD as generated by BuildXSD
+		0: {doc: (&DescribeFeatureType{FeatureTypes: []FeatureType{roads}}).BuildXSD(``),
+			featuretypes: []FeatureType{{Name: `roads`, Namespace: `http://www.openplans.org/topp`, Prefix: `topp`, SubstitutionGroup: `gml:AbstractFeature`,
+				Properties: []PropertyType{{Name: `the_geom`, Type: `gml:CurvePropertyType`, GeometryType: LineString, MinOccurs: 0, MaxOccurs: 1}}}}},
+		// Other prefixes, substitutionGroup chains, named and anonymous types and simpleTypes
+		1: {doc: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:g="http://www.opengis.net/gml/3.2" xmlns:app="http://www.acme.com/app" targetNamespace="http://www.acme.com/app" elementFormDefault="qualified">
+  <xs:import namespace="http://www.opengis.net/gml/3.2" schemaLocation="http://schemas.opengis.net/gml/3.2.1/gml.xsd"/>
+  <xs:element name="AbstractConstruction" abstract="true" substitutionGroup="g:AbstractFeature" type="app:AbstractConstructionType"/>
+  <xs:complexType name="AbstractConstructionType" abstract="true">
+    <xs:complexContent>
+      <xs:extension base="g:AbstractFeatureType">
+        <xs:sequence>
+          <xs:element name="status" type="app:StatusType" minOccurs="0"/>
+        </xs:sequence>
+      </xs:extension>
+    </xs:complexContent>
+  </xs:complexType>
+  <xs:simpleType name="StatusType">
+    <xs:restriction base="xs:string">
+      <xs:enumeration value="planned"/>
+      <xs:enumeration value="built"/>
+    </xs:restriction>
+  </xs:simpleType>
+  <xs:element name="Building" substitutionGroup="app:AbstractConstruction">
+    <xs:complexType>
+      <xs:complexContent>
+        <xs:extension base="app:AbstractConstructionType">
+          <xs:sequence>
+            <xs:element name="height" nillable="true">
+              <xs:simpleType>
+                <xs:restriction base="xs:decimal"><xs:minInclusive value="0"/></xs:restriction>
+              </xs:simpleType>
+            </xs:element>
+            <xs:choice>
+              <xs:element name="footprint" type="g:MultiSurfacePropertyType"/>
+              <xs:element name="location" type="g:PointPropertyType"/>
+            </xs:choice>
+            <xs:element ref="app:address" minOccurs="0" maxOccurs="unbounded"/>
+            <xs:element name="geometry" type="g:GeometryPropertyType"/>
+          </xs:sequence>
+        </xs:extension>
+      </xs:complexContent>
+    </xs:complexType>
+  </xs:element>
+  <xs:element name="address" type="xs:string" nillable="true"/>
+  <xs:element name="Road" type="app:RoadType"/>
+  <xs:complexType name="RoadType">
+    <xs:complexContent>
+      <xs:extension base="app:AbstractConstructionType">
+        <xs:sequence>
+          <xs:element name="lanes" type="xs:int" maxOccurs="2"/>
+        </xs:sequence>
+      </xs:extension>
+    </xs:complexContent>
+  </xs:complexType>
+</xs:schema>`),
+			featuretypes: []FeatureType{
+				{Name: `Building`, Namespace: `http://www.acme.com/app`, Prefix: `app`, SubstitutionGroup: `app:AbstractConstruction`,
+					Properties: []PropertyType{
+						{Name: `status`, Type: `xsd:string`, MinOccurs: 0, MaxOccurs: 1},
+						{Name: `height`, Type: `xsd:decimal`, Nillable: true, MinOccurs: 1, MaxOccurs: 1},
+						{Name: `footprint`, Type: `gml:MultiSurfacePropertyType`, GeometryType: MultiPolygon, MinOccurs: 1, MaxOccurs: 1},
+						{Name: `location`, Type: `gml:PointPropertyType`, GeometryType: Point, MinOccurs: 1, MaxOccurs: 1},
+						{Name: `address`, Type: `xsd:string`, Nillable: true, MinOccurs: 0, MaxOccurs: Unbounded},
+						{Name: `geometry`, Type: `gml:GeometryPropertyType`, MinOccurs: 1, MaxOccurs: 1},
+					}},
+				{Name: `Road`, Namespace: `http://www.acme.com/app`, Prefix: `app`,
+					Properties: []PropertyType{
+						{Name: `status`, Type: `xsd:string`, MinOccurs: 0, MaxOccurs: 1},
+						{Name: `lanes`, Type: `xsd:int`, MinOccurs: 1, MaxOccurs: 2},
+					}},
+			}},
+		// GML 3.1 application schema
+		2: {doc: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:gml="http://www.opengis.net/gml" xmlns:ms="http://mapserver.gis.umn.edu/mapserver" targetNamespace="http://mapserver.gis.umn.edu/mapserver">
+  <element name="parcels" type="ms:parcelsType" substitutionGroup="gml:_Feature"/>
+  <complexType name="parcelsType">
+    <complexContent>
+      <extension base="gml:AbstractFeatureType">
+        <sequence>
+          <element name="msGeometry" type="gml:PolygonPropertyType" minOccurs="0" maxOccurs="1"/>
+          <element name="area" type="double"/>
+        </sequence>
+      </extension>
+    </complexContent>
+  </complexType>
+</schema>`),
+			featuretypes: []FeatureType{{Name: `parcels`, Namespace: `http://mapserver.gis.umn.edu/mapserver`, Prefix: `ms`, SubstitutionGroup: `gml:_Feature`,
+				Properties: []PropertyType{
+					{Name: `msGeometry`, Type: `gml:PolygonPropertyType`, GeometryType: Polygon, MinOccurs: 0, MaxOccurs: 1},
+					{Name: `area`, Type: `xsd:double`, MinOccurs: 1, MaxOccurs: 1},
+				}}}},
+		3: {doc: []byte(`<DescribeFeatureType service="WFS" version="2.0.0"/>`), err: `not a XML schema, expected element type <schema> but have <DescribeFeatureType>`},
+		4: {doc: []byte(`no XML document, just a string`), err: `no XML document`},
+	}
+
+	for k, test := range tests {
+		var dft DescribeFeatureType
+		err := dft.ParseXML(test.doc)
+		if err != nil {
+			if err.Error() != test.err {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, test.err, err)
+			}
+			continue
+		}
+		if test.err != `` {
+			t.Errorf("test: %d, expected: %s,\n got: no error", k, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(dft.FeatureTypes, test.featuretypes) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.featuretypes, dft.FeatureTypes)
+		}
+	}
+}
+
+func TestFeatureTypeGeometryProperties(t *testing.T) {
+	ft := FeatureType{Properties: []PropertyType{
+		{Name: `name`, Type: `xsd:string`},
+		{Name: `location`, GeometryType: Point},
+		{Name: `geometry`, Type: `gml:GeometryPropertyType`},
+		{Name: `identifier`, Type: `gml:CodeType`},
+	}}
+
+	var geometries []string
+	for _, p := range ft.GeometryProperties() {
+		geometries = append(geometries, p.Name)
+	}
+	if !reflect.DeepEqual(geometries, []string{`location`, `geometry`}) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, []string{`location`, `geometry`}, geometries)
+	}
+
+	if p, ok := ft.Property(`name`); !ok || p.Type != `xsd:string` {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 1, `name`, p)
+	}
+	if _, ok := ft.Property(`unknown`); ok {
+		t.Errorf("test: %d, expected: %s,\n got: %t", 2, `no property`, ok)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/transform"
 	"github.com/pdok/ogc-specifications/pkg/wms130/exception"
 	"gopkg.in/yaml.v2"
 )
@@ -37,7 +38,7 @@ type Capabilities struct {
 type WMSCapabilities struct {
 	Request              Request               `xml:"Request" yaml:"request"`
 	Exception            Exception             `xml:"Exception" yaml:"exception"`
-	ExtendedCapabilities *ExtendedCapabilities `xml:"inspire_vs:ExtendedCapabilities" yaml:"extendedcapabilities"`
+	ExtendedCapabilities *ExtendedCapabilities `xml:"http://inspire.ec.europa.eu/schemas/inspire_vs/1.0 ExtendedCapabilities" yaml:"extendedcapabilities"`
 	Layer                []Layer               `xml:"Layer" yaml:"layer"`
 }
 
@@ -235,18 +236,18 @@ type AuthorityURL struct {
 // ExtendedCapabilities containing the inspire extendedcapabilities, when available
 type ExtendedCapabilities struct {
 	MetadataURL struct {
-		Type      string `xml:"xsi:type,attr,omitempty" yaml:"type"`
-		URL       string `xml:"inspire_common:URL" yaml:"url"`
-		MediaType string `xml:"inspire_common:MediaType" yaml:"mediatype"`
-	} `xml:"inspire_common:MetadataUrl" yaml:"metadataurl"`
+		Type      string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr,omitempty" yaml:"type"`
+		URL       string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 URL" yaml:"url"`
+		MediaType string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MediaType" yaml:"mediatype"`
+	} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 MetadataUrl" yaml:"metadataurl"`
 	SupportedLanguages struct {
 		DefaultLanguage struct {
-			Language string `xml:"inspire_common:Language" yaml:"language"`
-		} `xml:"inspire_common:DefaultLanguage" yaml:"defaultlanguage"`
-	} `xml:"inspire_common:SupportedLanguages" yaml:"supportedlanguages"`
+			Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+		} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 DefaultLanguage" yaml:"defaultlanguage"`
+	} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 SupportedLanguages" yaml:"supportedlanguages"`
 	ResponseLanguage struct {
-		Language string `xml:"inspire_common:Language" yaml:"language"`
-	} `xml:"inspire_common:ResponseLanguage" yaml:"responselanguage"`
+		Language string `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 Language" yaml:"language"`
+	} `xml:"http://inspire.ec.europa.eu/schemas/common/1.0 ResponseLanguage" yaml:"responselanguage"`
 }
 
 // EXGeographicBoundingBox in struct for repeatability
@@ -266,6 +267,26 @@ type BoundingBox struct {
 	Maxy float64 `xml:"maxy,attr" yaml:"maxy"`
 }
 
+// EXGeographicBoundingBox computes the EX_GeographicBoundingBox from the BoundingBox
+// The minx, miny, maxx and maxy of the BoundingBox are in the axis order of its CRS
+func (b BoundingBox) EXGeographicBoundingBox() (EXGeographicBoundingBox, error) {
+	crs, exception := ows.ParseCRS(b.CRS)
+	if exception != nil {
+		return EXGeographicBoundingBox{}, exception
+	}
+	bbox := ows.BoundingBox{LowerCorner: ows.Position{b.Minx, b.Miny}, UpperCorner: ows.Position{b.Maxx, b.Maxy}}
+	geographic, err := transform.GeographicBoundingBox(bbox.ToXY(crs), crs)
+	if err != nil {
+		return EXGeographicBoundingBox{}, err
+	}
+	return EXGeographicBoundingBox{
+		WestBoundLongitude: geographic.LowerCorner[0],
+		EastBoundLongitude: geographic.UpperCorner[0],
+		SouthBoundLatitude: geographic.LowerCorner[1],
+		NorthBoundLatitude: geographic.UpperCorner[1],
+	}, nil
+}
+
 // Style in struct for repeatability
 type Style struct {
 	Name      string `xml:"Name" yaml:"name"`
@@ -294,6 +315,6 @@ type Method struct {
 // OnlineResource in struct for repeatability
 type OnlineResource struct {
 	Xlink *string `xml:"xmlns:xlink,attr" yaml:"xlink"`
-	Type  *string `xml:"xlink:type,attr" yaml:"type"`
-	Href  *string `xml:"xlink:href,attr" yaml:"href"`
+	Type  *string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+	Href  *string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
 }
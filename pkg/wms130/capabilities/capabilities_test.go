@@ -1,6 +1,7 @@
 package capabilities
 
 import (
+	"math"
 	"testing"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
@@ -93,3 +94,36 @@ func TestGetLayer(t *testing.T) {
 		}
 	}
 }
+
+func TestBoundingBoxEXGeographicBoundingBox(t *testing.T) {
+	var tests = []struct {
+		bbox     BoundingBox
+		expected EXGeographicBoundingBox
+		err      string
+	}{
+		0: {bbox: BoundingBox{CRS: `CRS:84`, Minx: 3.3, Miny: 50.7, Maxx: 7.3, Maxy: 53.6},
+			expected: EXGeographicBoundingBox{WestBoundLongitude: 3.3, EastBoundLongitude: 7.3, SouthBoundLatitude: 50.7, NorthBoundLatitude: 53.6}},
+		// EPSG:4326 is in latitude longitude order
+		1: {bbox: BoundingBox{CRS: `EPSG:4326`, Minx: 50.7, Miny: 3.3, Maxx: 53.6, Maxy: 7.3},
+			expected: EXGeographicBoundingBox{WestBoundLongitude: 3.3, EastBoundLongitude: 7.3, SouthBoundLatitude: 50.7, NorthBoundLatitude: 53.6}},
+		2: {bbox: BoundingBox{CRS: `EPSG:3857`, Minx: 0, Miny: 0, Maxx: 20037508.342789244, Maxy: 20037508.342789244},
+			expected: EXGeographicBoundingBox{WestBoundLongitude: 0, EastBoundLongitude: 180, SouthBoundLatitude: 0, NorthBoundLatitude: 85.0511287798066}},
+		3: {bbox: BoundingBox{CRS: `EPSG:2056`}, err: `CRS EPSG:2056 is not supported`},
+	}
+
+	for k, test := range tests {
+		exgeographic, err := test.bbox.EXGeographicBoundingBox()
+		if err != nil {
+			if err.Error() != test.err {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, test.err, err.Error())
+			}
+			continue
+		}
+		if math.Abs(exgeographic.WestBoundLongitude-test.expected.WestBoundLongitude) > 1e-6 ||
+			math.Abs(exgeographic.EastBoundLongitude-test.expected.EastBoundLongitude) > 1e-6 ||
+			math.Abs(exgeographic.SouthBoundLatitude-test.expected.SouthBoundLatitude) > 1e-6 ||
+			math.Abs(exgeographic.NorthBoundLatitude-test.expected.NorthBoundLatitude) > 1e-6 {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.expected, exgeographic)
+		}
+	}
+}
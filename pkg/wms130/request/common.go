@@ -1,7 +1,10 @@
 package request
 
 import (
+	"encoding/xml"
 	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 )
@@ -63,3 +66,46 @@ func (b *BaseRequest) Build(service, version string) ows.Exception {
 	}
 	return nil
 }
+
+// Dimensions with the TIME, ELEVATION or DIM_<name> key and their value
+// In XML every dimension is a Dimension element with the key as name attribute, like <Dimension name="TIME">2020-01-01</Dimension>
+type Dimensions map[string]string
+
+// dimension is the XML element of a single dimension
+type dimension struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalXML encodes the Dimensions as Dimension elements, sorted on the key
+func (d Dimensions) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var keys []string
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := e.EncodeElement(dimension{Name: k, Value: d[k]}, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalXML decodes a Dimension element into the Dimensions, elements without a TIME, ELEVATION or DIM_<name>
+// name are skipped like the other unknown parameters in KVP
+func (d *Dimensions) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var dim dimension
+	if err := dec.DecodeElement(&dim, &start); err != nil {
+		return err
+	}
+	key := strings.ToUpper(dim.Name)
+	if !isDimension(key) {
+		return nil
+	}
+	if *d == nil {
+		*d = make(Dimensions)
+	}
+	(*d)[key] = dim.Value
+	return nil
+}
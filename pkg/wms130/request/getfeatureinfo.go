@@ -92,7 +92,15 @@ func (gfi *GetFeatureInfo) ParseOperationRequestKVP(orkvp ows.OperationRequestKV
 	if err := bbox.ParseString(gfikvp.Bbox); err != nil {
 		return ows.Exceptions{err}
 	}
-	gfi.BoundingBox = bbox
+	var crs ows.CRS
+	if err := crs.ParseString(gfikvp.CRS); err != nil {
+		return ows.Exceptions{exception.InvalidCRS(gfikvp.CRS)}
+	}
+	if !bbox.LowerCorner.MatchesCRS(crs) {
+		return ows.Exceptions{ows.InvalidParameterValue(gfikvp.Bbox, BBOX)}
+	}
+	// the BBOX is given in the axis order of the CRS
+	gfi.BoundingBox = bbox.ToXY(crs)
 
 	gfi.CRS = gfikvp.CRS
 
@@ -130,6 +138,7 @@ func (gfi *GetFeatureInfo) ParseOperationRequestKVP(orkvp ows.OperationRequestKV
 	gfi.FeatureCount = &fc
 	gfi.InfoFormat = &gfikvp.InfoFormat
 	gfi.Exceptions = gfikvp.Exceptions
+	gfi.Dimensions = gfikvp.Dimensions
 
 	return nil
 }
@@ -188,10 +197,11 @@ type GetFeatureInfo struct {
 	Size   Size   `xml:"Size" yaml:"size"`
 	Format string `xml:"Format,omitempty" yaml:"format,omitempty"`
 
-	QueryLayers  []string `xml:"QueryLayers" yaml:"querylayers"`
-	I            int      `xml:"I" yaml:"i"`
-	J            int      `xml:"J" yaml:"j"`
-	InfoFormat   *string  `xml:"InfoFormat" yaml:"infoformat"`
-	FeatureCount *int     `xml:"FeatureCount,omitempty" yaml:"featurecount,omitempty"`
-	Exceptions   *string  `xml:"Exceptions" yaml:"exceptions"`
+	QueryLayers  []string   `xml:"QueryLayers" yaml:"querylayers"`
+	I            int        `xml:"I" yaml:"i"`
+	J            int        `xml:"J" yaml:"j"`
+	InfoFormat   *string    `xml:"InfoFormat" yaml:"infoformat"`
+	FeatureCount *int       `xml:"FeatureCount,omitempty" yaml:"featurecount,omitempty"`
+	Exceptions   *string    `xml:"Exceptions" yaml:"exceptions"`
+	Dimensions   Dimensions `xml:"Dimension,omitempty" yaml:"dimensions,omitempty"`
 }
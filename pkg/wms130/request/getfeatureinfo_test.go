@@ -36,8 +36,8 @@ func TestGetFeatureInfoBuildKVP(t *testing.T) {
 				}},
 			CRS: "EPSG:4326",
 			BoundingBox: ows.BoundingBox{
-				LowerCorner: [2]float64{-180.0, -90.0},
-				UpperCorner: [2]float64{180.0, 90.0},
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
 			},
 			Size:         Size{Width: 1024, Height: 512},
 			QueryLayers:  []string{`CenterLine`},
@@ -51,7 +51,7 @@ func TestGetFeatureInfoBuildKVP(t *testing.T) {
 				VERSION:      {Version},
 				SERVICE:      {Service},
 				REQUEST:      {`GetFeatureInfo`},
-				BBOX:         {`-180.000000,-90.000000,180.000000,90.000000`},
+				BBOX:         {`-90.000000,-180.000000,90.000000,180.000000`},
 				CRS:          {`EPSG:4326`},
 				LAYERS:       {`Rivers,Roads,Houses`},
 				STYLES:       {`CenterLine,CenterLine,Outline`},
@@ -106,8 +106,8 @@ func TestGetFeatureInfoBuildXML(t *testing.T) {
 				}},
 			CRS: "EPSG:4326",
 			BoundingBox: ows.BoundingBox{
-				LowerCorner: [2]float64{-180.0, -90.0},
-				UpperCorner: [2]float64{180.0, 90.0},
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
 			},
 			Size:        Size{Width: 1024, Height: 512},
 			QueryLayers: []string{`CenterLine`},
@@ -150,6 +150,46 @@ func TestGetFeatureInfoBuildXML(t *testing.T) {
  <I>1</I>
  <J>1</J>
  <InfoFormat>application/json</InfoFormat>
+</GetFeatureInfo>`},
+		1: {gfi: GetFeatureInfo{
+			XMLName: xml.Name{Local: `GetFeatureInfo`},
+			BaseRequest: BaseRequest{
+				Service: Service,
+				Version: Version},
+			StyledLayerDescriptor: StyledLayerDescriptor{
+				NamedLayer: []NamedLayer{{Name: "Rivers"}}},
+			CRS: "EPSG:4326",
+			BoundingBox: ows.BoundingBox{
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
+			},
+			Size:        Size{Width: 1024, Height: 512},
+			QueryLayers: []string{`Rivers`},
+			I:           1,
+			J:           1,
+			Dimensions:  Dimensions{TIME: `2020-01-01`, ELEVATION: `10`},
+		},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<GetFeatureInfo service="WMS" version="1.3.0">
+ <StyledLayerDescriptor version="">
+  <NamedLayer>
+   <Name>Rivers</Name>
+  </NamedLayer>
+ </StyledLayerDescriptor>
+ <CRS>EPSG:4326</CRS>
+ <BoundingBox>
+  <LowerCorner>-180.000000 -90.000000</LowerCorner>
+  <UpperCorner>180.000000 90.000000</UpperCorner>
+ </BoundingBox>
+ <Size>
+  <Width>1024</Width>
+  <Height>512</Height>
+ </Size>
+ <QueryLayers>Rivers</QueryLayers>
+ <I>1</I>
+ <J>1</J>
+ <Dimension name="ELEVATION">10</Dimension>
+ <Dimension name="TIME">2020-01-01</Dimension>
 </GetFeatureInfo>`},
 	}
 
@@ -177,7 +217,7 @@ func TestGetFeatureInfoParseKVP(t *testing.T) {
 			LAYERS:       {`Rivers,Roads,Houses`},
 			STYLES:       {`CenterLine,,Outline`},
 			CRS:          {`EPSG:4326`},
-			BBOX:         {`-180.0,-90.0,180.0,90.0`},
+			BBOX:         {`-90.0,-180.0,90.0,180.0`},
 			WIDTH:        {`1024`},
 			HEIGHT:       {`512`},
 			FORMAT:       {`image/jpeg`},
@@ -200,8 +240,8 @@ func TestGetFeatureInfoParseKVP(t *testing.T) {
 					}},
 				CRS: "EPSG:4326",
 				BoundingBox: ows.BoundingBox{
-					LowerCorner: [2]float64{-180.0, -90.0},
-					UpperCorner: [2]float64{180.0, 90.0},
+					LowerCorner: ows.Position{-180.0, -90.0},
+					UpperCorner: ows.Position{180.0, 90.0},
 				},
 				Size:         Size{Width: 1024, Height: 512},
 				Exceptions:   sp("XML"),
@@ -212,11 +252,12 @@ func TestGetFeatureInfoParseKVP(t *testing.T) {
 				InfoFormat:   sp(`application/json`),
 			},
 		},
-		3: {Query: map[string][]string{WIDTH: {`not a number`}, VERSION: {Version}, BBOX: {`-180.0,-90.0,180.0,90.0`}}, Exceptions: ows.Exceptions{ows.MissingParameterValue(WIDTH, `not a number`)}},
-		4: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`not a number`}, VERSION: {Version}, BBOX: {`-180.0,-90.0,180.0,90.0`}}, Exceptions: ows.Exceptions{ows.MissingParameterValue(HEIGHT, `not a number`)}},
-		5: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`not a number`}, J: {`1`}, VERSION: {Version}, BBOX: {`-180.0,-90.0,180.0,90.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`not a number`, `1`)}},
-		6: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`1`}, J: {`not a number`}, VERSION: {Version}, BBOX: {`-180.0,-90.0,180.0,90.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`1`, `not a number`)}},
-		7: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`this in not a number`}, J: {`this is also not a number`}, VERSION: {Version}, BBOX: {`-180.0,-90.0,180.0,90.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`this in not a number`, `this is also not a number`)}},
+		3: {Query: map[string][]string{WIDTH: {`not a number`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{ows.MissingParameterValue(WIDTH, `not a number`)}},
+		4: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`not a number`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{ows.MissingParameterValue(HEIGHT, `not a number`)}},
+		5: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`not a number`}, J: {`1`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`not a number`, `1`)}},
+		6: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`1`}, J: {`not a number`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`1`, `not a number`)}},
+		7: {Query: map[string][]string{WIDTH: {`1024`}, HEIGHT: {`1024`}, I: {`this in not a number`}, J: {`this is also not a number`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{exception.InvalidPoint(`this in not a number`, `this is also not a number`)}},
+		8: {Query: map[string][]string{CRS: {`garbage`}, VERSION: {Version}, BBOX: {`-90.0,-180.0,90.0,180.0`}}, Exceptions: ows.Exceptions{exception.InvalidCRS(`garbage`)}},
 	}
 
 	for k, test := range tests {
@@ -307,8 +348,8 @@ func TestGetFeatureInfoParseXML(t *testing.T) {
 				CRS: "EPSG:4326",
 				BoundingBox: ows.BoundingBox{
 					Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-					LowerCorner: [2]float64{-180.0, -90.0},
-					UpperCorner: [2]float64{180.0, 90.0},
+					LowerCorner: ows.Position{-180.0, -90.0},
+					UpperCorner: ows.Position{180.0, 90.0},
 				},
 				Size:       Size{Width: 1024, Height: 512},
 				Exceptions: sp("XML"),
@@ -372,7 +413,7 @@ func compareGetFeatureInfoObject(result, expected GetFeatureInfo, t *testing.T,
 	if expected.CRS != result.CRS {
 		t.Errorf("test CRS: %d, expected: %v ,\n got: %v", k, expected.CRS, result.CRS)
 	}
-	if expected.BoundingBox != result.BoundingBox {
+	if !expected.BoundingBox.Equal(result.BoundingBox) {
 		t.Errorf("test BoundingBox: %d, expected: %v ,\n got: %v", k, expected.BoundingBox, result.BoundingBox)
 	}
 	if expected.Size != result.Size {
@@ -438,8 +479,8 @@ func BenchmarkGetFeatureInfoBuildKVP(b *testing.B) {
 			}},
 		CRS: "EPSG:4326",
 		BoundingBox: ows.BoundingBox{
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Size:        Size{Width: 1024, Height: 512},
 		QueryLayers: []string{`CenterLine`},
@@ -466,8 +507,8 @@ func BenchmarkGetFeatureInfoBuildXML(b *testing.B) {
 			}},
 		CRS: "EPSG:4326",
 		BoundingBox: ows.BoundingBox{
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Size:        Size{Width: 1024, Height: 512},
 		QueryLayers: []string{`CenterLine`},
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wms130/exception"
 )
 
 //GetFeatureInfoKVP struct
@@ -28,8 +29,9 @@ type GetFeatureInfoKVPMandatory struct {
 
 // GetFeatureInfoKVPOptional struct containing the optional WMS request KVP
 type GetFeatureInfoKVPOptional struct {
-	FeatureCount *string `yaml:"feature_count,omitempty"`
-	Exceptions   *string `yaml:"exceptions,omitempty"`
+	FeatureCount *string           `yaml:"feature_count,omitempty"`
+	Exceptions   *string           `yaml:"exceptions,omitempty"`
+	Dimensions   map[string]string `yaml:"dimensions,omitempty"`
 }
 
 // ParseKVP builds a GetMapKVP object based on the available query parameters
@@ -74,6 +76,13 @@ func (gfikvp *GetFeatureInfoKVP) ParseKVP(query url.Values) ows.Exceptions {
 			case EXCEPTIONS:
 				vp := v[0]
 				gfikvp.GetFeatureInfoKVPOptional.Exceptions = &vp
+			default:
+				if key := strings.ToUpper(k); isDimension(key) {
+					if gfikvp.GetFeatureInfoKVPOptional.Dimensions == nil {
+						gfikvp.GetFeatureInfoKVPOptional.Dimensions = make(map[string]string)
+					}
+					gfikvp.GetFeatureInfoKVPOptional.Dimensions[key] = v[0]
+				}
 			}
 		}
 	}
@@ -114,6 +123,9 @@ func (gfikvp *GetFeatureInfoKVP) BuildKVP() url.Values {
 	if gfikvp.Exceptions != nil {
 		query[EXCEPTIONS] = []string{*gfikvp.Exceptions}
 	}
+	for k, v := range gfikvp.Dimensions {
+		query[k] = []string{v}
+	}
 
 	return query
 }
@@ -128,7 +140,12 @@ func (gfikvp *GetFeatureInfoKVP) ParseOperationRequest(or ows.OperationRequest)
 	gfikvp.Layers = gfi.StyledLayerDescriptor.getLayerKVPValue()
 	gfikvp.Styles = gfi.StyledLayerDescriptor.getStyleKVPValue()
 	gfikvp.CRS = gfi.CRS
-	gfikvp.Bbox = gfi.BoundingBox.BuildKVP()
+	var crs ows.CRS
+	if err := crs.ParseString(gfi.CRS); err != nil {
+		return ows.Exceptions{exception.InvalidCRS(gfi.CRS)}
+	}
+	bbox := gfi.BoundingBox.ToAuthority(crs)
+	gfikvp.Bbox = bbox.BuildKVP()
 	gfikvp.Width = strconv.Itoa(gfi.Size.Width)
 	gfikvp.Height = strconv.Itoa(gfi.Size.Height)
 
@@ -145,6 +162,7 @@ func (gfikvp *GetFeatureInfoKVP) ParseOperationRequest(or ows.OperationRequest)
 	}
 
 	gfikvp.Exceptions = gfi.Exceptions
+	gfikvp.Dimensions = gfi.Dimensions
 
 	return nil
 }
@@ -32,12 +32,20 @@ const (
 	TRANSPARENT = `TRANSPARENT`
 	BGCOLOR     = `BGCOLOR`
 	EXCEPTIONS  = `EXCEPTIONS` // defaults to XML
+)
 
-	// TODO: something with Time & Elevation
-	// TIME        = `TIME`
-	// ELEVATION   = `ELEVATION`
+// Dimension Keys
+const (
+	TIME      = `TIME`
+	ELEVATION = `ELEVATION`
+	DIMPREFIX = `DIM_` // prefix of the sample dimensions, like DIM_WAVELENGTH
 )
 
+// isDimension checks if the (uppercase) key is a TIME, ELEVATION or sample dimension parameter
+func isDimension(key string) bool {
+	return key == TIME || key == ELEVATION || (strings.HasPrefix(key, DIMPREFIX) && len(key) > len(DIMPREFIX))
+}
+
 // Type returns GetMap
 func (gm *GetMap) Type() string {
 	return getmap
@@ -89,14 +97,20 @@ func (gm *GetMap) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Ex
 	gm.StyledLayerDescriptor = sld
 
 	var crs ows.CRS
-	crs.ParseString(gmkvp.CRS)
+	if err := crs.ParseString(gmkvp.CRS); err != nil {
+		return ows.Exceptions{exception.InvalidCRS(gmkvp.CRS)}
+	}
 	gm.CRS = crs
 
 	var bbox ows.BoundingBox
 	if err := bbox.ParseString(gmkvp.Bbox); err != nil {
 		return ows.Exceptions{err}
 	}
-	gm.BoundingBox = bbox
+	if !bbox.LowerCorner.MatchesCRS(crs) {
+		return ows.Exceptions{ows.InvalidParameterValue(gmkvp.Bbox, BBOX)}
+	}
+	// the BBOX is given in the axis order of the CRS
+	gm.BoundingBox = bbox.ToXY(crs)
 
 	output, err := gmkvp.buildOutput()
 	if err != nil {
@@ -105,6 +119,7 @@ func (gm *GetMap) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Ex
 	gm.Output = output
 
 	gm.Exceptions = gmkvp.Exceptions
+	gm.Dimensions = gmkvp.Dimensions
 
 	return nil
 }
@@ -247,9 +262,7 @@ type GetMap struct {
 	BoundingBox           ows.BoundingBox       `xml:"BoundingBox" yaml:"boundingbox"`
 	Output                Output                `xml:"Output" yaml:"output"`
 	Exceptions            *string               `xml:"Exceptions" yaml:"exceptions"`
-	// TODO: something with Time & Elevation
-	// Elevation             *[]Elevation          `xml:"Elevation" yaml:"elevation"`
-	// Time                  *string               `xml:"Time" yaml:"time"`BuildKVP
+	Dimensions            Dimensions            `xml:"Dimension,omitempty" yaml:"dimensions,omitempty"`
 }
 
 // Validate validates the output parameters
@@ -3,6 +3,8 @@ package request
 import (
 	"encoding/xml"
 	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
@@ -179,11 +181,11 @@ func TestGetMapParseXML(t *testing.T) {
 						{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 						{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 					}},
-				CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+				CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 				BoundingBox: ows.BoundingBox{
 					Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-					LowerCorner: [2]float64{-180.0, -90.0},
-					UpperCorner: [2]float64{180.0, 90.0},
+					LowerCorner: ows.Position{-180.0, -90.0},
+					UpperCorner: ows.Position{180.0, 90.0},
 				},
 				Output: Output{
 					Size:        Size{Width: 1024, Height: 512},
@@ -280,12 +282,12 @@ func TestGetMapParseKVP(t *testing.T) {
 		},
 		1: {Query: url.Values{},
 			Exception: ows.MissingParameterValue(VERSION)},
-		//REQUEST=GetMap&SERVICE=WMS&VERSION=1.3.0&LAYERS=Rivers,Roads,Houses&STYLES=CenterLine,CenterLine,Outline&CRS=EPSG:4326&BBOX=-180.0,-90.0,180.0,90.0&WIDTH=1024&HEIGHT=512&FORMAT=image/jpeg&TRANSPARENT=FALSE&EXCEPTIONS=XML
+		//REQUEST=GetMap&SERVICE=WMS&VERSION=1.3.0&LAYERS=Rivers,Roads,Houses&STYLES=CenterLine,CenterLine,Outline&CRS=EPSG:4326&BBOX=-90.0,-180.0,90.0,180.0&WIDTH=1024&HEIGHT=512&FORMAT=image/jpeg&TRANSPARENT=FALSE&EXCEPTIONS=XML
 		2: {Query: map[string][]string{REQUEST: {getmap}, SERVICE: {Service}, VERSION: {Version},
 			LAYERS:      {`Rivers,Roads,Houses`},
 			STYLES:      {`CenterLine,CenterLine,Outline`},
 			CRS:         {`EPSG:4326`},
-			BBOX:        {`-180.0,-90.0,180.0,90.0`},
+			BBOX:        {`-90.0,-180.0,90.0,180.0`},
 			WIDTH:       {`1024`},
 			HEIGHT:      {`512`},
 			FORMAT:      {`image/jpeg`},
@@ -303,10 +305,10 @@ func TestGetMapParseKVP(t *testing.T) {
 						{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 						{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 					}},
-				CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+				CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 				BoundingBox: ows.BoundingBox{
-					LowerCorner: [2]float64{-180.0, -90.0},
-					UpperCorner: [2]float64{180.0, 90.0},
+					LowerCorner: ows.Position{-180.0, -90.0},
+					UpperCorner: ows.Position{180.0, 90.0},
 				},
 				Output: Output{
 					Size:        Size{Width: 1024, Height: 512},
@@ -315,6 +317,13 @@ func TestGetMapParseKVP(t *testing.T) {
 					BGcolor:     sp(`0x7F7F7F`)},
 				Exceptions: sp("XML"),
 			}},
+		// a BBOX with a height doesn't fit the 2D EPSG:4326
+		3: {Query: map[string][]string{REQUEST: {getmap}, SERVICE: {Service}, VERSION: {Version}, CRS: {`EPSG:4326`}, BBOX: {`-90.0,-180.0,0,90.0,180.0,10`}},
+			Exception: ows.InvalidParameterValue(`-90.0,-180.0,0,90.0,180.0,10`, BBOX),
+		},
+		4: {Query: map[string][]string{REQUEST: {getmap}, SERVICE: {Service}, VERSION: {Version}, CRS: {`garbage`}, BBOX: {`-90.0,-180.0,90.0,180.0`}},
+			Exception: exception.InvalidCRS(`garbage`),
+		},
 	}
 	for k, n := range tests {
 		var gm GetMap
@@ -347,10 +356,10 @@ func TestGetMapBuildKVP(t *testing.T) {
 					{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 					{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 				}},
-			CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+			CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 			BoundingBox: ows.BoundingBox{
-				LowerCorner: [2]float64{-180.0, -90.0},
-				UpperCorner: [2]float64{180.0, 90.0},
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
 			},
 			Output: Output{
 				Size:        Size{Width: 1024, Height: 512},
@@ -362,7 +371,7 @@ func TestGetMapBuildKVP(t *testing.T) {
 			LAYERS:      {`Rivers,Roads,Houses`},
 			STYLES:      {`CenterLine,CenterLine,Outline`},
 			CRS:         {`EPSG:4326`},
-			BBOX:        {`-180.000000,-90.000000,180.000000,90.000000`},
+			BBOX:        {`-90.000000,-180.000000,90.000000,180.000000`},
 			EXCEPTIONS:  {`XML`},
 			FORMAT:      {`image/jpeg`},
 			HEIGHT:      {`512`},
@@ -372,10 +381,10 @@ func TestGetMapBuildKVP(t *testing.T) {
 			SERVICE:     {`WMS`},
 		}},
 		1: {Object: GetMap{
-			CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+			CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 			BoundingBox: ows.BoundingBox{
-				LowerCorner: [2]float64{-180.0, -90.0},
-				UpperCorner: [2]float64{180.0, 90.0},
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
 			},
 			Exceptions: sp(`XML`),
 		},
@@ -383,7 +392,7 @@ func TestGetMapBuildKVP(t *testing.T) {
 				LAYERS:     {``},
 				STYLES:     {``},
 				CRS:        {`EPSG:4326`},
-				BBOX:       {`-180.000000,-90.000000,180.000000,90.000000`},
+				BBOX:       {`-90.000000,-180.000000,90.000000,180.000000`},
 				FORMAT:     {``},
 				HEIGHT:     {`0`},
 				WIDTH:      {`0`},
@@ -415,6 +424,44 @@ func TestGetMapBuildKVP(t *testing.T) {
 	}
 }
 
+func TestGetMapDimensions(t *testing.T) {
+	query := url.Values{REQUEST: {getmap}, SERVICE: {Service}, VERSION: {Version}, LAYERS: {`Rivers`}, STYLES: {``}, CRS: {`EPSG:4326`},
+		BBOX: {`-90.0,-180.0,90.0,180.0`}, WIDTH: {`1024`}, HEIGHT: {`512`}, FORMAT: {`image/jpeg`}, TRANSPARENT: {`FALSE`},
+		`time`: {`2020-01-01`}, `Dim_Wavelength`: {`1200`}, `DIM_`: {`empty`}, `UNKNOWN`: {`parameter`}}
+	expected := Dimensions{TIME: `2020-01-01`, `DIM_WAVELENGTH`: `1200`}
+
+	var gm GetMap
+	if exceptions := gm.ParseKVP(query); exceptions != nil {
+		t.Errorf("test: %d, expected no exceptions,\n got: %v", 0, exceptions)
+		return
+	}
+	if !reflect.DeepEqual(gm.Dimensions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 1, expected, gm.Dimensions)
+	}
+
+	result := gm.BuildKVP()
+	for k, v := range expected {
+		if result.Get(k) != v {
+			t.Errorf("test: %d, expected: %s=%s,\n got: %v", 2, k, v, result)
+		}
+	}
+
+	// the Dimensions survive a XML round trip
+	body := gm.BuildXML()
+	if !strings.Contains(string(body), `<Dimension name="DIM_WAVELENGTH">1200</Dimension>`) ||
+		!strings.Contains(string(body), `<Dimension name="TIME">2020-01-01</Dimension>`) {
+		t.Errorf("test: %d, expected Dimension elements,\n got: %s", 3, body)
+	}
+	var xmlgm GetMap
+	if exceptions := xmlgm.ParseXML(body); exceptions != nil {
+		t.Errorf("test: %d, expected no exceptions,\n got: %v", 4, exceptions)
+		return
+	}
+	if !reflect.DeepEqual(xmlgm.Dimensions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 5, expected, xmlgm.Dimensions)
+	}
+}
+
 func TestGetMapBuildXML(t *testing.T) {
 	var tests = []struct {
 		gm     GetMap
@@ -483,12 +530,12 @@ func TestGetNamedStyles(t *testing.T) {
 }
 
 func TestCheckCRS(t *testing.T) {
-	definedCrs := []ows.CRS{{Namespace: `CRS`, Code: 84}, {Namespace: `EPSG`, Code: 4326}, {Namespace: `EPSG`, Code: 3857}}
+	definedCrs := []ows.CRS{{Namespace: `CRS`, Code: `84`}, {Namespace: `EPSG`, Code: `4326`}, {Namespace: `EPSG`, Code: `3857`}}
 	var tests = []struct {
 		crs       ows.CRS
 		exception ows.Exception
 	}{
-		0: {crs: ows.CRS{Namespace: `CRS`, Code: 84}},
+		0: {crs: ows.CRS{Namespace: `CRS`, Code: `84`}},
 		1: {crs: ows.CRS{Namespace: `UNKNOWN`}, exception: exception.InvalidCRS(`UNKNOWN`)},
 	}
 
@@ -548,7 +595,7 @@ func compareGetMapObject(result, expected GetMap, t *testing.T, k int) {
 	if expected.CRS != result.CRS {
 		t.Errorf("test CRS: %d, expected: %v+ ,\n got: %v+", k, expected.CRS, result.CRS)
 	}
-	if expected.BoundingBox != result.BoundingBox {
+	if !expected.BoundingBox.Equal(result.BoundingBox) {
 		t.Errorf("test BoundingBox: %d, expected: %v+ ,\n got: %v+", k, expected.BoundingBox, result.BoundingBox)
 	}
 	if expected.Output.Size != result.Output.Size {
@@ -583,13 +630,13 @@ func TestGetMapValidate(t *testing.T) {
 				{
 					Queryable: ip(1),
 					Title:     `Rivers, Roads and Houses`,
-					CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+					CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 					Layer: []*capabilities.Layer{
 						{
 							Queryable: ip(1),
 							Name:      sp(`Rivers`),
 							Title:     `Rivers`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `CenterLine`,
@@ -600,7 +647,7 @@ func TestGetMapValidate(t *testing.T) {
 							Queryable: ip(1),
 							Name:      sp(`Roads`),
 							Title:     `Roads`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `CenterLine`,
@@ -611,7 +658,7 @@ func TestGetMapValidate(t *testing.T) {
 							Queryable: ip(1),
 							Name:      sp(`Houses`),
 							Title:     `Houses`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `Outline`,
@@ -648,11 +695,11 @@ func TestGetMapValidate(t *testing.T) {
 					{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 					{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 				}},
-			CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+			CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 			BoundingBox: ows.BoundingBox{
 				Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-				LowerCorner: [2]float64{-180.0, -90.0},
-				UpperCorner: [2]float64{180.0, 90.0},
+				LowerCorner: ows.Position{-180.0, -90.0},
+				UpperCorner: ows.Position{180.0, 90.0},
 			},
 			Output: Output{
 				Size:        Size{Width: 1024, Height: 512},
@@ -695,11 +742,11 @@ func BenchmarkGetMapBuildKVP(b *testing.B) {
 				{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 				{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 			}},
-		CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+		CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 		BoundingBox: ows.BoundingBox{
 			Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Output: Output{
 			Size:        Size{Width: 1024, Height: 512},
@@ -733,11 +780,11 @@ func BenchmarkGetMapBuildXML(b *testing.B) {
 				{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 				{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 			}},
-		CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+		CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 		BoundingBox: ows.BoundingBox{
 			Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Output: Output{
 			Size:        Size{Width: 1024, Height: 512},
@@ -755,7 +802,7 @@ func BenchmarkGetMapParseKVP(b *testing.B) {
 		LAYERS:      {`Rivers,Roads,Houses`},
 		STYLES:      {`CenterLine,CenterLine,Outline`},
 		CRS:         {`EPSG:4326`},
-		BBOX:        {`-180.0,-90.0,180.0,90.0`},
+		BBOX:        {`-90.0,-180.0,90.0,180.0`},
 		WIDTH:       {`1024`},
 		HEIGHT:      {`512`},
 		FORMAT:      {`image/jpeg`},
@@ -838,11 +885,11 @@ func BenchmarkGetMapValidate(b *testing.B) {
 				{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 				{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 			}},
-		CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+		CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 		BoundingBox: ows.BoundingBox{
 			Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Output: Output{
 			Size:        Size{Width: 1024, Height: 512},
@@ -869,13 +916,13 @@ func BenchmarkGetMapParseValidate(b *testing.B) {
 				{
 					Queryable: ip(1),
 					Title:     `Rivers, Roads and Houses`,
-					CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+					CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 					Layer: []*capabilities.Layer{
 						{
 							Queryable: ip(1),
 							Name:      sp(`Rivers`),
 							Title:     `Rivers`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `CenterLine`,
@@ -886,7 +933,7 @@ func BenchmarkGetMapParseValidate(b *testing.B) {
 							Queryable: ip(1),
 							Name:      sp(`Roads`),
 							Title:     `Roads`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `CenterLine`,
@@ -897,7 +944,7 @@ func BenchmarkGetMapParseValidate(b *testing.B) {
 							Queryable: ip(1),
 							Name:      sp(`Houses`),
 							Title:     `Houses`,
-							CRS:       []ows.CRS{{Code: 4326, Namespace: `EPSG`}},
+							CRS:       []ows.CRS{{Code: `4326`, Namespace: `EPSG`}},
 							Style: []*capabilities.Style{
 								{
 									Name: `Outline`,
@@ -930,11 +977,11 @@ func BenchmarkGetMapParseValidate(b *testing.B) {
 				{Name: "Roads", NamedStyle: &NamedStyle{Name: "CenterLine"}},
 				{Name: "Houses", NamedStyle: &NamedStyle{Name: "Outline"}},
 			}},
-		CRS: ows.CRS{Namespace: "EPSG", Code: 4326},
+		CRS: ows.CRS{Namespace: "EPSG", Code: `4326`},
 		BoundingBox: ows.BoundingBox{
 			Crs:         "http://www.opengis.net/gml/srs/epsg.xml#4326",
-			LowerCorner: [2]float64{-180.0, -90.0},
-			UpperCorner: [2]float64{180.0, 90.0},
+			LowerCorner: ows.Position{-180.0, -90.0},
+			UpperCorner: ows.Position{180.0, 90.0},
 		},
 		Output: Output{
 			Size:        Size{Width: 1024, Height: 512},
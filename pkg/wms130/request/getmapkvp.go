@@ -35,12 +35,10 @@ type GetMapKVPMandatory struct {
 
 // GetMapKVPOptional struct containing the optional WMS request KVP
 type GetMapKVPOptional struct {
-	Transparent *string `yaml:"transparent,omitempty"`
-	BGColor     *string `yaml:"bgcolor,omitempty"`
-	Exceptions  *string `yaml:"exceptions,omitempty"`
-	// TODO: something with Time & Elevation
-	// Time        *string `yaml:"time,omitempty"`
-	// Elevation   *string `yaml:"elevation,omitempty"`
+	Transparent *string           `yaml:"transparent,omitempty"`
+	BGColor     *string           `yaml:"bgcolor,omitempty"`
+	Exceptions  *string           `yaml:"exceptions,omitempty"`
+	Dimensions  map[string]string `yaml:"dimensions,omitempty"`
 }
 
 // ParseKVP builds a GetMapKVP object based on the available query parameters
@@ -80,6 +78,13 @@ func (gmkvp *GetMapKVP) ParseKVP(query url.Values) ows.Exceptions {
 			case EXCEPTIONS:
 				vp := v[0]
 				gmkvp.GetMapKVPOptional.Exceptions = &vp
+			default:
+				if key := strings.ToUpper(k); isDimension(key) {
+					if gmkvp.GetMapKVPOptional.Dimensions == nil {
+						gmkvp.GetMapKVPOptional.Dimensions = make(map[string]string)
+					}
+					gmkvp.GetMapKVPOptional.Dimensions[key] = v[0]
+				}
 			}
 		}
 	}
@@ -101,7 +106,8 @@ func (gmkvp *GetMapKVP) ParseOperationRequest(or ows.OperationRequest) ows.Excep
 	gmkvp.Layers = gm.StyledLayerDescriptor.getLayerKVPValue()
 	gmkvp.Styles = gm.StyledLayerDescriptor.getStyleKVPValue()
 	gmkvp.CRS = gm.CRS.String()
-	gmkvp.Bbox = gm.BoundingBox.BuildKVP()
+	bbox := gm.BoundingBox.ToAuthority(gm.CRS)
+	gmkvp.Bbox = bbox.BuildKVP()
 	gmkvp.Width = strconv.Itoa(gm.Output.Size.Width)
 	gmkvp.Height = strconv.Itoa(gm.Output.Size.Height)
 	gmkvp.Format = gm.Output.Format
@@ -116,10 +122,7 @@ func (gmkvp *GetMapKVP) ParseOperationRequest(or ows.OperationRequest) ows.Excep
 		gmkvp.BGColor = gm.Output.BGcolor
 	}
 
-	// TODO: something with Time & Elevation
-	// gmkvp.Time = gm.Time
-	// gmkvp.Elevation = gm.Elevation
-
+	gmkvp.Dimensions = gm.Dimensions
 	gmkvp.Exceptions = gm.Exceptions
 
 	return nil
@@ -190,6 +193,9 @@ func (gmkvp *GetMapKVP) BuildKVP() url.Values {
 	if gmkvp.Exceptions != nil {
 		query[EXCEPTIONS] = []string{*gmkvp.Exceptions}
 	}
+	for k, v := range gmkvp.Dimensions {
+		query[k] = []string{v}
+	}
 
 	return query
 }
@@ -2,7 +2,6 @@ package response
 
 import (
 	"encoding/xml"
-	"regexp"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 	"github.com/pdok/ogc-specifications/pkg/wms130/capabilities"
@@ -43,9 +42,8 @@ func (gc *GetCapabilities) Validate() ows.Exceptions {
 
 // BuildXML builds a GetCapabilities response object
 func (gc *GetCapabilities) BuildXML() []byte {
-	si, _ := xml.MarshalIndent(gc, "", "")
-	re := regexp.MustCompile(`><.*>`)
-	return []byte(xml.Header + re.ReplaceAllString(string(si), "/>"))
+	si, _ := ows.CanonicalNamespaces.Marshal(gc, "", " ")
+	return append([]byte(xml.Header), si...)
 }
 
 // GetCapabilities base struct
@@ -65,7 +63,7 @@ type Namespaces struct {
 	XmlnsInspireCommon string `xml:"xmlns:inspire_common,attr,omitempty" yaml:"inspirecommon,omitempty"` //http://inspire.ec.europa.eu/schemas/common/1.0
 	XmlnsInspireVs     string `xml:"xmlns:inspire_vs,attr,omitempty" yaml:"inspirevs,omitempty"`         //http://inspire.ec.europa.eu/schemas/inspire_vs/1.0
 	Version            string `xml:"version,attr" yaml:"version"`
-	SchemaLocation     string `xml:"xsi:schemaLocation,attr" yaml:"schemalocation"`
+	SchemaLocation     string `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr" yaml:"schemalocation"`
 }
 
 // WMSService struct containing the base service information filled from the template
@@ -76,8 +74,8 @@ type WMSService struct {
 	KeywordList    *ows.Keywords `xml:"KeywordList" yaml:"keywordlist"`
 	OnlineResource struct {
 		Xlink *string `xml:"xmlns:xlink,attr" yaml:"xlink"`
-		Type  *string `xml:"xlink:type,attr" yaml:"type"`
-		Href  *string `xml:"xlink:href,attr" yaml:"href"`
+		Type  *string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+		Href  *string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
 	} `xml:"OnlineResource" yaml:"onlineresource"`
 	ContactInformation struct {
 		ContactPersonPrimary struct {
@@ -1,19 +1,49 @@
 package capabilities
 
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"gopkg.in/yaml.v2"
+)
+
 // ParseXML func
+// The document can be a complete WMTS Capabilities document or only the Contents
 func (c *Contents) ParseXML(doc []byte) error {
+	var capabilities struct {
+		Contents *Contents `xml:"http://www.opengis.net/wmts/1.0 Contents"`
+	}
+	if err := xml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	if capabilities.Contents != nil {
+		*c = *capabilities.Contents
+		return nil
+	}
+
+	var contents Contents
+	if err := xml.Unmarshal(doc, &contents); err != nil {
+		return err
+	}
+	*c = contents
 	return nil
 }
 
 // ParseYAMl func
 func (c *Contents) ParseYAMl(doc []byte) error {
+	var contents Contents
+	if err := yaml.Unmarshal(doc, &contents); err != nil {
+		return err
+	}
+	*c = contents
 	return nil
 }
 
 // Contents struct for the WMTS 1.0.0
 type Contents struct {
-	Layer         []Layer         `xml:"Layer" yaml:"layer"`
-	TileMatrixSet []TileMatrixSet `xml:"TileMatrixSet" yaml:"tilematrixset"`
+	Layer         []Layer         `xml:"http://www.opengis.net/wmts/1.0 Layer" yaml:"layer"`
+	TileMatrixSet []TileMatrixSet `xml:"http://www.opengis.net/wmts/1.0 TileMatrixSet" yaml:"tilematrixset"`
 }
 
 // GetTilematrixsets helper function for collecting the provided TileMatrixSets, so th base can be cleanup for unused TileMatrixSets
@@ -27,46 +57,224 @@ func (c Contents) GetTilematrixsets() map[string]bool {
 	return tilematrixsets
 }
 
+// GetLayer returns the Layer with the given Identifier
+func (c Contents) GetLayer(identifier string) (Layer, bool) {
+	for _, l := range c.Layer {
+		if l.Identifier == identifier {
+			return l, true
+		}
+	}
+	return Layer{}, false
+}
+
+// GetTileMatrixSet returns the TileMatrixSet with the given Identifier
+func (c Contents) GetTileMatrixSet(identifier string) (TileMatrixSet, bool) {
+	for _, t := range c.TileMatrixSet {
+		if t.Identifier == identifier {
+			return t, true
+		}
+	}
+	return TileMatrixSet{}, false
+}
+
 // Layer in struct for repeatability
 type Layer struct {
-	Title            string `xml:"ows:Title" yaml:"title"`
-	Abstract         string `xml:"ows:Abstract" yaml:"abstract"`
-	WGS84BoundingBox struct {
-		LowerCorner string `xml:"ows:LowerCorner" yaml:"lowercorner"`
-		UpperCorner string `xml:"ows:UpperCorner" yaml:"uppercorner"`
-	} `xml:"ows:WGS84BoundingBox" yaml:"wgs84boundingbox"`
-	Identifier string `xml:"ows:Identifier" yaml:"identifier"`
-	Style      struct {
-		Identifier string `xml:"ows:Identifier" yaml:"identifier"`
-	} `xml:"Style" yaml:"style"`
-	Format            string              `xml:"Format" yaml:"format"`
-	TileMatrixSetLink []TileMatrixSetLink `xml:"TileMatrixSetLink" yaml:"tilematrixsetlink"`
-	ResourceURL       struct {
-		Format       string `xml:"format,attr" yaml:"format"`
-		ResourceType string `xml:"resourceType,attr" yaml:"resourcetype"`
-		Template     string `xml:"template,attr" yaml:"template"`
-	} `xml:"ResourceURL" yaml:"resourceurl"`
+	Title             string              `xml:"http://www.opengis.net/ows/1.1 Title" yaml:"title"`
+	Abstract          string              `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract"`
+	Keywords          *ows.Keywords       `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords,omitempty"`
+	WGS84BoundingBox  *ows.BoundingBox    `xml:"http://www.opengis.net/ows/1.1 WGS84BoundingBox" yaml:"wgs84boundingbox,omitempty"`
+	Identifier        string              `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	BoundingBox       []ows.BoundingBox   `xml:"http://www.opengis.net/ows/1.1 BoundingBox" yaml:"boundingbox,omitempty"`
+	Metadata          []Metadata          `xml:"http://www.opengis.net/ows/1.1 Metadata" yaml:"metadata,omitempty"`
+	Style             []Style             `xml:"http://www.opengis.net/wmts/1.0 Style" yaml:"style"`
+	Format            []string            `xml:"http://www.opengis.net/wmts/1.0 Format" yaml:"format"`
+	InfoFormat        []string            `xml:"http://www.opengis.net/wmts/1.0 InfoFormat" yaml:"infoformat,omitempty"`
+	Dimension         []Dimension         `xml:"http://www.opengis.net/wmts/1.0 Dimension" yaml:"dimension,omitempty"`
+	TileMatrixSetLink []TileMatrixSetLink `xml:"http://www.opengis.net/wmts/1.0 TileMatrixSetLink" yaml:"tilematrixsetlink"`
+	ResourceURL       []ResourceURL       `xml:"http://www.opengis.net/wmts/1.0 ResourceURL" yaml:"resourceurl,omitempty"`
+}
+
+// GetStyle returns the Style with the given Identifier
+func (l Layer) GetStyle(identifier string) (Style, bool) {
+	for _, s := range l.Style {
+		if s.Identifier == identifier {
+			return s, true
+		}
+	}
+	return Style{}, false
+}
+
+// DefaultStyle returns the Style marked as default, or the first Style when none is marked
+func (l Layer) DefaultStyle() (Style, bool) {
+	for _, s := range l.Style {
+		if s.IsDefault {
+			return s, true
+		}
+	}
+	if len(l.Style) > 0 {
+		return l.Style[0], true
+	}
+	return Style{}, false
+}
+
+// HasFormat checks if the Layer advertises the given Format
+func (l Layer) HasFormat(format string) bool {
+	for _, f := range l.Format {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// HasInfoFormat checks if the Layer advertises the given InfoFormat
+func (l Layer) HasInfoFormat(infoformat string) bool {
+	for _, f := range l.InfoFormat {
+		if f == infoformat {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDimension returns the Dimension with the given (case insensitive) Identifier
+func (l Layer) GetDimension(identifier string) (Dimension, bool) {
+	for _, d := range l.Dimension {
+		if strings.EqualFold(d.Identifier, identifier) {
+			return d, true
+		}
+	}
+	return Dimension{}, false
+}
+
+// HasTileMatrixSetLink checks if the Layer is linked to the given TileMatrixSet
+func (l Layer) HasTileMatrixSetLink(tilematrixset string) bool {
+	for _, t := range l.TileMatrixSetLink {
+		if t.TileMatrixSet == tilematrixset {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTileMatrixSetLink returns the TileMatrixSetLink to the given TileMatrixSet
+func (l Layer) GetTileMatrixSetLink(tilematrixset string) (TileMatrixSetLink, bool) {
+	for _, t := range l.TileMatrixSetLink {
+		if t.TileMatrixSet == tilematrixset {
+			return t, true
+		}
+	}
+	return TileMatrixSetLink{}, false
+}
+
+// Metadata contains a reference to metadata
+type Metadata struct {
+	Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+}
+
+// Style in struct for repeatability
+type Style struct {
+	Title      string        `xml:"http://www.opengis.net/ows/1.1 Title,omitempty" yaml:"title,omitempty"`
+	Abstract   string        `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Keywords   *ows.Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords,omitempty"`
+	Identifier string        `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	LegendURL  []LegendURL   `xml:"http://www.opengis.net/wmts/1.0 LegendURL" yaml:"legendurl,omitempty"`
+	IsDefault  bool          `xml:"isDefault,attr,omitempty" yaml:"isdefault,omitempty"`
+}
+
+// LegendURL describes the legend of a Style
+type LegendURL struct {
+	Format              string   `xml:"format,attr,omitempty" yaml:"format,omitempty"`
+	MinScaleDenominator *float64 `xml:"minScaleDenominator,attr,omitempty" yaml:"minscaledenominator,omitempty"`
+	MaxScaleDenominator *float64 `xml:"maxScaleDenominator,attr,omitempty" yaml:"maxscaledenominator,omitempty"`
+	Width               *int     `xml:"width,attr,omitempty" yaml:"width,omitempty"`
+	Height              *int     `xml:"height,attr,omitempty" yaml:"height,omitempty"`
+	Href                string   `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+}
+
+// Dimension describes an extra dimension, like TIME or ELEVATION, of a Layer
+type Dimension struct {
+	Title      string        `xml:"http://www.opengis.net/ows/1.1 Title,omitempty" yaml:"title,omitempty"`
+	Abstract   string        `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Keywords   *ows.Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords,omitempty"`
+	Identifier string        `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	UOM        string        `xml:"http://www.opengis.net/ows/1.1 UOM,omitempty" yaml:"uom,omitempty"`
+	UnitSymbol string        `xml:"http://www.opengis.net/wmts/1.0 UnitSymbol,omitempty" yaml:"unitsymbol,omitempty"`
+	Default    string        `xml:"http://www.opengis.net/wmts/1.0 Default,omitempty" yaml:"default,omitempty"`
+	Current    bool          `xml:"http://www.opengis.net/wmts/1.0 Current,omitempty" yaml:"current,omitempty"`
+	Value      []string      `xml:"http://www.opengis.net/wmts/1.0 Value" yaml:"value"`
+}
+
+// HasValue checks if the Dimension advertises the given value
+func (d Dimension) HasValue(value string) bool {
+	for _, v := range d.Value {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // TileMatrixSetLink in struct for repeatability
 type TileMatrixSetLink struct {
-	TileMatrixSet string `xml:"TileMatrixSet" yaml:"tilematrixset"`
+	TileMatrixSet       string               `xml:"http://www.opengis.net/wmts/1.0 TileMatrixSet" yaml:"tilematrixset"`
+	TileMatrixSetLimits *TileMatrixSetLimits `xml:"http://www.opengis.net/wmts/1.0 TileMatrixSetLimits" yaml:"tilematrixsetlimits,omitempty"`
+}
+
+// TileMatrixSetLimits limits the tiles of the TileMatrices that are available for a Layer
+type TileMatrixSetLimits struct {
+	TileMatrixLimits []TileMatrixLimits `xml:"http://www.opengis.net/wmts/1.0 TileMatrixLimits" yaml:"tilematrixlimits"`
+}
+
+// GetTileMatrixLimits returns the TileMatrixLimits of the given TileMatrix
+func (t TileMatrixSetLimits) GetTileMatrixLimits(tilematrix string) (TileMatrixLimits, bool) {
+	for _, l := range t.TileMatrixLimits {
+		if l.TileMatrix == tilematrix {
+			return l, true
+		}
+	}
+	return TileMatrixLimits{}, false
+}
+
+// TileMatrixLimits are the (inclusive) minimum and maximum row and column of a TileMatrix
+type TileMatrixLimits struct {
+	TileMatrix string `xml:"http://www.opengis.net/wmts/1.0 TileMatrix" yaml:"tilematrix"`
+	MinTileRow int    `xml:"http://www.opengis.net/wmts/1.0 MinTileRow" yaml:"mintilerow"`
+	MaxTileRow int    `xml:"http://www.opengis.net/wmts/1.0 MaxTileRow" yaml:"maxtilerow"`
+	MinTileCol int    `xml:"http://www.opengis.net/wmts/1.0 MinTileCol" yaml:"mintilecol"`
+	MaxTileCol int    `xml:"http://www.opengis.net/wmts/1.0 MaxTileCol" yaml:"maxtilecol"`
 }
 
 // TileMatrixSet in struct for repeatability
 type TileMatrixSet struct {
-	Identifier   string       `xml:"ows:Identifier" yaml:"identifier"`
-	SupportedCRS string       `xml:"ows:SupportedCRS" yaml:"supportedcrs"`
-	TileMatrix   []TileMatrix `xml:"TileMatrix" yaml:"tilematrix"`
+	Title             string           `xml:"http://www.opengis.net/ows/1.1 Title,omitempty" yaml:"title,omitempty"`
+	Abstract          string           `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Identifier        string           `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	BoundingBox       *ows.BoundingBox `xml:"http://www.opengis.net/ows/1.1 BoundingBox" yaml:"boundingbox,omitempty"`
+	SupportedCRS      string           `xml:"http://www.opengis.net/ows/1.1 SupportedCRS" yaml:"supportedcrs"`
+	WellKnownScaleSet string           `xml:"http://www.opengis.net/wmts/1.0 WellKnownScaleSet,omitempty" yaml:"wellknownscaleset,omitempty"`
+	TileMatrix        []TileMatrix     `xml:"http://www.opengis.net/wmts/1.0 TileMatrix" yaml:"tilematrix"`
+}
+
+// GetTileMatrix returns the TileMatrix with the given Identifier
+func (t TileMatrixSet) GetTileMatrix(identifier string) (TileMatrix, bool) {
+	for _, tm := range t.TileMatrix {
+		if tm.Identifier == identifier {
+			return tm, true
+		}
+	}
+	return TileMatrix{}, false
 }
 
 // TileMatrix in struct for repeatability
 type TileMatrix struct {
-	Identifier       string `xml:"ows:Identifier" yaml:"identifier"`
-	ScaleDenominator string `xml:"ScaleDenominator" yaml:"scaledenominator"`
-	TopLeftCorner    string `xml:"TopLeftCorner" yaml:"topleftcorner"`
-	TileWidth        string `xml:"TileWidth" yaml:"tilewidth"`
-	TileHeight       string `xml:"TileHeight" yaml:"tileheight"`
-	MatrixWidth      string `xml:"MatrixWidth" yaml:"matrixwidth"`
-	MatrixHeight     string `xml:"MatrixHeight" yaml:"matrixheight"`
+	Title            string       `xml:"http://www.opengis.net/ows/1.1 Title,omitempty" yaml:"title,omitempty"`
+	Abstract         string       `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Identifier       string       `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	ScaleDenominator float64      `xml:"http://www.opengis.net/wmts/1.0 ScaleDenominator" yaml:"scaledenominator"`
+	TopLeftCorner    ows.Position `xml:"http://www.opengis.net/wmts/1.0 TopLeftCorner" yaml:"topleftcorner"`
+	TileWidth        int          `xml:"http://www.opengis.net/wmts/1.0 TileWidth" yaml:"tilewidth"`
+	TileHeight       int          `xml:"http://www.opengis.net/wmts/1.0 TileHeight" yaml:"tileheight"`
+	MatrixWidth      int          `xml:"http://www.opengis.net/wmts/1.0 MatrixWidth" yaml:"matrixwidth"`
+	MatrixHeight     int          `xml:"http://www.opengis.net/wmts/1.0 MatrixHeight" yaml:"matrixheight"`
 }
@@ -0,0 +1,243 @@
+package capabilities
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+var pdokCapabilities = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Capabilities xmlns="http://www.opengis.net/wmts/1.0" xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:xlink="http://www.w3.org/1999/xlink" version="1.0.0">
+  <ows:ServiceIdentification>
+    <ows:Title>Web Map Tile Service - PDOK</ows:Title>
+    <ows:ServiceType>OGC WMTS</ows:ServiceType>
+    <ows:ServiceTypeVersion>1.0.0</ows:ServiceTypeVersion>
+  </ows:ServiceIdentification>
+  <Contents>
+    <Layer>
+      <ows:Title>Luchtfoto</ows:Title>
+      <ows:Abstract>Luchtfoto van Nederland</ows:Abstract>
+      <ows:Keywords>
+        <ows:Keyword>luchtfoto</ows:Keyword>
+        <ows:Keyword>ortho</ows:Keyword>
+      </ows:Keywords>
+      <ows:WGS84BoundingBox>
+        <ows:LowerCorner>3.3 50.7</ows:LowerCorner>
+        <ows:UpperCorner>7.3 53.6</ows:UpperCorner>
+      </ows:WGS84BoundingBox>
+      <ows:Identifier>luchtfoto</ows:Identifier>
+      <ows:Metadata xlink:href="https://www.nationaalgeoregister.nl/geonetwork/srv/dut/csw?service=CSW&amp;request=GetRecordById"/>
+      <Style isDefault="true">
+        <ows:Title>Default</ows:Title>
+        <ows:Identifier>default</ows:Identifier>
+        <LegendURL format="image/png" width="100" height="20" xlink:href="https://service.pdok.nl/luchtfoto/legend.png"/>
+      </Style>
+      <Style>
+        <ows:Identifier>infrarood</ows:Identifier>
+      </Style>
+      <Format>image/jpeg</Format>
+      <Format>image/png</Format>
+      <InfoFormat>application/json</InfoFormat>
+      <Dimension>
+        <ows:Identifier>TIME</ows:Identifier>
+        <ows:UOM>ISO8601</ows:UOM>
+        <Default>2020</Default>
+        <Current>true</Current>
+        <Value>2019</Value>
+        <Value>2020</Value>
+      </Dimension>
+      <TileMatrixSetLink>
+        <TileMatrixSet>EPSG:28992</TileMatrixSet>
+        <TileMatrixSetLimits>
+          <TileMatrixLimits>
+            <TileMatrix>01</TileMatrix>
+            <MinTileRow>0</MinTileRow>
+            <MaxTileRow>1</MaxTileRow>
+            <MinTileCol>0</MinTileCol>
+            <MaxTileCol>1</MaxTileCol>
+          </TileMatrixLimits>
+        </TileMatrixSetLimits>
+      </TileMatrixSetLink>
+      <ResourceURL format="image/jpeg" resourceType="tile" template="https://service.pdok.nl/luchtfoto/wmts/{Style}/{TIME}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.jpeg"/>
+    </Layer>
+    <TileMatrixSet>
+      <ows:Identifier>EPSG:28992</ows:Identifier>
+      <ows:SupportedCRS>urn:ogc:def:crs:EPSG::28992</ows:SupportedCRS>
+      <TileMatrix>
+        <ows:Identifier>01</ows:Identifier>
+        <ScaleDenominator>6144000</ScaleDenominator>
+        <TopLeftCorner>-285401.92 903401.92</TopLeftCorner>
+        <TileWidth>256</TileWidth>
+        <TileHeight>256</TileHeight>
+        <MatrixWidth>2</MatrixWidth>
+        <MatrixHeight>2</MatrixHeight>
+      </TileMatrix>
+    </TileMatrixSet>
+  </Contents>
+</Capabilities>`)
+
+var pdokContents = Contents{
+	Layer: []Layer{{
+		Title:            `Luchtfoto`,
+		Abstract:         `Luchtfoto van Nederland`,
+		Keywords:         &ows.Keywords{Keyword: []string{`luchtfoto`, `ortho`}},
+		WGS84BoundingBox: &ows.BoundingBox{LowerCorner: ows.Position{3.3, 50.7}, UpperCorner: ows.Position{7.3, 53.6}},
+		Identifier:       `luchtfoto`,
+		Metadata:         []Metadata{{Href: `https://www.nationaalgeoregister.nl/geonetwork/srv/dut/csw?service=CSW&request=GetRecordById`}},
+		Style: []Style{
+			{Title: `Default`, Identifier: `default`, IsDefault: true,
+				LegendURL: []LegendURL{{Format: `image/png`, Width: intPtr(100), Height: intPtr(20), Href: `https://service.pdok.nl/luchtfoto/legend.png`}}},
+			{Identifier: `infrarood`},
+		},
+		Format:     []string{`image/jpeg`, `image/png`},
+		InfoFormat: []string{`application/json`},
+		Dimension:  []Dimension{{Identifier: `TIME`, UOM: `ISO8601`, Default: `2020`, Current: true, Value: []string{`2019`, `2020`}}},
+		TileMatrixSetLink: []TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`, TileMatrixSetLimits: &TileMatrixSetLimits{
+			TileMatrixLimits: []TileMatrixLimits{{TileMatrix: `01`, MinTileRow: 0, MaxTileRow: 1, MinTileCol: 0, MaxTileCol: 1}}}}},
+		ResourceURL: []ResourceURL{{Format: `image/jpeg`, ResourceType: ResourceTypeTile,
+			Template: `https://service.pdok.nl/luchtfoto/wmts/{Style}/{TIME}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.jpeg`}},
+	}},
+	TileMatrixSet: []TileMatrixSet{{Identifier: `EPSG:28992`, SupportedCRS: `urn:ogc:def:crs:EPSG::28992`, TileMatrix: []TileMatrix{
+		{Identifier: `01`, ScaleDenominator: 6144000, TopLeftCorner: ows.Position{-285401.92, 903401.92}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 2},
+	}}},
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestContentsParseXML(t *testing.T) {
+	var tests = []struct {
+		doc      []byte
+		contents Contents
+		err      bool
+	}{
+		0: {doc: pdokCapabilities, contents: pdokContents},
+		// Only the Contents
+		1: {doc: []byte(`<Contents xmlns="http://www.opengis.net/wmts/1.0" xmlns:ows="http://www.opengis.net/ows/1.1">
+  <Layer><ows:Identifier>luchtfoto</ows:Identifier><Format>image/jpeg</Format></Layer>
+</Contents>`),
+			contents: Contents{Layer: []Layer{{Identifier: `luchtfoto`, Format: []string{`image/jpeg`}}}}},
+		2: {doc: []byte(`<Contents>`), err: true},
+	}
+
+	for k, test := range tests {
+		var contents Contents
+		err := contents.ParseXML(test.doc)
+		if (err != nil) != test.err {
+			t.Errorf("test: %d, expected error: %t,\n got: %v", k, test.err, err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(contents, test.contents) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.contents, contents)
+		}
+	}
+}
+
+func TestContentsParseYAML(t *testing.T) {
+	doc := []byte(`layer:
+- title: Luchtfoto
+  identifier: luchtfoto
+  wgs84boundingbox:
+    lowercorner: 3.3 50.7
+    uppercorner: 7.3 53.6
+  style:
+  - identifier: default
+    isdefault: true
+  format:
+  - image/jpeg
+  dimension:
+  - identifier: TIME
+    default: "2020"
+    value:
+    - "2019"
+    - "2020"
+  tilematrixsetlink:
+  - tilematrixset: EPSG:28992
+tilematrixset:
+- identifier: EPSG:28992
+  supportedcrs: urn:ogc:def:crs:EPSG::28992
+  tilematrix:
+  - identifier: "01"
+    scaledenominator: 6144000
+    topleftcorner: -285401.92 903401.92
+    tilewidth: 256
+    tileheight: 256
+    matrixwidth: 2
+    matrixheight: 2
+`)
+
+	expected := Contents{
+		Layer: []Layer{{Title: `Luchtfoto`, Identifier: `luchtfoto`,
+			WGS84BoundingBox:  &ows.BoundingBox{LowerCorner: ows.Position{3.3, 50.7}, UpperCorner: ows.Position{7.3, 53.6}},
+			Style:             []Style{{Identifier: `default`, IsDefault: true}},
+			Format:            []string{`image/jpeg`},
+			Dimension:         []Dimension{{Identifier: `TIME`, Default: `2020`, Value: []string{`2019`, `2020`}}},
+			TileMatrixSetLink: []TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`}}}},
+		TileMatrixSet: pdokContents.TileMatrixSet,
+	}
+
+	var contents Contents
+	if err := contents.ParseYAMl(doc); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+	if !reflect.DeepEqual(contents, expected) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 0, expected, contents)
+	}
+}
+
+func TestContentsRoundTrip(t *testing.T) {
+	doc, err := ows.CanonicalNamespaces.Marshal(pdokContents, ``, ` `)
+	if err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+
+	var contents Contents
+	if err := contents.ParseXML(doc); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 1, err.Error())
+		return
+	}
+	if !reflect.DeepEqual(contents, pdokContents) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 2, pdokContents, contents)
+	}
+}
+
+func TestLayerStyle(t *testing.T) {
+	var tests = []struct {
+		layer      Layer
+		identifier string
+		style      string
+		ok         bool
+	}{
+		0: {layer: pdokContents.Layer[0], identifier: `infrarood`, style: `default`, ok: true},
+		1: {layer: Layer{Style: []Style{{Identifier: `a`}, {Identifier: `b`}}}, identifier: `c`, style: `a`, ok: true},
+		2: {layer: Layer{}, identifier: `default`},
+	}
+
+	for k, test := range tests {
+		if style, ok := test.layer.DefaultStyle(); ok != test.ok || style.Identifier != test.style {
+			t.Errorf("test: %d, expected: %s %t,\n got: %s %t", k, test.style, test.ok, style.Identifier, ok)
+		}
+		if _, ok := test.layer.GetStyle(test.identifier); ok != (k == 0) {
+			t.Errorf("test: %d, expected: %t,\n got: %t", k, k == 0, ok)
+		}
+	}
+}
+
+func TestLayerGetDimension(t *testing.T) {
+	layer := pdokContents.Layer[0]
+
+	dimension, ok := layer.GetDimension(`time`)
+	if !ok || !dimension.HasValue(`2019`) || dimension.HasValue(`2021`) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 0, layer.Dimension[0], dimension)
+	}
+	if _, ok := layer.GetDimension(`elevation`); ok {
+		t.Errorf("test: %d, expected: %t,\n got: %t", 1, false, ok)
+	}
+	if !layer.HasFormat(`image/png`) || layer.HasFormat(`image/gif`) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 2, `image/png`, layer.Format)
+	}
+}
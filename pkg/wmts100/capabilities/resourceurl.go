@@ -0,0 +1,138 @@
+package capabilities
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ResourceTypes of a ResourceURL
+const (
+	ResourceTypeTile        = `tile`
+	ResourceTypeFeatureInfo = `FeatureInfo`
+)
+
+// placeholder matches the {variables} in a ResourceURL template
+var placeholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// ResourceURL describes the template of the RESTful encoding of a resource
+type ResourceURL struct {
+	Format       string `xml:"format,attr" yaml:"format"`
+	ResourceType string `xml:"resourceType,attr" yaml:"resourcetype"`
+	Template     string `xml:"template,attr" yaml:"template"`
+}
+
+// Placeholders returns the variable names used in the template in order of appearance
+func (r ResourceURL) Placeholders() []string {
+	var placeholders []string
+	for _, m := range placeholder.FindAllStringSubmatch(r.Template, -1) {
+		placeholders = append(placeholders, m[1])
+	}
+	return placeholders
+}
+
+// Expand replaces the variables in the template with the given values
+// Variable names are case insensitive, every variable in the template needs a value
+func (r ResourceURL) Expand(values map[string]string) (string, error) {
+	var missing []string
+	expanded := placeholder.ReplaceAllStringFunc(r.Template, func(p string) string {
+		name := p[1 : len(p)-1]
+		value, ok := lookup(values, name)
+		if !ok {
+			missing = append(missing, name)
+			return p
+		}
+		return url.PathEscape(value)
+	})
+	if len(missing) > 0 {
+		return ``, fmt.Errorf("no value for template variable(s): %s", strings.Join(missing, ","))
+	}
+	return expanded, nil
+}
+
+// Match matches the path of the given URL against the path of the template
+// and returns the values of the variables when it matches
+func (r ResourceURL) Match(rawurl string) (map[string]string, bool) {
+	path, err := urlPath(rawurl)
+	if err != nil {
+		return nil, false
+	}
+	template, err := urlPath(r.Template)
+	if err != nil {
+		return nil, false
+	}
+
+	var names []string
+	var expr strings.Builder
+	expr.WriteString(`^`)
+	last := 0
+	for _, loc := range placeholder.FindAllStringSubmatchIndex(template, -1) {
+		expr.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		expr.WriteString(`([^/]+)`)
+		names = append(names, template[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(template[last:]))
+	expr.WriteString(`$`)
+
+	m := regexp.MustCompile(expr.String()).FindStringSubmatch(path)
+	if m == nil {
+		return nil, false
+	}
+
+	values := make(map[string]string)
+	for i, name := range names {
+		value, err := url.PathUnescape(m[i+1])
+		if err != nil {
+			return nil, false
+		}
+		// a variable used more then once in a template needs to have the same value
+		if v, ok := lookup(values, name); ok && v != value {
+			return nil, false
+		}
+		values[name] = value
+	}
+	return values, true
+}
+
+// GetResourceURL returns the ResourceURL of the Layer with the given resourceType and format
+func (l Layer) GetResourceURL(resourcetype, format string) (ResourceURL, bool) {
+	for _, r := range l.ResourceURL {
+		if r.ResourceType == resourcetype && r.Format == format {
+			return r, true
+		}
+	}
+	return ResourceURL{}, false
+}
+
+// lookup returns the value for the case insensitive key
+func lookup(values map[string]string, key string) (string, bool) {
+	if v, ok := values[key]; ok {
+		return v, true
+	}
+	for k, v := range values {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return ``, false
+}
+
+// urlPath returns the path of an absolute or relative URL, the braces of the template variables are kept
+func urlPath(rawurl string) (string, error) {
+	if i := strings.IndexAny(rawurl, `?#`); i >= 0 {
+		rawurl = rawurl[:i]
+	}
+	if i := strings.Index(rawurl, `://`); i >= 0 {
+		rawurl = rawurl[i+3:]
+		if j := strings.Index(rawurl, `/`); j >= 0 {
+			return rawurl[j:], nil
+		}
+		return `/`, nil
+	}
+	if rawurl == `` {
+		return ``, fmt.Errorf("empty URL")
+	}
+	return rawurl, nil
+}
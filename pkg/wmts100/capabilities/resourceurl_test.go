@@ -0,0 +1,81 @@
+package capabilities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceURLExpand(t *testing.T) {
+	var tests = []struct {
+		template string
+		values   map[string]string
+		result   string
+		err      string
+	}{
+		0: {template: `https://service.pdok.nl/wmts/brt/{Style}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.png`,
+			values: map[string]string{`Style`: `default`, `TileMatrixSet`: `EPSG:28992`, `TileMatrix`: `05`, `TileCol`: `20`, `TileRow`: `12`},
+			result: `https://service.pdok.nl/wmts/brt/default/EPSG:28992/05/20/12.png`},
+		// Variable names are case insensitive and values are escaped
+		1: {template: `/wmts/{time}/{tilematrix}/{TileRow}/{TileCol}`,
+			values: map[string]string{`TIME`: `2020 01`, `TileMatrix`: `a/b`, `TileRow`: `1`, `TileCol`: `2`},
+			result: `/wmts/2020%2001/a%2Fb/1/2`},
+		2: {template: `/wmts/{TileMatrix}/{TileRow}/{TileCol}`, values: map[string]string{`TileMatrix`: `05`},
+			err: `no value for template variable(s): TileRow,TileCol`},
+	}
+
+	for k, test := range tests {
+		r := ResourceURL{Template: test.template}
+		result, err := r.Expand(test.values)
+		if err != nil {
+			if err.Error() != test.err {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, test.err, err.Error())
+			}
+			continue
+		}
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestResourceURLMatch(t *testing.T) {
+	var tests = []struct {
+		template string
+		url      string
+		values   map[string]string
+	}{
+		0: {template: `https://service.pdok.nl/wmts/brt/{Style}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.png`,
+			url:    `/wmts/brt/default/EPSG:28992/05/20/12.png`,
+			values: map[string]string{`Style`: `default`, `TileMatrixSet`: `EPSG:28992`, `TileMatrix`: `05`, `TileCol`: `20`, `TileRow`: `12`}},
+		// Absolute URLs with a query string and escaped values
+		1: {template: `https://service.pdok.nl/wmts/{TIME}/{TileMatrix}/{TileRow}/{TileCol}.jpeg`,
+			url:    `http://localhost:8080/wmts/2020%2001/05/1/2.jpeg?token=abc`,
+			values: map[string]string{`TIME`: `2020 01`, `TileMatrix`: `05`, `TileRow`: `1`, `TileCol`: `2`}},
+		2: {template: `https://service.pdok.nl/wmts/brt/{TileMatrix}/{TileCol}/{TileRow}.png`, url: `/wmts/brt/05/20/12.jpeg`},
+		3: {template: `https://service.pdok.nl/wmts/brt/{TileMatrix}/{TileCol}/{TileRow}.png`, url: `/wmts/brt/05/20/12/13.png`},
+		// The same variable twice needs to have the same value
+		4: {template: `/wmts/{TileMatrix}/{TileMatrix}/{TileCol}/{TileRow}.png`, url: `/wmts/05/06/20/12.png`},
+		5: {template: `/wmts/{TileMatrix}/{TileMatrix}/{TileCol}/{TileRow}.png`, url: `/wmts/05/05/20/12.png`,
+			values: map[string]string{`TileMatrix`: `05`, `TileCol`: `20`, `TileRow`: `12`}},
+	}
+
+	for k, test := range tests {
+		r := ResourceURL{Template: test.template}
+		values, ok := r.Match(test.url)
+		if ok != (test.values != nil) {
+			t.Errorf("test: %d, expected: %t,\n got: %t", k, test.values != nil, ok)
+			continue
+		}
+		if !reflect.DeepEqual(values, test.values) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.values, values)
+		}
+	}
+}
+
+func TestResourceURLPlaceholders(t *testing.T) {
+	r := ResourceURL{Template: `/wmts/{Style}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.png`}
+	expected := []string{`Style`, `TileMatrixSet`, `TileMatrix`, `TileCol`, `TileRow`}
+	if !reflect.DeepEqual(r.Placeholders(), expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, r.Placeholders())
+	}
+}
@@ -0,0 +1,242 @@
+package capabilities
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/transform"
+)
+
+// StandardizedRenderingPixelSize is the size of a pixel in meters (0.28mm) used to relate the ScaleDenominator to a pixel size
+const StandardizedRenderingPixelSize = 0.00028
+
+// MetersPerDegree is the length of a degree on the equator of the WGS84 ellipsoid (2 * pi * 6378137 / 360)
+const MetersPerDegree = 111319.49079327357
+
+// epsilon prevents floating point errors from adding an extra tile when a bounding box is aligned with the tile edges
+const epsilon = 1e-9
+
+// geographicCRS are the CRSs with degrees as unit
+var geographicCRS = map[ows.CRS]bool{
+	{Namespace: ows.EPSG, Code: `4326`}: true,
+	{Namespace: ows.EPSG, Code: `4258`}: true,
+	{Namespace: ows.EPSG, Code: `4289`}: true,
+	{Namespace: ows.EPSG, Code: `4171`}: true,
+	ows.CRS84:                           true,
+}
+
+// TileRange is the (inclusive) range of tiles in a TileMatrix
+type TileRange struct {
+	MinTileRow int
+	MaxTileRow int
+	MinTileCol int
+	MaxTileCol int
+}
+
+// MetersPerUnit returns the length of a unit of the SupportedCRS in meters
+// Geographic CRSs have degrees as unit, all other CRSs are assumed to have meters as unit
+func (t TileMatrixSet) MetersPerUnit() float64 {
+	if isGeographic(t.SupportedCRS) {
+		return MetersPerDegree
+	}
+	return 1
+}
+
+// isGeographic checks if the CRS has degrees as unit
+func isGeographic(s string) bool {
+	var crs ows.CRS
+	crs.ParseString(s)
+	return geographicCRS[crs]
+}
+
+// PixelSize returns the size of a pixel of the TileMatrix in units of the SupportedCRS
+func (t TileMatrixSet) PixelSize(tm TileMatrix) float64 {
+	return tm.ScaleDenominator * StandardizedRenderingPixelSize / t.MetersPerUnit()
+}
+
+// TileSpan returns the width and height of a tile of the TileMatrix in units of the SupportedCRS
+func (t TileMatrixSet) TileSpan(tm TileMatrix) (float64, float64) {
+	pixelsize := t.PixelSize(tm)
+	return float64(tm.TileWidth) * pixelsize, float64(tm.TileHeight) * pixelsize
+}
+
+// topLeftCorner returns the TopLeftCorner of the TileMatrix, given in the axis order of the SupportedCRS, in x y order
+func (t TileMatrixSet) topLeftCorner(tm TileMatrix) ows.Position {
+	var crs ows.CRS
+	crs.ParseString(t.SupportedCRS)
+	return tm.TopLeftCorner.ToXY(crs)
+}
+
+// TileBoundingBox returns the BoundingBox, in x y order, of the tile at the given row and column
+func (t TileMatrixSet) TileBoundingBox(tm TileMatrix, row, col int) ows.BoundingBox {
+	width, height := t.TileSpan(tm)
+	topleft := t.topLeftCorner(tm)
+	minx := topleft[0] + float64(col)*width
+	maxy := topleft[1] - float64(row)*height
+	return ows.BoundingBox{
+		Crs:         t.SupportedCRS,
+		LowerCorner: ows.Position{minx, maxy - height},
+		UpperCorner: ows.Position{minx + width, maxy},
+	}
+}
+
+// TileRange returns the range of tiles in the TileMatrix covering the BoundingBox, given in x y order
+// The range is limited to the size of the TileMatrix, false is returned when the BoundingBox lies outside the TileMatrix
+func (t TileMatrixSet) TileRange(tm TileMatrix, bbox ows.BoundingBox) (TileRange, bool) {
+	width, height := t.TileSpan(tm)
+	if width <= 0 || height <= 0 {
+		return TileRange{}, false
+	}
+
+	topleft := t.topLeftCorner(tm)
+	r := TileRange{
+		MinTileCol: int(math.Floor((bbox.LowerCorner[0]-topleft[0])/width + epsilon)),
+		MaxTileCol: int(math.Ceil((bbox.UpperCorner[0]-topleft[0])/width-epsilon)) - 1,
+		MinTileRow: int(math.Floor((topleft[1]-bbox.UpperCorner[1])/height + epsilon)),
+		MaxTileRow: int(math.Ceil((topleft[1]-bbox.LowerCorner[1])/height-epsilon)) - 1,
+	}
+
+	r.MinTileCol = max(r.MinTileCol, 0)
+	r.MinTileRow = max(r.MinTileRow, 0)
+	r.MaxTileCol = min(r.MaxTileCol, tm.MatrixWidth-1)
+	r.MaxTileRow = min(r.MaxTileRow, tm.MatrixHeight-1)
+
+	if r.MinTileCol > r.MaxTileCol || r.MinTileRow > r.MaxTileRow {
+		return TileRange{}, false
+	}
+	return r, true
+}
+
+// BestTileMatrix returns the TileMatrix with the pixel size closest to the given resolution
+// The resolution is in units of the SupportedCRS per pixel
+func (t TileMatrixSet) BestTileMatrix(resolution float64) (TileMatrix, bool) {
+	var best TileMatrix
+	found := false
+	difference := math.Inf(1)
+	for _, tm := range t.TileMatrix {
+		// compare the ratio, the TileMatrices differ by a factor and not by a fixed amount
+		d := math.Abs(math.Log(t.PixelSize(tm) / resolution))
+		if d < difference {
+			best, difference, found = tm, d, true
+		}
+	}
+	return best, found
+}
+
+// TileMatrixSetLimits returns the TileMatrixSetLimits covering the BoundingBox, given in the SupportedCRS
+// TileMatrices that don't overlap the BoundingBox are left out, they have no tiles available
+func (t TileMatrixSet) TileMatrixSetLimits(bbox ows.BoundingBox) TileMatrixSetLimits {
+	var limits TileMatrixSetLimits
+	for _, tm := range t.TileMatrix {
+		if r, ok := t.TileRange(tm, bbox); ok {
+			limits.TileMatrixLimits = append(limits.TileMatrixLimits, TileMatrixLimits{
+				TileMatrix: tm.Identifier,
+				MinTileRow: r.MinTileRow,
+				MaxTileRow: r.MaxTileRow,
+				MinTileCol: r.MinTileCol,
+				MaxTileCol: r.MaxTileCol,
+			})
+		}
+	}
+	return limits
+}
+
+// Extent returns the BoundingBox, in x y order, covered by all TileMatrices of the TileMatrixSet
+// False is returned when the TileMatrixSet has no TileMatrix
+func (t TileMatrixSet) Extent() (ows.BoundingBox, bool) {
+	if len(t.TileMatrix) == 0 {
+		return ows.BoundingBox{}, false
+	}
+	lower := ows.Position{math.Inf(1), math.Inf(1)}
+	upper := ows.Position{math.Inf(-1), math.Inf(-1)}
+	for _, tm := range t.TileMatrix {
+		width, height := t.TileSpan(tm)
+		topleft := t.topLeftCorner(tm)
+		lower = ows.Position{math.Min(lower[0], topleft[0]), math.Min(lower[1], topleft[1]-float64(tm.MatrixHeight)*height)}
+		upper = ows.Position{math.Max(upper[0], topleft[0]+float64(tm.MatrixWidth)*width), math.Max(upper[1], topleft[1])}
+	}
+	return ows.BoundingBox{Crs: t.SupportedCRS, LowerCorner: lower, UpperCorner: upper}, true
+}
+
+// TileMatrixSetLimits computes the TileMatrixSetLimits of the Layer for the TileMatrixSet
+// The extent of the Layer is the WGS84BoundingBox when the SupportedCRS is geographic, or a BoundingBox of the Layer
+// in the SupportedCRS when there is one. Otherwise the WGS84BoundingBox is projected into the SupportedCRS,
+// after it's intersected with the Extent of the TileMatrixSet so it stays inside the valid area of projections like
+// Web Mercator.
+// An error is returned when the Layer has no extent or the SupportedCRS isn't supported by the transform package
+func (l Layer) TileMatrixSetLimits(t TileMatrixSet) (TileMatrixSetLimits, error) {
+	if l.WGS84BoundingBox != nil && isGeographic(t.SupportedCRS) {
+		return t.TileMatrixSetLimits(*l.WGS84BoundingBox), nil
+	}
+	for _, bbox := range l.BoundingBox {
+		if sameCRS(bbox.Crs, t.SupportedCRS) {
+			return t.TileMatrixSetLimits(bbox), nil
+		}
+	}
+	if l.WGS84BoundingBox == nil {
+		return TileMatrixSetLimits{}, fmt.Errorf("layer %s has no WGS84BoundingBox or BoundingBox in %s", l.Identifier, t.SupportedCRS)
+	}
+	var crs ows.CRS
+	if exception := crs.ParseString(t.SupportedCRS); exception != nil {
+		return TileMatrixSetLimits{}, exception
+	}
+	wgs84 := *l.WGS84BoundingBox
+	if extent, ok := t.Extent(); ok {
+		if geographic, err := transform.GeographicBoundingBox(extent, crs); err == nil {
+			var overlaps bool
+			if wgs84, overlaps = intersect(wgs84, geographic); !overlaps {
+				return TileMatrixSetLimits{}, nil
+			}
+		}
+	}
+	bbox, err := transform.TransformBoundingBox(wgs84, ows.CRS84, crs)
+	if err != nil {
+		return TileMatrixSetLimits{}, err
+	}
+	return t.TileMatrixSetLimits(bbox), nil
+}
+
+// intersect returns the overlap of both BoundingBoxes, given in x y order in the same CRS
+// False is returned when the BoundingBoxes don't overlap
+func intersect(a, b ows.BoundingBox) (ows.BoundingBox, bool) {
+	al, au, bl, bu := a.LowerCorner.XY(), a.UpperCorner.XY(), b.LowerCorner.XY(), b.UpperCorner.XY()
+	lower := ows.Position{math.Max(al[0], bl[0]), math.Max(al[1], bl[1])}
+	upper := ows.Position{math.Min(au[0], bu[0]), math.Min(au[1], bu[1])}
+	if lower[0] > upper[0] || lower[1] > upper[1] {
+		return ows.BoundingBox{}, false
+	}
+	return ows.BoundingBox{Crs: a.Crs, LowerCorner: lower, UpperCorner: upper}, true
+}
+
+// sameCRS checks if both strings denote the same CRS, like EPSG:28992 and urn:ogc:def:crs:EPSG::28992
+// The BoundingBoxes are in x y order, so CRSs that only differ in axis order are the same
+func sameCRS(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var crsa, crsb ows.CRS
+	crsa.ParseString(a)
+	crsb.ParseString(b)
+	same, _ := crsa.Equivalent(crsb)
+	return same
+}
+
+// Tiles returns the number of tiles in the TileRange
+func (r TileRange) Tiles() int {
+	return (r.MaxTileRow - r.MinTileRow + 1) * (r.MaxTileCol - r.MinTileCol + 1)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
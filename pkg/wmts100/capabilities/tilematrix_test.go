@@ -0,0 +1,217 @@
+package capabilities
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+var rdNew = TileMatrixSet{Identifier: `EPSG:28992`, SupportedCRS: `urn:ogc:def:crs:EPSG::28992`, TileMatrix: []TileMatrix{
+	{Identifier: `00`, ScaleDenominator: 12288000, TopLeftCorner: ows.Position{-285401.92, 903401.92}, TileWidth: 256, TileHeight: 256, MatrixWidth: 1, MatrixHeight: 1},
+	{Identifier: `01`, ScaleDenominator: 6144000, TopLeftCorner: ows.Position{-285401.92, 903401.92}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 2},
+	{Identifier: `02`, ScaleDenominator: 3072000, TopLeftCorner: ows.Position{-285401.92, 903401.92}, TileWidth: 256, TileHeight: 256, MatrixWidth: 4, MatrixHeight: 4},
+}}
+
+var crs84 = TileMatrixSet{Identifier: `WorldCRS84Quad`, SupportedCRS: `urn:ogc:def:crs:OGC:1.3:CRS84`, TileMatrix: []TileMatrix{
+	{Identifier: `0`, ScaleDenominator: 279541132.0143589, TopLeftCorner: ows.Position{-180, 90}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 1},
+}}
+
+// wgs84 has the TopLeftCorner in the northing easting axis order of EPSG:4326
+var wgs84 = TileMatrixSet{Identifier: `EPSG:4326`, SupportedCRS: `urn:ogc:def:crs:EPSG::4326`, TileMatrix: []TileMatrix{
+	{Identifier: `0`, ScaleDenominator: 279541132.0143589, TopLeftCorner: ows.Position{90, -180}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 1},
+}}
+
+var webMercator = TileMatrixSet{Identifier: `WebMercatorQuad`, SupportedCRS: `urn:ogc:def:crs:EPSG::3857`, TileMatrix: []TileMatrix{
+	{Identifier: `0`, ScaleDenominator: 559082264.0287178, TopLeftCorner: ows.Position{-20037508.3427892, 20037508.3427892}, TileWidth: 256, TileHeight: 256, MatrixWidth: 1, MatrixHeight: 1},
+	{Identifier: `5`, ScaleDenominator: 17471320.75089743, TopLeftCorner: ows.Position{-20037508.3427892, 20037508.3427892}, TileWidth: 256, TileHeight: 256, MatrixWidth: 32, MatrixHeight: 32},
+}}
+
+func equal(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func equalBoundingBox(a, b ows.BoundingBox) bool {
+	return a.Crs == b.Crs && equal(a.LowerCorner[0], b.LowerCorner[0]) && equal(a.LowerCorner[1], b.LowerCorner[1]) &&
+		equal(a.UpperCorner[0], b.UpperCorner[0]) && equal(a.UpperCorner[1], b.UpperCorner[1])
+}
+
+func TestTileMatrixSetPixelSize(t *testing.T) {
+	var tests = []struct {
+		tilematrixset TileMatrixSet
+		tilematrix    TileMatrix
+		pixelsize     float64
+	}{
+		0: {tilematrixset: rdNew, tilematrix: rdNew.TileMatrix[0], pixelsize: 3440.64},
+		1: {tilematrixset: rdNew, tilematrix: rdNew.TileMatrix[2], pixelsize: 860.16},
+		2: {tilematrixset: crs84, tilematrix: crs84.TileMatrix[0], pixelsize: 0.703125},
+	}
+
+	for k, test := range tests {
+		if pixelsize := test.tilematrixset.PixelSize(test.tilematrix); !equal(pixelsize, test.pixelsize) {
+			t.Errorf("test: %d, expected: %f,\n got: %f", k, test.pixelsize, pixelsize)
+		}
+	}
+}
+
+func TestTileMatrixSetTileBoundingBox(t *testing.T) {
+	var tests = []struct {
+		tilematrixset TileMatrixSet
+		tilematrix    TileMatrix
+		row, col      int
+		bbox          ows.BoundingBox
+	}{
+		0: {tilematrixset: rdNew, tilematrix: rdNew.TileMatrix[0],
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:EPSG::28992`, LowerCorner: ows.Position{-285401.92, 22598.08}, UpperCorner: ows.Position{595401.92, 903401.92}}},
+		1: {tilematrixset: rdNew, tilematrix: rdNew.TileMatrix[1], row: 1, col: 0,
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:EPSG::28992`, LowerCorner: ows.Position{-285401.92, 22598.08}, UpperCorner: ows.Position{155000, 463000}}},
+		2: {tilematrixset: crs84, tilematrix: crs84.TileMatrix[0], row: 0, col: 1,
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:OGC:1.3:CRS84`, LowerCorner: ows.Position{0, -90}, UpperCorner: ows.Position{180, 90}}},
+		3: {tilematrixset: wgs84, tilematrix: wgs84.TileMatrix[0], row: 0, col: 1,
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:EPSG::4326`, LowerCorner: ows.Position{0, -90}, UpperCorner: ows.Position{180, 90}}},
+	}
+
+	for k, test := range tests {
+		if bbox := test.tilematrixset.TileBoundingBox(test.tilematrix, test.row, test.col); !equalBoundingBox(bbox, test.bbox) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.bbox, bbox)
+		}
+	}
+}
+
+func TestTileMatrixSetExtent(t *testing.T) {
+	var tests = []struct {
+		tilematrixset TileMatrixSet
+		bbox          ows.BoundingBox
+		ok            bool
+	}{
+		0: {tilematrixset: rdNew, ok: true,
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:EPSG::28992`, LowerCorner: ows.Position{-285401.92, 22598.08}, UpperCorner: ows.Position{595401.92, 903401.92}}},
+		1: {tilematrixset: wgs84, ok: true,
+			bbox: ows.BoundingBox{Crs: `urn:ogc:def:crs:EPSG::4326`, LowerCorner: ows.Position{-180, -90}, UpperCorner: ows.Position{180, 90}}},
+		2: {tilematrixset: TileMatrixSet{SupportedCRS: `EPSG:3857`}},
+	}
+
+	for k, test := range tests {
+		bbox, ok := test.tilematrixset.Extent()
+		if ok != test.ok || (ok && !equalBoundingBox(bbox, test.bbox)) {
+			t.Errorf("test: %d, expected: %v %t,\n got: %v %t", k, test.bbox, test.ok, bbox, ok)
+		}
+	}
+}
+
+func TestTileMatrixSetTileRange(t *testing.T) {
+	var tests = []struct {
+		tilematrix TileMatrix
+		bbox       ows.BoundingBox
+		tilerange  TileRange
+		ok         bool
+	}{
+		0: {tilematrix: rdNew.TileMatrix[1], bbox: ows.BoundingBox{LowerCorner: ows.Position{0, 400000}, UpperCorner: ows.Position{100000, 500000}},
+			tilerange: TileRange{MinTileRow: 0, MaxTileRow: 1, MinTileCol: 0, MaxTileCol: 0}, ok: true},
+		// Aligned with the tile edges
+		1: {tilematrix: rdNew.TileMatrix[1], bbox: ows.BoundingBox{LowerCorner: ows.Position{-285401.92, 463000}, UpperCorner: ows.Position{155000, 903401.92}},
+			tilerange: TileRange{MinTileRow: 0, MaxTileRow: 0, MinTileCol: 0, MaxTileCol: 0}, ok: true},
+		// Limited to the TileMatrix
+		2: {tilematrix: rdNew.TileMatrix[2], bbox: ows.BoundingBox{LowerCorner: ows.Position{-1000000, -1000000}, UpperCorner: ows.Position{1000000, 1000000}},
+			tilerange: TileRange{MinTileRow: 0, MaxTileRow: 3, MinTileCol: 0, MaxTileCol: 3}, ok: true},
+		3: {tilematrix: rdNew.TileMatrix[2], bbox: ows.BoundingBox{LowerCorner: ows.Position{1000000, 1000000}, UpperCorner: ows.Position{2000000, 2000000}}},
+	}
+
+	for k, test := range tests {
+		tilerange, ok := rdNew.TileRange(test.tilematrix, test.bbox)
+		if ok != test.ok || tilerange != test.tilerange {
+			t.Errorf("test: %d, expected: %v %t,\n got: %v %t", k, test.tilerange, test.ok, tilerange, ok)
+		}
+	}
+
+	if tiles := (TileRange{MinTileRow: 0, MaxTileRow: 3, MinTileCol: 1, MaxTileCol: 3}).Tiles(); tiles != 12 {
+		t.Errorf("test: %d, expected: %d,\n got: %d", 4, 12, tiles)
+	}
+}
+
+func TestTileMatrixSetBestTileMatrix(t *testing.T) {
+	var tests = []struct {
+		resolution float64
+		identifier string
+	}{
+		0: {resolution: 2000, identifier: `01`},
+		1: {resolution: 100000, identifier: `00`},
+		2: {resolution: 0.25, identifier: `02`},
+		3: {resolution: 3440.64, identifier: `00`},
+	}
+
+	for k, test := range tests {
+		tm, ok := rdNew.BestTileMatrix(test.resolution)
+		if !ok || tm.Identifier != test.identifier {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.identifier, tm.Identifier)
+		}
+	}
+
+	if _, ok := (TileMatrixSet{}).BestTileMatrix(1); ok {
+		t.Errorf("test: %d, expected: %t,\n got: %t", 4, false, ok)
+	}
+}
+
+func TestLayerTileMatrixSetLimits(t *testing.T) {
+	netherlands := ows.BoundingBox{Crs: `EPSG:28992`, LowerCorner: ows.Position{0, 300000}, UpperCorner: ows.Position{280000, 625000}}
+	wgs84 := &ows.BoundingBox{LowerCorner: ows.Position{3.3, 50.7}, UpperCorner: ows.Position{7.3, 53.6}}
+
+	var tests = []struct {
+		layer         Layer
+		tilematrixset TileMatrixSet
+		limits        TileMatrixSetLimits
+		err           string
+	}{
+		0: {layer: Layer{BoundingBox: []ows.BoundingBox{netherlands}}, tilematrixset: rdNew,
+			limits: TileMatrixSetLimits{TileMatrixLimits: []TileMatrixLimits{
+				{TileMatrix: `00`, MinTileRow: 0, MaxTileRow: 0, MinTileCol: 0, MaxTileCol: 0},
+				{TileMatrix: `01`, MinTileRow: 0, MaxTileRow: 1, MinTileCol: 0, MaxTileCol: 1},
+				{TileMatrix: `02`, MinTileRow: 1, MaxTileRow: 2, MinTileCol: 1, MaxTileCol: 2},
+			}}},
+		1: {layer: Layer{WGS84BoundingBox: wgs84}, tilematrixset: crs84,
+			limits: TileMatrixSetLimits{TileMatrixLimits: []TileMatrixLimits{{TileMatrix: `0`, MinTileRow: 0, MaxTileRow: 0, MinTileCol: 1, MaxTileCol: 1}}}},
+		// The WGS84BoundingBox is projected into a projected TileMatrixSet
+		2: {layer: Layer{WGS84BoundingBox: wgs84}, tilematrixset: rdNew,
+			limits: TileMatrixSetLimits{TileMatrixLimits: []TileMatrixLimits{
+				{TileMatrix: `00`, MinTileRow: 0, MaxTileRow: 0, MinTileCol: 0, MaxTileCol: 0},
+				{TileMatrix: `01`, MinTileRow: 0, MaxTileRow: 1, MinTileCol: 0, MaxTileCol: 1},
+				{TileMatrix: `02`, MinTileRow: 1, MaxTileRow: 2, MinTileCol: 1, MaxTileCol: 2},
+			}}},
+		3: {layer: Layer{WGS84BoundingBox: wgs84}, tilematrixset: webMercator,
+			limits: TileMatrixSetLimits{TileMatrixLimits: []TileMatrixLimits{
+				{TileMatrix: `0`, MinTileRow: 0, MaxTileRow: 0, MinTileCol: 0, MaxTileCol: 0},
+				{TileMatrix: `5`, MinTileRow: 10, MaxTileRow: 10, MinTileCol: 16, MaxTileCol: 16},
+			}}},
+		// Outside of the TileMatrixSet
+		4: {layer: Layer{BoundingBox: []ows.BoundingBox{{Crs: `urn:ogc:def:crs:EPSG::28992`, LowerCorner: ows.Position{1000000, 1000000}, UpperCorner: ows.Position{2000000, 2000000}}}},
+			tilematrixset: rdNew},
+		5: {layer: Layer{Identifier: `luchtfoto`}, tilematrixset: rdNew, err: `layer luchtfoto has no WGS84BoundingBox or BoundingBox in urn:ogc:def:crs:EPSG::28992`},
+		6: {layer: Layer{WGS84BoundingBox: wgs84}, tilematrixset: TileMatrixSet{SupportedCRS: `EPSG:2056`}, err: `CRS EPSG:2056 is not supported`},
+		// A global layer is limited to the valid area of Web Mercator
+		7: {layer: Layer{WGS84BoundingBox: &ows.BoundingBox{LowerCorner: ows.Position{-180, -90}, UpperCorner: ows.Position{180, 90}}}, tilematrixset: webMercator,
+			limits: TileMatrixSetLimits{TileMatrixLimits: []TileMatrixLimits{
+				{TileMatrix: `0`, MinTileRow: 0, MaxTileRow: 0, MinTileCol: 0, MaxTileCol: 0},
+				{TileMatrix: `5`, MinTileRow: 0, MaxTileRow: 31, MinTileCol: 0, MaxTileCol: 31},
+			}}},
+		// Outside of the TileMatrixSet
+		8: {layer: Layer{WGS84BoundingBox: &ows.BoundingBox{LowerCorner: ows.Position{20, 10}, UpperCorner: ows.Position{30, 20}}}, tilematrixset: rdNew},
+	}
+
+	for k, test := range tests {
+		limits, err := test.layer.TileMatrixSetLimits(test.tilematrixset)
+		if err != nil {
+			if err.Error() != test.err {
+				t.Errorf("test: %d, expected: %s,\n got: %s", k, test.err, err.Error())
+			}
+			continue
+		}
+		if test.err != `` {
+			t.Errorf("test: %d, expected: %s,\n got: %+v", k, test.err, limits)
+			continue
+		}
+		if !reflect.DeepEqual(limits, test.limits) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.limits, limits)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package exception
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// Type and Version as constant
+const (
+	Service string = `WMTS`
+	Version string = `1.0.0`
+)
+
+// HTTP status codes of the exception codes, Table 8 and Table 23 of the WMTS 1.0.0 spec
+var statusCodes = map[string]int{
+	`OperationNotSupported`:    http.StatusNotImplemented,
+	`MissingParameterValue`:    http.StatusBadRequest,
+	`InvalidParameterValue`:    http.StatusBadRequest,
+	`VersionNegotiationFailed`: http.StatusBadRequest,
+	`InvalidUpdateSequence`:    http.StatusBadRequest,
+	`OptionNotSupported`:       http.StatusNotImplemented,
+	`NoApplicableCode`:         http.StatusInternalServerError,
+	`TileOutOfRange`:           http.StatusNotFound,
+	`PointIJOutOfRange`:        http.StatusBadRequest,
+}
+
+// StatusCode returns the HTTP status code for the exception
+// Unknown exception codes are handled as NoApplicableCode
+func StatusCode(e ows.Exception) int {
+	if code, ok := statusCodes[e.Code()]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// WMTSExceptionReport struct
+type WMTSExceptionReport struct {
+	XMLName        xml.Name       `xml:"ows:ExceptionReport" yaml:"exceptionreport"`
+	Ows            string         `xml:"xmlns:ows,attr,omitempty"`
+	Xsi            string         `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string         `xml:"xsi:schemaLocation,attr,omitempty"`
+	Version        string         `xml:"version,attr" yaml:"version"`
+	Language       string         `xml:"xml:lang,attr,omitempty" yaml:"lang,omitempty"`
+	Exception      ows.Exceptions `xml:"ows:Exception"`
+}
+
+// Report returns WMTSExceptionReport
+func (r WMTSExceptionReport) Report(errors ows.Exceptions) []byte {
+	r.Ows = `http://www.opengis.net/ows/1.1`
+	r.Xsi = `http://www.w3.org/2001/XMLSchema-instance`
+	r.SchemaLocation = `http://www.opengis.net/ows/1.1 http://schemas.opengis.net/ows/1.1.0/owsExceptionReport.xsd`
+	r.Version = Version
+	r.Language = `en`
+	r.Exception = errors
+
+	si, _ := xml.MarshalIndent(r, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// StatusCode returns the HTTP status code for the report, that is the status code of the first exception
+func (r WMTSExceptionReport) StatusCode(errors ows.Exceptions) int {
+	if len(errors) == 0 {
+		return http.StatusInternalServerError
+	}
+	return StatusCode(errors[0])
+}
+
+// WMTSException grouping the error message variables together
+type WMTSException struct {
+	XMLName       xml.Name `xml:"ows:Exception"`
+	ExceptionText string   `xml:",chardata" yaml:"exception"`
+	ExceptionCode string   `xml:"exceptionCode,attr" yaml:"exceptioncode"`
+	LocatorCode   string   `xml:"locator,attr,omitempty" yaml:"locator,omitempty"`
+}
+
+// Error returns available ExceptionText
+func (e WMTSException) Error() string {
+	return e.ExceptionText
+}
+
+// Code returns available ExceptionCode
+func (e WMTSException) Code() string {
+	return e.ExceptionCode
+}
+
+// Locator returns available LocatorCode
+func (e WMTSException) Locator() string {
+	return e.LocatorCode
+}
+
+// InvalidParameterValue exception
+// Unlike ows.InvalidParameterValue the locator is the name of the parameter, as required by the WMTS spec
+func InvalidParameterValue(value, locator string) WMTSException {
+	return WMTSException{
+		ExceptionText: fmt.Sprintf("%s contains a invalid value: %s", locator, value),
+		ExceptionCode: `InvalidParameterValue`,
+		LocatorCode:   locator,
+	}
+}
+
+// TileOutOfRange exception
+// TileRow or TileCol out of range
+func TileOutOfRange(index, min, max int, locator string) WMTSException {
+	return WMTSException{
+		ExceptionText: fmt.Sprintf("%s is out of range, it must be between %d and %d but is: %d", locator, min, max, index),
+		ExceptionCode: `TileOutOfRange`,
+		LocatorCode:   locator,
+	}
+}
+
+// PointIJOutOfRange exception
+// I or J out of range
+func PointIJOutOfRange(index, min, max int, locator string) WMTSException {
+	return WMTSException{
+		ExceptionText: fmt.Sprintf("%s is out of range, it must be between %d and %d but is: %d", locator, min, max, index),
+		ExceptionCode: `PointIJOutOfRange`,
+		LocatorCode:   locator,
+	}
+}
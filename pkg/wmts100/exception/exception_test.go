@@ -0,0 +1,103 @@
+package exception
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+func TestWMTSException(t *testing.T) {
+	var tests = []struct {
+		exception     ows.Exception
+		exceptionText string
+		exceptionCode string
+		locatorCode   string
+	}{
+		0: {exception: WMTSException{ExceptionCode: "", ExceptionText: "", LocatorCode: ""},
+			exceptionText: "",
+			exceptionCode: "",
+			locatorCode:   "",
+		},
+		1: {exception: InvalidParameterValue("unknown", "STYLE"),
+			exceptionText: "STYLE contains a invalid value: unknown",
+			exceptionCode: "InvalidParameterValue",
+			locatorCode:   "STYLE",
+		},
+		2: {exception: TileOutOfRange(12, 0, 9, "TILEROW"),
+			exceptionText: "TILEROW is out of range, it must be between 0 and 9 but is: 12",
+			exceptionCode: "TileOutOfRange",
+			locatorCode:   "TILEROW",
+		},
+		3: {exception: PointIJOutOfRange(256, 0, 255, "I"),
+			exceptionText: "I is out of range, it must be between 0 and 255 but is: 256",
+			exceptionCode: "PointIJOutOfRange",
+			locatorCode:   "I",
+		},
+	}
+
+	for k, a := range tests {
+		if a.exception.Error() != a.exceptionText {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.exceptionText, a.exception.Error())
+		}
+		if a.exception.Code() != a.exceptionCode {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.exceptionCode, a.exception.Code())
+		}
+		if a.exception.Locator() != a.locatorCode {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.locatorCode, a.exception.Locator())
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	var tests = []struct {
+		exceptions []ows.Exception
+		result     []byte
+	}{
+		0: {exceptions: []ows.Exception{WMTSException{ExceptionCode: "", ExceptionText: "", LocatorCode: ""}},
+			result: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<ows:ExceptionReport xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/ows/1.1 http://schemas.opengis.net/ows/1.1.0/owsExceptionReport.xsd" version="1.0.0" xml:lang="en">
+ <ows:Exception exceptionCode=""></ows:Exception>
+</ows:ExceptionReport>`)},
+		1: {exceptions: []ows.Exception{
+			TileOutOfRange(12, 0, 9, "TILEROW"),
+			InvalidParameterValue("unknown", "STYLE"),
+		},
+			result: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<ows:ExceptionReport xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.opengis.net/ows/1.1 http://schemas.opengis.net/ows/1.1.0/owsExceptionReport.xsd" version="1.0.0" xml:lang="en">
+ <ows:Exception exceptionCode="TileOutOfRange" locator="TILEROW">TILEROW is out of range, it must be between 0 and 9 but is: 12</ows:Exception>
+ <ows:Exception exceptionCode="InvalidParameterValue" locator="STYLE">STYLE contains a invalid value: unknown</ows:Exception>
+</ows:ExceptionReport>`)},
+	}
+
+	for k, a := range tests {
+		report := WMTSExceptionReport{}
+		r := report.Report(a.exceptions)
+
+		if string(r) != string(a.result) {
+			t.Errorf("test: %d, expected: %s\n got: %s", k, a.result, r)
+		}
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	var tests = []struct {
+		exceptions ows.Exceptions
+		statuscode int
+	}{
+		0: {exceptions: ows.Exceptions{InvalidParameterValue("unknown", "STYLE")}, statuscode: http.StatusBadRequest},
+		1: {exceptions: ows.Exceptions{TileOutOfRange(12, 0, 9, "TILEROW")}, statuscode: http.StatusNotFound},
+		2: {exceptions: ows.Exceptions{PointIJOutOfRange(256, 0, 255, "I")}, statuscode: http.StatusBadRequest},
+		3: {exceptions: ows.Exceptions{ows.OperationNotSupported("GetLegendGraphic")}, statuscode: http.StatusNotImplemented},
+		4: {exceptions: ows.Exceptions{ows.MissingParameterValue("TILEMATRIX"), TileOutOfRange(12, 0, 9, "TILEROW")}, statuscode: http.StatusBadRequest},
+		5: {exceptions: ows.Exceptions{WMTSException{ExceptionCode: "Unknown"}}, statuscode: http.StatusInternalServerError},
+		6: {statuscode: http.StatusInternalServerError},
+	}
+
+	for k, a := range tests {
+		report := WMTSExceptionReport{}
+		if statuscode := report.StatusCode(a.exceptions); statuscode != a.statuscode {
+			t.Errorf("test: %d, expected: %d\n got: %d", k, a.statuscode, statuscode)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package request
+
+import (
+	"net/url"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+)
+
+// Type and Version as constant
+const (
+	Service string = `WMTS`
+	Version string = `1.0.0`
+)
+
+// WMTS 1.0.0 Tokens
+const (
+	SERVICE = `SERVICE`
+	REQUEST = `REQUEST`
+	VERSION = `VERSION`
+)
+
+// BaseRequest contains the service and version attributes shared by the WMTS operations
+// Note: not usable for GetCapabilities request regarding deviation of Optional/Mandatory parameters SERVICE and VERSION
+type BaseRequest struct {
+	Service string           `xml:"service,attr" yaml:"service"`
+	Version string           `xml:"version,attr" yaml:"version"`
+	Attr    ows.XMLAttribute `xml:",attr"`
+}
+
+// BaseRequestKVP struct
+type BaseRequestKVP struct {
+	Service string `yaml:"service,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	Request string `yaml:"request,omitempty"`
+}
+
+// ParseKVP builds a BaseRequestKVP struct based on the given parameters
+func (b *BaseRequestKVP) ParseKVP(key, value string) bool {
+	switch key {
+	case SERVICE:
+		b.Service = value
+	case VERSION:
+		b.Version = value
+	case REQUEST:
+		b.Request = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Build builds a BaseRequest struct
+// SERVICE and VERSION are both mandatory for the WMTS operations
+func (b *BaseRequest) Build(service, version string) ows.Exceptions {
+	var exceptions ows.Exceptions
+	if service == `` {
+		exceptions = append(exceptions, ows.MissingParameterValue(SERVICE))
+	}
+	if version == `` {
+		exceptions = append(exceptions, ows.MissingParameterValue(VERSION))
+	}
+	b.Service = service
+	b.Version = version
+	return exceptions
+}
+
+// BuildKVP adds the SERVICE, REQUEST and VERSION to the query
+func (b *BaseRequestKVP) BuildKVP(query url.Values) {
+	query[SERVICE] = []string{b.Service}
+	query[REQUEST] = []string{b.Request}
+	query[VERSION] = []string{b.Version}
+}
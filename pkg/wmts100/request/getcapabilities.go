@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
-	"github.com/pdok/ogc-specifications/pkg/wcs201/capabilities"
 )
 
 //
@@ -15,26 +14,13 @@ const (
 	getcapabilities = `GetCapabilities`
 )
 
-// Type and Version as constant
-const (
-	Service string = `WMTS`
-	Version string = `1.0.0`
-)
-
-// WMTS 1.0.0 Tokens
-const (
-	SERVICE = `SERVICE`
-	REQUEST = `REQUEST`
-	VERSION = `VERSION`
-)
-
 // Type returns GetCapabilities
 func (gc *GetCapabilities) Type() string {
 	return getcapabilities
 }
 
 // Validate returns GetCapabilities
-func (gc *GetCapabilities) Validate(c capabilities.Contents) ows.Exceptions {
+func (gc *GetCapabilities) Validate(c ows.Capabilities) ows.Exceptions {
 	return nil
 }
 
@@ -0,0 +1,171 @@
+package request
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+// GetFeatureInfo
+const (
+	getfeatureinfo = `GetFeatureInfo`
+)
+
+// GetFeatureInfo Keys
+const (
+	J          = `J`
+	I          = `I`
+	INFOFORMAT = `INFOFORMAT`
+)
+
+// Type returns GetFeatureInfo
+func (gfi *GetFeatureInfo) Type() string {
+	return getfeatureinfo
+}
+
+// Validate validates the GetFeatureInfo against the capabilities Contents
+// Next to the checks of the GetTile it checks if the point lies within the tile and if the InfoFormat is advertised
+// An empty Format, as a RESTful FeatureInfo has, is checked as the Format of the map, see mapTile
+// The capabilities are a *capabilities.Contents, other capabilities result in a NoApplicableCode exception
+func (gfi *GetFeatureInfo) Validate(c ows.Capabilities) ows.Exceptions {
+	contents, ok := c.(*capabilities.Contents)
+	if !ok {
+		return ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WMTS 1.0.0 Contents`)}
+	}
+
+	gt := gfi.mapTile(*contents)
+	layer, tilematrix, exceptions := gt.validate(*contents)
+	if tilematrix.Identifier != `` {
+		exceptions = append(exceptions, checkRange(gfi.J, 0, tilematrix.TileHeight-1, J, exception.PointIJOutOfRange)...)
+		exceptions = append(exceptions, checkRange(gfi.I, 0, tilematrix.TileWidth-1, I, exception.PointIJOutOfRange)...)
+	}
+	if layer.Identifier != `` && !layer.HasInfoFormat(gfi.InfoFormat) {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gfi.InfoFormat, INFOFORMAT))
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// mapTile returns the GetTile of the map the feature info is requested for
+// A RESTful FeatureInfo has no tile Format, the map then gets the first Format of the Layer as that doesn't change the feature info
+func (gfi *GetFeatureInfo) mapTile(contents capabilities.Contents) GetTile {
+	gt := gfi.GetTile
+	if gt.Format != `` {
+		return gt
+	}
+	if layer, ok := contents.GetLayer(gt.Layer); ok && len(layer.Format) > 0 {
+		gt.Format = layer.Format[0]
+	}
+	return gt
+}
+
+// ParseOperationRequestKVP process the simple struct to a complex struct
+func (gfi *GetFeatureInfo) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Exceptions {
+	gfikvp := orkvp.(*GetFeatureInfoKVP)
+
+	exceptions := gfi.GetTile.ParseOperationRequestKVP(&gfikvp.GetTileKVP)
+	for _, p := range []struct{ key, value string }{{J, gfikvp.J}, {I, gfikvp.I}, {INFOFORMAT, gfikvp.InfoFormat}} {
+		if p.value == `` {
+			exceptions = append(exceptions, ows.MissingParameterValue(p.key))
+		}
+	}
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	gfi.XMLName.Local = getfeatureinfo
+	gfi.BaseRequest = BaseRequest{Service: gfi.GetTile.Service, Version: gfi.GetTile.Version}
+	gfi.InfoFormat = gfikvp.InfoFormat
+
+	j, err := strconv.Atoi(gfikvp.J)
+	if err != nil {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gfikvp.J, J))
+	}
+	gfi.J = j
+
+	i, err := strconv.Atoi(gfikvp.I)
+	if err != nil {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gfikvp.I, I))
+	}
+	gfi.I = i
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// ParseKVP builds a GetFeatureInfo object based on the available query parameters
+func (gfi *GetFeatureInfo) ParseKVP(query url.Values) ows.Exceptions {
+	if len(query) == 0 {
+		// When there are no query values we know that at least
+		// the mandatory SERVICE, VERSION and REQUEST parameter is missing.
+		return ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}
+	}
+
+	gfikvp := GetFeatureInfoKVP{}
+	if err := gfikvp.ParseKVP(query); err != nil {
+		return err
+	}
+
+	if err := gfi.ParseOperationRequestKVP(&gfikvp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseXML builds a GetFeatureInfo object based on a XML document
+func (gfi *GetFeatureInfo) ParseXML(body []byte) ows.Exceptions {
+	var xmlattributes ows.XMLAttribute
+	if err := xml.Unmarshal(body, &xmlattributes); err != nil {
+		return ows.Exceptions{ows.MissingParameterValue()}
+	}
+	if err := xml.Unmarshal(body, &gfi); err != nil {
+		return ows.Exceptions{ows.NoApplicableCode(err.Error())}
+	}
+	var n []xml.Attr
+	for _, a := range xmlattributes {
+		switch strings.ToUpper(a.Name.Local) {
+		case VERSION:
+		case SERVICE:
+		default:
+			n = append(n, a)
+		}
+	}
+	gfi.BaseRequest.Attr = ows.StripDuplicateAttr(n)
+	return nil
+}
+
+// BuildKVP builds a new query string that will be proxied
+func (gfi *GetFeatureInfo) BuildKVP() url.Values {
+	gfikvp := GetFeatureInfoKVP{}
+	gfikvp.ParseOperationRequest(gfi)
+
+	return gfikvp.BuildKVP()
+}
+
+// BuildXML builds a 'new' XML document 'based' on the 'original' XML document
+func (gfi *GetFeatureInfo) BuildXML() []byte {
+	si, _ := ows.CanonicalNamespaces.Marshal(gfi, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// GetFeatureInfo struct with the needed parameters/attributes needed for making a GetFeatureInfo request
+// Struct based on http://schemas.opengis.net/wmts/1.0/wmtsGetFeatureInfo_request.xsd
+type GetFeatureInfo struct {
+	XMLName xml.Name `xml:"http://www.opengis.net/wmts/1.0 GetFeatureInfo" yaml:"getfeatureinfo"`
+	BaseRequest
+	GetTile    GetTile `xml:"http://www.opengis.net/wmts/1.0 GetTile" yaml:"gettile"`
+	J          int     `xml:"http://www.opengis.net/wmts/1.0 J" yaml:"j"`
+	I          int     `xml:"http://www.opengis.net/wmts/1.0 I" yaml:"i"`
+	InfoFormat string  `xml:"http://www.opengis.net/wmts/1.0 InfoFormat" yaml:"infoformat"`
+}
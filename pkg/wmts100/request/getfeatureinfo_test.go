@@ -0,0 +1,143 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+func TestGetFeatureInfoType(t *testing.T) {
+	gfi := GetFeatureInfo{}
+	if gfi.Type() != `GetFeatureInfo` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, `GetFeatureInfo`, gfi.Type())
+	}
+}
+
+func TestGetFeatureInfoParseKVP(t *testing.T) {
+	var tests = []struct {
+		query          url.Values
+		getfeatureinfo GetFeatureInfo
+		exceptions     ows.Exceptions
+	}{
+		0: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetFeatureInfo`}, LAYER: {`ahn`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, `TIME`: {`2020`},
+			J: {`86`}, I: {`132`}, INFOFORMAT: {`application/json`}},
+			getfeatureinfo: GetFeatureInfo{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, J: 86, I: 132, InfoFormat: `application/json`,
+				GetTile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `ahn`, Style: `default`, Format: `image/png`,
+					DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}}, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 1, TileCol: 2}}},
+		1: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetFeatureInfo`}, LAYER: {`ahn`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, J: {`a`}, I: {`132`}},
+			exceptions: ows.Exceptions{ows.MissingParameterValue(INFOFORMAT)}},
+		2: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetFeatureInfo`}, LAYER: {`ahn`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, J: {`a`}, I: {`132`}, INFOFORMAT: {`text/html`}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`a`, J)}},
+		// a repeated key is an invalid value of that key
+		3: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetFeatureInfo`}, LAYER: {`ahn`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, J: {`86`}, I: {`132`, `133`}, INFOFORMAT: {`text/html`}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`132,133`, I)}},
+	}
+
+	for k, test := range tests {
+		var gfi GetFeatureInfo
+		exceptions := gfi.ParseKVP(test.query)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.getfeatureinfo.XMLName.Local = `GetFeatureInfo`
+		test.getfeatureinfo.GetTile.XMLName.Local = `GetTile`
+		if !reflect.DeepEqual(gfi, test.getfeatureinfo) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.getfeatureinfo, gfi)
+		}
+	}
+}
+
+func TestGetFeatureInfoBuildKVP(t *testing.T) {
+	gfi := GetFeatureInfo{J: 86, I: 132, InfoFormat: `application/json`,
+		GetTile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 1, TileCol: 2}}
+	expected := url.Values{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetFeatureInfo`}, LAYER: {`ahn`}, STYLE: {`default`},
+		FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, J: {`86`}, I: {`132`}, INFOFORMAT: {`application/json`}}
+
+	if query := gfi.BuildKVP(); !reflect.DeepEqual(query, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 0, expected, query)
+	}
+}
+
+func TestGetFeatureInfoXML(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<GetFeatureInfo xmlns="http://www.opengis.net/wmts/1.0" service="WMTS" version="1.0.0">
+ <GetTile service="WMTS" version="1.0.0">
+  <Layer>coastlines</Layer>
+  <Style>default</Style>
+  <Format>image/png</Format>
+  <TileMatrixSet>BigWorld</TileMatrixSet>
+  <TileMatrix>1e6</TileMatrix>
+  <TileRow>5</TileRow>
+  <TileCol>3</TileCol>
+ </GetTile>
+ <J>86</J>
+ <I>132</I>
+ <InfoFormat>application/gml+xml; version=3.1</InfoFormat>
+</GetFeatureInfo>`
+
+	var gfi GetFeatureInfo
+	if exceptions := gfi.ParseXML([]byte(doc)); exceptions != nil {
+		t.Errorf("test: %d, expected no exceptions,\n got: %v", 0, exceptions)
+		return
+	}
+	if gfi.J != 86 || gfi.I != 132 || gfi.InfoFormat != `application/gml+xml; version=3.1` || gfi.GetTile.Layer != `coastlines` || gfi.GetTile.TileRow != 5 {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 0, doc, gfi)
+	}
+
+	gfi.BaseRequest.Attr = nil
+	gfi.GetTile.BaseRequest.Attr = nil
+	if result := string(gfi.BuildXML()); result != doc {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 1, doc, result)
+	}
+}
+
+func TestGetFeatureInfoValidate(t *testing.T) {
+	var contents capabilities.Contents
+	var layer capabilities.Layer
+	layer.Identifier = `ahn`
+	layer.Style = []capabilities.Style{{Identifier: `default`}}
+	layer.Format = []string{`image/png`}
+	layer.InfoFormat = []string{`application/json`}
+	layer.TileMatrixSetLink = []capabilities.TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`}}
+	contents.Layer = []capabilities.Layer{layer}
+	contents.TileMatrixSet = []capabilities.TileMatrixSet{
+		{Identifier: `EPSG:28992`, TileMatrix: []capabilities.TileMatrix{{Identifier: `01`, MatrixWidth: 2, MatrixHeight: 2, TileWidth: 256, TileHeight: 256}}},
+	}
+
+	gettile := GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 1}
+
+	var tests = []struct {
+		getfeatureinfo GetFeatureInfo
+		exceptions     ows.Exceptions
+	}{
+		0: {getfeatureinfo: GetFeatureInfo{GetTile: gettile, J: 255, I: 0, InfoFormat: `application/json`}},
+		1: {getfeatureinfo: GetFeatureInfo{GetTile: gettile, J: 256, I: -1, InfoFormat: `text/html`},
+			exceptions: ows.Exceptions{exception.PointIJOutOfRange(256, 0, 255, J), exception.PointIJOutOfRange(-1, 0, 255, I), exception.InvalidParameterValue(`text/html`, INFOFORMAT)}},
+		2: {getfeatureinfo: GetFeatureInfo{GetTile: GetTile{Layer: `unknown`}, InfoFormat: `application/json`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`unknown`, LAYER)}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.getfeatureinfo.Validate(&contents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+	// capabilities of another type result in an exception instead of a panic
+	expected := ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WMTS 1.0.0 Contents`)}
+	if exceptions := tests[0].getfeatureinfo.Validate(nil); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 3, expected, exceptions)
+	}
+}
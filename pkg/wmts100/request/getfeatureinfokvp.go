@@ -0,0 +1,71 @@
+package request
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+// GetFeatureInfoKVP struct
+type GetFeatureInfoKVP struct {
+	// Table 28 - GetFeatureInfo request parameters
+	GetTileKVP
+	J          string `yaml:"j,omitempty"`
+	I          string `yaml:"i,omitempty"`
+	InfoFormat string `yaml:"infoformat,omitempty"`
+}
+
+// ParseKVP builds a GetFeatureInfoKVP object based on the available query parameters
+func (gfikvp *GetFeatureInfoKVP) ParseKVP(query url.Values) ows.Exceptions {
+	var exceptions ows.Exceptions
+	gettile := make(url.Values)
+	for k, v := range query {
+		if len(v) != 1 {
+			exceptions = append(exceptions, exception.InvalidParameterValue(strings.Join(v, ","), k))
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case J:
+			gfikvp.J = v[0]
+		case I:
+			gfikvp.I = v[0]
+		case INFOFORMAT:
+			gfikvp.InfoFormat = v[0]
+		default:
+			gettile[k] = v
+		}
+	}
+	exceptions = append(exceptions, gfikvp.GetTileKVP.ParseKVP(gettile)...)
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	return nil
+}
+
+// ParseOperationRequest builds a GetFeatureInfoKVP object based on a GetFeatureInfo struct
+func (gfikvp *GetFeatureInfoKVP) ParseOperationRequest(or ows.OperationRequest) ows.Exceptions {
+	gfi := or.(*GetFeatureInfo)
+
+	gfikvp.GetTileKVP.ParseOperationRequest(&gfi.GetTile)
+	gfikvp.Request = getfeatureinfo
+	gfikvp.J = strconv.Itoa(gfi.J)
+	gfikvp.I = strconv.Itoa(gfi.I)
+	gfikvp.InfoFormat = gfi.InfoFormat
+
+	return nil
+}
+
+// BuildKVP builds a url.Values query from a GetFeatureInfoKVP object
+func (gfikvp *GetFeatureInfoKVP) BuildKVP() url.Values {
+	query := gfikvp.GetTileKVP.BuildKVP()
+	query[J] = []string{gfikvp.J}
+	query[I] = []string{gfikvp.I}
+	query[INFOFORMAT] = []string{gfikvp.InfoFormat}
+
+	return query
+}
@@ -0,0 +1,244 @@
+package request
+
+import (
+	"encoding/xml"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+// GetTile
+const (
+	gettile = `GetTile`
+)
+
+// GetTile Keys
+const (
+	LAYER         = `LAYER`
+	STYLE         = `STYLE`
+	FORMAT        = `FORMAT`
+	TILEMATRIXSET = `TILEMATRIXSET`
+	TILEMATRIX    = `TILEMATRIX`
+	TILEROW       = `TILEROW`
+	TILECOL       = `TILECOL`
+)
+
+// Type returns GetTile
+func (gt *GetTile) Type() string {
+	return gettile
+}
+
+// Validate validates the GetTile against the capabilities Contents
+// Checks if the Layer is known and has the requested dimensions, is linked to the TileMatrixSet and if the tile lies
+// within the TileMatrix
+// The capabilities are a *capabilities.Contents, other capabilities result in a NoApplicableCode exception
+func (gt *GetTile) Validate(c ows.Capabilities) ows.Exceptions {
+	contents, ok := c.(*capabilities.Contents)
+	if !ok {
+		return ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WMTS 1.0.0 Contents`)}
+	}
+
+	if _, _, exceptions := gt.validate(*contents); len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// validate returns the addressed Layer and TileMatrix together with the exceptions found
+func (gt *GetTile) validate(contents capabilities.Contents) (capabilities.Layer, capabilities.TileMatrix, ows.Exceptions) {
+	layer, ok := contents.GetLayer(gt.Layer)
+	if !ok {
+		return layer, capabilities.TileMatrix{}, ows.Exceptions{exception.InvalidParameterValue(gt.Layer, LAYER)}
+	}
+
+	var exceptions ows.Exceptions
+	if _, ok := layer.GetStyle(gt.Style); !ok {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gt.Style, STYLE))
+	}
+	if !layer.HasFormat(gt.Format) {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gt.Format, FORMAT))
+	}
+	exceptions = append(exceptions, checkDimensions(layer, gt.DimensionNameValue)...)
+
+	tilematrixset, ok := contents.GetTileMatrixSet(gt.TileMatrixSet)
+	if !ok || !layer.HasTileMatrixSetLink(gt.TileMatrixSet) {
+		return layer, capabilities.TileMatrix{}, append(exceptions, exception.InvalidParameterValue(gt.TileMatrixSet, TILEMATRIXSET))
+	}
+
+	tilematrix, ok := tilematrixset.GetTileMatrix(gt.TileMatrix)
+	if !ok {
+		return layer, tilematrix, append(exceptions, exception.InvalidParameterValue(gt.TileMatrix, TILEMATRIX))
+	}
+
+	limits := capabilities.TileMatrixLimits{TileMatrix: tilematrix.Identifier, MaxTileRow: tilematrix.MatrixHeight - 1, MaxTileCol: tilematrix.MatrixWidth - 1}
+	if link, _ := layer.GetTileMatrixSetLink(gt.TileMatrixSet); link.TileMatrixSetLimits != nil {
+		// when TileMatrixSetLimits are given the Layer has no tiles in the TileMatrices that aren't listed
+		if limits, ok = link.TileMatrixSetLimits.GetTileMatrixLimits(tilematrix.Identifier); !ok {
+			return layer, capabilities.TileMatrix{}, append(exceptions, exception.InvalidParameterValue(gt.TileMatrix, TILEMATRIX))
+		}
+	}
+
+	exceptions = append(exceptions, checkRange(gt.TileRow, limits.MinTileRow, limits.MaxTileRow, TILEROW, exception.TileOutOfRange)...)
+	exceptions = append(exceptions, checkRange(gt.TileCol, limits.MinTileCol, limits.MaxTileCol, TILECOL, exception.TileOutOfRange)...)
+
+	return layer, tilematrix, exceptions
+}
+
+// checkDimensions returns an InvalidParameterValue exception for every requested dimension that isn't a Dimension of the Layer
+func checkDimensions(layer capabilities.Layer, dimensions []DimensionNameValue) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, d := range dimensions {
+		if _, ok := layer.GetDimension(d.Name); !ok {
+			exceptions = append(exceptions, exception.InvalidParameterValue(d.Value, d.Name))
+		}
+	}
+	return exceptions
+}
+
+// defaultDimensions returns the requested dimensions completed with the Default of the Dimensions of the Layer
+// that aren't requested, sorted by name
+func defaultDimensions(layer capabilities.Layer, dimensions []DimensionNameValue) []DimensionNameValue {
+	result := append([]DimensionNameValue{}, dimensions...)
+	for _, d := range layer.Dimension {
+		requested := false
+		for _, r := range dimensions {
+			if strings.EqualFold(r.Name, d.Identifier) {
+				requested = true
+			}
+		}
+		if !requested && d.Default != `` {
+			result = append(result, DimensionNameValue{Name: d.Identifier, Value: d.Default})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// checkRange checks if the given index lies between the (inclusive) min and max
+func checkRange(index, min, max int, locator string, outOfRange func(index, min, max int, locator string) exception.WMTSException) ows.Exceptions {
+	if index < min || index > max {
+		return ows.Exceptions{outOfRange(index, min, max, locator)}
+	}
+	return nil
+}
+
+// ParseOperationRequestKVP process the simple struct to a complex struct
+func (gt *GetTile) ParseOperationRequestKVP(orkvp ows.OperationRequestKVP) ows.Exceptions {
+	gtkvp := orkvp.(*GetTileKVP)
+
+	exceptions := gt.BaseRequest.Build(gtkvp.Service, gtkvp.Version)
+	exceptions = append(exceptions, gtkvp.checkMandatory()...)
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	gt.XMLName.Local = gettile
+	gt.Layer = gtkvp.Layer
+	gt.Style = gtkvp.Style
+	gt.Format = gtkvp.Format
+	gt.DimensionNameValue = gtkvp.buildDimensionNameValues()
+	gt.TileMatrixSet = gtkvp.TileMatrixSet
+	gt.TileMatrix = gtkvp.TileMatrix
+
+	row, err := strconv.Atoi(gtkvp.TileRow)
+	if err != nil {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gtkvp.TileRow, TILEROW))
+	}
+	gt.TileRow = row
+
+	col, err := strconv.Atoi(gtkvp.TileCol)
+	if err != nil {
+		exceptions = append(exceptions, exception.InvalidParameterValue(gtkvp.TileCol, TILECOL))
+	}
+	gt.TileCol = col
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// ParseKVP builds a GetTile object based on the available query parameters
+func (gt *GetTile) ParseKVP(query url.Values) ows.Exceptions {
+	if len(query) == 0 {
+		// When there are no query values we know that at least
+		// the mandatory SERVICE, VERSION and REQUEST parameter is missing.
+		return ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}
+	}
+
+	gtkvp := GetTileKVP{}
+	if err := gtkvp.ParseKVP(query); err != nil {
+		return err
+	}
+
+	if err := gt.ParseOperationRequestKVP(&gtkvp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseXML builds a GetTile object based on a XML document
+func (gt *GetTile) ParseXML(body []byte) ows.Exceptions {
+	var xmlattributes ows.XMLAttribute
+	if err := xml.Unmarshal(body, &xmlattributes); err != nil {
+		return ows.Exceptions{ows.MissingParameterValue()}
+	}
+	if err := xml.Unmarshal(body, &gt); err != nil {
+		return ows.Exceptions{ows.NoApplicableCode(err.Error())}
+	}
+	var n []xml.Attr
+	for _, a := range xmlattributes {
+		switch strings.ToUpper(a.Name.Local) {
+		case VERSION:
+		case SERVICE:
+		default:
+			n = append(n, a)
+		}
+	}
+	gt.BaseRequest.Attr = ows.StripDuplicateAttr(n)
+	return nil
+}
+
+// BuildKVP builds a new query string that will be proxied
+func (gt *GetTile) BuildKVP() url.Values {
+	gtkvp := GetTileKVP{}
+	gtkvp.ParseOperationRequest(gt)
+
+	return gtkvp.BuildKVP()
+}
+
+// BuildXML builds a 'new' XML document 'based' on the 'original' XML document
+func (gt *GetTile) BuildXML() []byte {
+	si, _ := ows.CanonicalNamespaces.Marshal(gt, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// GetTile struct with the needed parameters/attributes needed for making a GetTile request
+// Struct based on http://schemas.opengis.net/wmts/1.0/wmtsGetTile_request.xsd
+type GetTile struct {
+	XMLName xml.Name `xml:"http://www.opengis.net/wmts/1.0 GetTile" yaml:"gettile"`
+	BaseRequest
+	Layer              string               `xml:"http://www.opengis.net/wmts/1.0 Layer" yaml:"layer"`
+	Style              string               `xml:"http://www.opengis.net/wmts/1.0 Style" yaml:"style"`
+	Format             string               `xml:"http://www.opengis.net/wmts/1.0 Format" yaml:"format"`
+	DimensionNameValue []DimensionNameValue `xml:"http://www.opengis.net/wmts/1.0 DimensionNameValue" yaml:"dimensionnamevalue,omitempty"`
+	TileMatrixSet      string               `xml:"http://www.opengis.net/wmts/1.0 TileMatrixSet" yaml:"tilematrixset"`
+	TileMatrix         string               `xml:"http://www.opengis.net/wmts/1.0 TileMatrix" yaml:"tilematrix"`
+	TileRow            int                  `xml:"http://www.opengis.net/wmts/1.0 TileRow" yaml:"tilerow"`
+	TileCol            int                  `xml:"http://www.opengis.net/wmts/1.0 TileCol" yaml:"tilecol"`
+}
+
+// DimensionNameValue is the value of a Dimension that is requested
+type DimensionNameValue struct {
+	Name  string `xml:"name,attr" yaml:"name"`
+	Value string `xml:",chardata" yaml:"value"`
+}
@@ -0,0 +1,213 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+func TestGetTileType(t *testing.T) {
+	gt := GetTile{}
+	if gt.Type() != `GetTile` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 0, `GetTile`, gt.Type())
+	}
+}
+
+func TestGetTileParseKVP(t *testing.T) {
+	var tests = []struct {
+		query      url.Values
+		gettile    GetTile
+		exceptions ows.Exceptions
+	}{
+		0: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetTile`}, LAYER: {`brtachtergrondkaart`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`12`}, TILECOL: {`20`}},
+			gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`,
+				TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20}},
+		// Keys are case insensitive, unknown keys are dimensions
+		1: {query: map[string][]string{`service`: {`WMTS`}, `version`: {`1.0.0`}, `request`: {`GetTile`}, `layer`: {`ahn`}, `style`: {`default`},
+			`format`: {`image/png`}, `TileMatrixSet`: {`EPSG:28992`}, `TileMatrix`: {`05`}, `TileRow`: {`1`}, `TileCol`: {`2`}, `TIME`: {`2020-01`}, `ELEVATION`: {`10`}},
+			gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `ahn`, Style: `default`, Format: `image/png`, DimensionNameValue: []DimensionNameValue{{Name: `ELEVATION`, Value: `10`}, {Name: `TIME`, Value: `2020-01`}},
+				TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 1, TileCol: 2}},
+		2: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetTile`}, LAYER: {`ahn`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`a`}, TILECOL: {`2`}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`a`, TILEROW)}},
+		3: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetTile`}, LAYER: {`ahn`}},
+			exceptions: ows.Exceptions{ows.MissingParameterValue(STYLE), ows.MissingParameterValue(FORMAT), ows.MissingParameterValue(TILEMATRIXSET),
+				ows.MissingParameterValue(TILEMATRIX), ows.MissingParameterValue(TILEROW), ows.MissingParameterValue(TILECOL)}},
+		4: {query: map[string][]string{}, exceptions: ows.Exceptions{ows.MissingParameterValue(SERVICE), ows.MissingParameterValue(VERSION), ows.MissingParameterValue(REQUEST)}},
+		// a repeated key is an invalid value of that key
+		5: {query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetTile`}, LAYER: {`ahn`, `brt`}, STYLE: {`default`},
+			FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`ahn,brt`, LAYER)}},
+	}
+
+	for k, test := range tests {
+		var gt GetTile
+		exceptions := gt.ParseKVP(test.query)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.gettile.XMLName.Local = `GetTile`
+		if !reflect.DeepEqual(gt, test.gettile) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.gettile, gt)
+		}
+	}
+}
+
+func TestGetTileBuildKVP(t *testing.T) {
+	var tests = []struct {
+		gettile GetTile
+		query   url.Values
+	}{
+		0: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020-01`}},
+			TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 1, TileCol: 2},
+			query: map[string][]string{SERVICE: {`WMTS`}, VERSION: {`1.0.0`}, REQUEST: {`GetTile`}, LAYER: {`ahn`}, STYLE: {`default`},
+				FORMAT: {`image/png`}, TILEMATRIXSET: {`EPSG:28992`}, TILEMATRIX: {`05`}, TILEROW: {`1`}, TILECOL: {`2`}, `TIME`: {`2020-01`}}},
+	}
+
+	for k, test := range tests {
+		query := test.gettile.BuildKVP()
+		if !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
+
+func TestGetTileParseXML(t *testing.T) {
+	var tests = []struct {
+		body       []byte
+		gettile    GetTile
+		exceptions ows.Exceptions
+	}{
+		0: {body: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<GetTile service="WMTS" version="1.0.0" xmlns="http://www.opengis.net/wmts/1.0">
+ <Layer>etopo2</Layer>
+ <Style>default</Style>
+ <Format>image/png</Format>
+ <DimensionNameValue name="TIME">2007-06</DimensionNameValue>
+ <TileMatrixSet>WholeWorld_CRS_84</TileMatrixSet>
+ <TileMatrix>10m</TileMatrix>
+ <TileRow>1</TileRow>
+ <TileCol>3</TileCol>
+</GetTile>`),
+			gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `etopo2`, Style: `default`, Format: `image/png`, DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2007-06`}},
+				TileMatrixSet: `WholeWorld_CRS_84`, TileMatrix: `10m`, TileRow: 1, TileCol: 3}},
+		1: {body: []byte(`no XML document, just a string`), exceptions: ows.Exceptions{ows.MissingParameterValue()}},
+	}
+
+	for k, test := range tests {
+		var gt GetTile
+		exceptions := gt.ParseXML(test.body)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if gt.XMLName.Local != `GetTile` || gt.Layer != test.gettile.Layer || gt.Style != test.gettile.Style || gt.Format != test.gettile.Format ||
+			!reflect.DeepEqual(gt.DimensionNameValue, test.gettile.DimensionNameValue) || gt.TileMatrixSet != test.gettile.TileMatrixSet ||
+			gt.TileMatrix != test.gettile.TileMatrix || gt.TileRow != test.gettile.TileRow || gt.TileCol != test.gettile.TileCol ||
+			gt.Service != test.gettile.Service || gt.Version != test.gettile.Version {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.gettile, gt)
+		}
+	}
+}
+
+func TestGetTileBuildXML(t *testing.T) {
+	var tests = []struct {
+		gettile GetTile
+		result  string
+	}{
+		0: {gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `etopo2`, Style: `default`, Format: `image/png`, DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2007-06`}},
+			TileMatrixSet: `WholeWorld_CRS_84`, TileMatrix: `10m`, TileRow: 1, TileCol: 3},
+			result: `<?xml version="1.0" encoding="UTF-8"?>
+<GetTile xmlns="http://www.opengis.net/wmts/1.0" service="WMTS" version="1.0.0">
+ <Layer>etopo2</Layer>
+ <Style>default</Style>
+ <Format>image/png</Format>
+ <DimensionNameValue name="TIME">2007-06</DimensionNameValue>
+ <TileMatrixSet>WholeWorld_CRS_84</TileMatrixSet>
+ <TileMatrix>10m</TileMatrix>
+ <TileRow>1</TileRow>
+ <TileCol>3</TileCol>
+</GetTile>`},
+	}
+
+	for k, test := range tests {
+		result := string(test.gettile.BuildXML())
+		if result != test.result {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.result, result)
+		}
+	}
+}
+
+func TestGetTileValidate(t *testing.T) {
+	var contents capabilities.Contents
+	var layer capabilities.Layer
+	layer.Identifier = `brtachtergrondkaart`
+	layer.Style = []capabilities.Style{{Identifier: `default`}}
+	layer.Format = []string{`image/png`}
+	layer.TileMatrixSetLink = []capabilities.TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`}}
+	limited := layer
+	limited.Identifier = `luchtfoto`
+	limited.TileMatrixSetLink = []capabilities.TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`, TileMatrixSetLimits: &capabilities.TileMatrixSetLimits{
+		TileMatrixLimits: []capabilities.TileMatrixLimits{{TileMatrix: `01`, MinTileRow: 1, MaxTileRow: 1, MinTileCol: 0, MaxTileCol: 1}}}}}
+	contents.Layer = []capabilities.Layer{layer, limited}
+	contents.TileMatrixSet = []capabilities.TileMatrixSet{
+		{Identifier: `EPSG:28992`, TileMatrix: []capabilities.TileMatrix{{Identifier: `00`, MatrixWidth: 1, MatrixHeight: 1}, {Identifier: `01`, MatrixWidth: 2, MatrixHeight: 2}}},
+		{Identifier: `EPSG:3857`, TileMatrix: []capabilities.TileMatrix{{Identifier: `01`, MatrixWidth: 2, MatrixHeight: 2}}},
+	}
+
+	var tests = []struct {
+		gettile    GetTile
+		exceptions ows.Exceptions
+	}{
+		0: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 1}},
+		1: {gettile: GetTile{Layer: `unknown`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`unknown`, LAYER)}},
+		2: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `grey`, Format: `image/jpeg`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`grey`, STYLE), exception.InvalidParameterValue(`image/jpeg`, FORMAT)}},
+		// TileMatrixSet exists but isn't linked to the layer
+		3: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:3857`, TileMatrix: `01`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`EPSG:3857`, TILEMATRIXSET)}},
+		4: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `02`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`02`, TILEMATRIX)}},
+		5: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 2, TileCol: -1},
+			exceptions: ows.Exceptions{exception.TileOutOfRange(2, 0, 1, TILEROW), exception.TileOutOfRange(-1, 0, 1, TILECOL)}},
+		// Limited by the TileMatrixSetLimits of the layer
+		6: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 1}},
+		7: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 0, TileCol: 1},
+			exceptions: ows.Exceptions{exception.TileOutOfRange(0, 1, 1, TILEROW)}},
+		8: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `00`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`00`, TILEMATRIX)}},
+		9: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `00`}},
+		// the layer has no TIME Dimension
+		10: {gettile: GetTile{Layer: `brtachtergrondkaart`, Style: `default`, Format: `image/png`, DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}},
+			TileMatrixSet: `EPSG:28992`, TileMatrix: `00`},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`2020`, `TIME`)}},
+	}
+
+	for k, test := range tests {
+		exceptions := test.gettile.Validate(&contents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+	if e := exception.TileOutOfRange(2, 0, 1, TILEROW); e.Code() != `TileOutOfRange` || e.Locator() != TILEROW {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 10, `TileOutOfRange`, e.Code())
+	}
+	// capabilities of another type result in an exception instead of a panic
+	expected := ows.Exceptions{ows.NoApplicableCode(`The capabilities are not WMTS 1.0.0 Contents`)}
+	if exceptions := tests[0].gettile.Validate(nil); !reflect.DeepEqual(exceptions, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 11, expected, exceptions)
+	}
+}
@@ -0,0 +1,140 @@
+package request
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+// GetTileKVP struct
+type GetTileKVP struct {
+	// Table 23 - GetTile request parameters
+	BaseRequestKVP
+	Layer         string `yaml:"layer,omitempty"`
+	Style         string `yaml:"style,omitempty"`
+	Format        string `yaml:"format,omitempty"`
+	TileMatrixSet string `yaml:"tilematrixset,omitempty"`
+	TileMatrix    string `yaml:"tilematrix,omitempty"`
+	TileRow       string `yaml:"tilerow,omitempty"`
+	TileCol       string `yaml:"tilecol,omitempty"`
+	// Dimensions are the key-value pairs that are not a GetTile parameter, like TIME or ELEVATION
+	Dimensions map[string]string `yaml:"dimensions,omitempty"`
+}
+
+// ParseKVP builds a GetTileKVP object based on the available query parameters
+func (gtkvp *GetTileKVP) ParseKVP(query url.Values) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for k, v := range query {
+		if len(v) != 1 {
+			exceptions = append(exceptions, exception.InvalidParameterValue(strings.Join(v, ","), k))
+			continue
+		}
+		if gtkvp.BaseRequestKVP.ParseKVP(strings.ToUpper(k), v[0]) {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case LAYER:
+			gtkvp.Layer = v[0]
+		case STYLE:
+			gtkvp.Style = v[0]
+		case FORMAT:
+			gtkvp.Format = v[0]
+		case TILEMATRIXSET:
+			gtkvp.TileMatrixSet = v[0]
+		case TILEMATRIX:
+			gtkvp.TileMatrix = v[0]
+		case TILEROW:
+			gtkvp.TileRow = v[0]
+		case TILECOL:
+			gtkvp.TileCol = v[0]
+		default:
+			if gtkvp.Dimensions == nil {
+				gtkvp.Dimensions = make(map[string]string)
+			}
+			gtkvp.Dimensions[k] = v[0]
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+
+	return nil
+}
+
+// ParseOperationRequest builds a GetTileKVP object based on a GetTile struct
+func (gtkvp *GetTileKVP) ParseOperationRequest(or ows.OperationRequest) ows.Exceptions {
+	gt := or.(*GetTile)
+
+	gtkvp.Request = gettile
+	gtkvp.Version = Version
+	gtkvp.Service = Service
+	gtkvp.Layer = gt.Layer
+	gtkvp.Style = gt.Style
+	gtkvp.Format = gt.Format
+	gtkvp.TileMatrixSet = gt.TileMatrixSet
+	gtkvp.TileMatrix = gt.TileMatrix
+	gtkvp.TileRow = strconv.Itoa(gt.TileRow)
+	gtkvp.TileCol = strconv.Itoa(gt.TileCol)
+
+	gtkvp.Dimensions = nil
+	for _, d := range gt.DimensionNameValue {
+		if gtkvp.Dimensions == nil {
+			gtkvp.Dimensions = make(map[string]string)
+		}
+		gtkvp.Dimensions[d.Name] = d.Value
+	}
+
+	return nil
+}
+
+// BuildKVP builds a url.Values query from a GetTileKVP object
+func (gtkvp *GetTileKVP) BuildKVP() url.Values {
+	query := make(map[string][]string)
+	gtkvp.BaseRequestKVP.BuildKVP(query)
+	query[LAYER] = []string{gtkvp.Layer}
+	query[STYLE] = []string{gtkvp.Style}
+	query[FORMAT] = []string{gtkvp.Format}
+	query[TILEMATRIXSET] = []string{gtkvp.TileMatrixSet}
+	query[TILEMATRIX] = []string{gtkvp.TileMatrix}
+	query[TILEROW] = []string{gtkvp.TileRow}
+	query[TILECOL] = []string{gtkvp.TileCol}
+	for k, v := range gtkvp.Dimensions {
+		query[k] = []string{v}
+	}
+
+	return query
+}
+
+// buildDimensionNameValues returns the Dimensions as DimensionNameValues sorted by name
+func (gtkvp *GetTileKVP) buildDimensionNameValues() []DimensionNameValue {
+	var dimensions []DimensionNameValue
+	for k, v := range gtkvp.Dimensions {
+		dimensions = append(dimensions, DimensionNameValue{Name: k, Value: v})
+	}
+	sort.Slice(dimensions, func(i, j int) bool { return dimensions[i].Name < dimensions[j].Name })
+	return dimensions
+}
+
+// checkMandatory returns a MissingParameterValue exception for every mandatory parameter that is empty
+func (gtkvp *GetTileKVP) checkMandatory() ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, p := range []struct{ key, value string }{
+		{LAYER, gtkvp.Layer},
+		{STYLE, gtkvp.Style},
+		{FORMAT, gtkvp.Format},
+		{TILEMATRIXSET, gtkvp.TileMatrixSet},
+		{TILEMATRIX, gtkvp.TileMatrix},
+		{TILEROW, gtkvp.TileRow},
+		{TILECOL, gtkvp.TileCol},
+	} {
+		if p.value == `` {
+			exceptions = append(exceptions, ows.MissingParameterValue(p.key))
+		}
+	}
+	return exceptions
+}
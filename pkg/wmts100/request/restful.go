@@ -0,0 +1,192 @@
+package request
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+// ResourceURL template variables
+const (
+	templateLayer         = `Layer`
+	templateStyle         = `Style`
+	templateTileMatrixSet = `TileMatrixSet`
+	templateTileMatrix    = `TileMatrix`
+	templateTileRow       = `TileRow`
+	templateTileCol       = `TileCol`
+	templateJ             = `J`
+	templateI             = `I`
+)
+
+// RESOURCEURL is used as locator when no ResourceURL template matches the request
+const RESOURCEURL = `ResourceURL`
+
+// ParseRESTful builds a GetTile object by matching the URL against the tile ResourceURL templates of the layers
+// Template variables that aren't GetTile parameters are the dimensions
+func (gt *GetTile) ParseRESTful(rawurl string, c capabilities.Contents) ows.Exceptions {
+	layer, r, values, ok := matchResourceURL(rawurl, c, capabilities.ResourceTypeTile)
+	if !ok {
+		return ows.Exceptions{exception.InvalidParameterValue(rawurl, RESOURCEURL)}
+	}
+	return gt.parseTemplateValues(layer.Identifier, r.Format, values)
+}
+
+// matchResourceURL returns the Layer and ResourceURL of the given resourceType matching the URL
+func matchResourceURL(rawurl string, c capabilities.Contents, resourcetype string) (capabilities.Layer, capabilities.ResourceURL, map[string]string, bool) {
+	for _, layer := range c.Layer {
+		for _, r := range layer.ResourceURL {
+			if r.ResourceType != resourcetype {
+				continue
+			}
+			values, ok := r.Match(rawurl)
+			if !ok {
+				continue
+			}
+			if l, ok := takeValue(values, templateLayer); ok && l != layer.Identifier {
+				continue
+			}
+			return layer, r, values, true
+		}
+	}
+	return capabilities.Layer{}, capabilities.ResourceURL{}, nil, false
+}
+
+// parseTemplateValues fills the GetTile with the matched template values
+func (gt *GetTile) parseTemplateValues(layer, format string, values map[string]string) ows.Exceptions {
+	gt.XMLName.Local = gettile
+	gt.Service = Service
+	gt.Version = Version
+	gt.Layer = layer
+	gt.Format = format
+	gt.Style, _ = takeValue(values, templateStyle)
+	gt.TileMatrixSet, _ = takeValue(values, templateTileMatrixSet)
+	gt.TileMatrix, _ = takeValue(values, templateTileMatrix)
+
+	var exceptions ows.Exceptions
+	for _, t := range []struct {
+		variable, locator string
+		index             *int
+	}{
+		{templateTileRow, TILEROW, &gt.TileRow},
+		{templateTileCol, TILECOL, &gt.TileCol},
+	} {
+		v, _ := takeValue(values, t.variable)
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			exceptions = append(exceptions, exception.InvalidParameterValue(v, t.locator))
+		}
+		*t.index = i
+	}
+
+	gt.DimensionNameValue = nil
+	for k, v := range values {
+		gt.DimensionNameValue = append(gt.DimensionNameValue, DimensionNameValue{Name: k, Value: v})
+	}
+	sort.Slice(gt.DimensionNameValue, func(i, j int) bool { return gt.DimensionNameValue[i].Name < gt.DimensionNameValue[j].Name })
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// BuildRESTful builds the URL of the tile by expanding the tile ResourceURL template of the layer for the requested format
+func (gt *GetTile) BuildRESTful(c capabilities.Contents) (string, ows.Exceptions) {
+	return expandResourceURL(c, gt.Layer, capabilities.ResourceTypeTile, gt.Format, FORMAT, gt.templateValues(), gt.DimensionNameValue)
+}
+
+// templateValues returns the values for the template variables of the GetTile, the dimensions are added by expandResourceURL
+func (gt *GetTile) templateValues() map[string]string {
+	values := map[string]string{
+		templateLayer:         gt.Layer,
+		templateStyle:         gt.Style,
+		templateTileMatrixSet: gt.TileMatrixSet,
+		templateTileMatrix:    gt.TileMatrix,
+		templateTileRow:       strconv.Itoa(gt.TileRow),
+		templateTileCol:       strconv.Itoa(gt.TileCol),
+	}
+	return values
+}
+
+// ParseRESTful builds a GetFeatureInfo object by matching the URL against the FeatureInfo ResourceURL templates of the layers
+// The Format of the GetTile isn't part of the template, so it's left empty
+func (gfi *GetFeatureInfo) ParseRESTful(rawurl string, c capabilities.Contents) ows.Exceptions {
+	layer, r, values, ok := matchResourceURL(rawurl, c, capabilities.ResourceTypeFeatureInfo)
+	if !ok {
+		return ows.Exceptions{exception.InvalidParameterValue(rawurl, RESOURCEURL)}
+	}
+
+	gfi.XMLName.Local = getfeatureinfo
+	gfi.BaseRequest = BaseRequest{Service: Service, Version: Version}
+	gfi.InfoFormat = r.Format
+
+	var exceptions ows.Exceptions
+	for _, t := range []struct {
+		variable, locator string
+		index             *int
+	}{
+		{templateJ, J, &gfi.J},
+		{templateI, I, &gfi.I},
+	} {
+		v, _ := takeValue(values, t.variable)
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			exceptions = append(exceptions, exception.InvalidParameterValue(v, t.locator))
+		}
+		*t.index = i
+	}
+	exceptions = append(exceptions, gfi.GetTile.parseTemplateValues(layer.Identifier, ``, values)...)
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
+	return nil
+}
+
+// BuildRESTful builds the URL of the FeatureInfo by expanding the FeatureInfo ResourceURL template of the layer for the requested InfoFormat
+func (gfi *GetFeatureInfo) BuildRESTful(c capabilities.Contents) (string, ows.Exceptions) {
+	values := gfi.GetTile.templateValues()
+	values[templateJ] = strconv.Itoa(gfi.J)
+	values[templateI] = strconv.Itoa(gfi.I)
+	return expandResourceURL(c, gfi.GetTile.Layer, capabilities.ResourceTypeFeatureInfo, gfi.InfoFormat, INFOFORMAT, values, gfi.GetTile.DimensionNameValue)
+}
+
+// expandResourceURL expands the ResourceURL template of the layer for the given resourceType and format
+// The dimensions need to be Dimensions of the layer, the Dimensions that aren't requested get their Default value
+func expandResourceURL(c capabilities.Contents, identifier, resourcetype, format, locator string, values map[string]string, dimensions []DimensionNameValue) (string, ows.Exceptions) {
+	layer, ok := c.GetLayer(identifier)
+	if !ok {
+		return ``, ows.Exceptions{exception.InvalidParameterValue(identifier, LAYER)}
+	}
+	if exceptions := checkDimensions(layer, dimensions); exceptions != nil {
+		return ``, exceptions
+	}
+	for _, d := range defaultDimensions(layer, dimensions) {
+		values[d.Name] = d.Value
+	}
+	r, ok := layer.GetResourceURL(resourcetype, format)
+	if !ok {
+		return ``, ows.Exceptions{exception.InvalidParameterValue(format, locator)}
+	}
+
+	u, err := r.Expand(values)
+	if err != nil {
+		return ``, ows.Exceptions{ows.NoApplicableCode(err.Error())}
+	}
+	return u, nil
+}
+
+// takeValue returns and removes the value of the case insensitive template variable
+func takeValue(values map[string]string, variable string) (string, bool) {
+	for k, v := range values {
+		if strings.EqualFold(k, variable) {
+			delete(values, k)
+			return v, true
+		}
+	}
+	return ``, false
+}
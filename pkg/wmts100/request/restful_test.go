@@ -0,0 +1,149 @@
+package request
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+var restfulContents = capabilities.Contents{Layer: []capabilities.Layer{
+	{Identifier: `brtachtergrondkaart`, ResourceURL: []capabilities.ResourceURL{
+		{Format: `image/png`, ResourceType: capabilities.ResourceTypeTile, Template: `https://service.pdok.nl/brt/wmts/{Layer}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.png`},
+		{Format: `image/jpeg`, ResourceType: capabilities.ResourceTypeTile, Template: `https://service.pdok.nl/brt/wmts/{Layer}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.jpeg`},
+	}},
+	{Identifier: `luchtfoto`, Format: []string{`image/jpeg`}, Dimension: []capabilities.Dimension{{Identifier: `TIME`, Value: []string{`2019`, `2020`}}}, ResourceURL: []capabilities.ResourceURL{
+		{Format: `image/jpeg`, ResourceType: capabilities.ResourceTypeTile, Template: `https://service.pdok.nl/luchtfoto/wmts/{Style}/{TIME}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}.jpeg`},
+		{Format: `application/json`, ResourceType: capabilities.ResourceTypeFeatureInfo, Template: `https://service.pdok.nl/luchtfoto/wmts/{Style}/{TIME}/{TileMatrixSet}/{TileMatrix}/{TileCol}/{TileRow}/{J}/{I}.json`},
+	}},
+}}
+
+func TestGetTileParseRESTful(t *testing.T) {
+	var tests = []struct {
+		url        string
+		gettile    GetTile
+		exceptions ows.Exceptions
+	}{
+		0: {url: `/brt/wmts/brtachtergrondkaart/EPSG:28992/05/20/12.png`,
+			gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `brtachtergrondkaart`, Format: `image/png`,
+				TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20}},
+		1: {url: `https://service.pdok.nl/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12.jpeg`,
+			gettile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `luchtfoto`, Style: `default`, Format: `image/jpeg`,
+				DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}}, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20}},
+		// {Layer} in the template doesn't match a layer identifier
+		2: {url: `/brt/wmts/unknown/EPSG:28992/05/20/12.png`, exceptions: ows.Exceptions{exception.InvalidParameterValue(`/brt/wmts/unknown/EPSG:28992/05/20/12.png`, RESOURCEURL)}},
+		3: {url: `/brt/wmts/brtachtergrondkaart/EPSG:28992/05/a/12.png`, exceptions: ows.Exceptions{exception.InvalidParameterValue(`a`, TILECOL)}},
+		// FeatureInfo templates are ignored
+		4: {url: `/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12/1/2.json`,
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12/1/2.json`, RESOURCEURL)}},
+	}
+
+	for k, test := range tests {
+		var gt GetTile
+		exceptions := gt.ParseRESTful(test.url, restfulContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.gettile.XMLName.Local = `GetTile`
+		if !reflect.DeepEqual(gt, test.gettile) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.gettile, gt)
+		}
+	}
+}
+
+func TestGetTileBuildRESTful(t *testing.T) {
+	var tests = []struct {
+		gettile    GetTile
+		url        string
+		exceptions ows.Exceptions
+	}{
+		0: {gettile: GetTile{Layer: `brtachtergrondkaart`, Format: `image/jpeg`, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20},
+			url: `https://service.pdok.nl/brt/wmts/brtachtergrondkaart/EPSG:28992/05/20/12.jpeg`},
+		1: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/jpeg`, DimensionNameValue: []DimensionNameValue{{Name: `time`, Value: `2020`}},
+			TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20},
+			url: `https://service.pdok.nl/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12.jpeg`},
+		2: {gettile: GetTile{Layer: `unknown`}, exceptions: ows.Exceptions{exception.InvalidParameterValue(`unknown`, LAYER)}},
+		3: {gettile: GetTile{Layer: `luchtfoto`, Format: `image/png`}, exceptions: ows.Exceptions{exception.InvalidParameterValue(`image/png`, FORMAT)}},
+		4: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/jpeg`, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`},
+			exceptions: ows.Exceptions{ows.NoApplicableCode(`no value for template variable(s): TIME`)}},
+		5: {gettile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/jpeg`, DimensionNameValue: []DimensionNameValue{{Name: `time`, Value: `2020`}, {Name: `foo`, Value: `bar`}},
+			TileMatrixSet: `EPSG:28992`, TileMatrix: `05`}, exceptions: ows.Exceptions{exception.InvalidParameterValue(`bar`, `foo`)}},
+	}
+
+	for k, test := range tests {
+		url, exceptions := test.gettile.BuildRESTful(restfulContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if url != test.url {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.url, url)
+		}
+	}
+}
+
+func TestGetFeatureInfoParseRESTful(t *testing.T) {
+	var tests = []struct {
+		url            string
+		getfeatureinfo GetFeatureInfo
+		exceptions     ows.Exceptions
+	}{
+		0: {url: `/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12/1/2.json`,
+			getfeatureinfo: GetFeatureInfo{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, J: 1, I: 2, InfoFormat: `application/json`,
+				GetTile: GetTile{BaseRequest: BaseRequest{Service: `WMTS`, Version: `1.0.0`}, Layer: `luchtfoto`, Style: `default`,
+					DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}}, TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20}}},
+		1: {url: `/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12/j/2.json`, exceptions: ows.Exceptions{exception.InvalidParameterValue(`j`, J)}},
+		// Tile templates are ignored
+		2: {url: `/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12.jpeg`,
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12.jpeg`, RESOURCEURL)}},
+	}
+
+	for k, test := range tests {
+		var gfi GetFeatureInfo
+		exceptions := gfi.ParseRESTful(test.url, restfulContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		test.getfeatureinfo.XMLName.Local = `GetFeatureInfo`
+		test.getfeatureinfo.GetTile.XMLName.Local = `GetTile`
+		if !reflect.DeepEqual(gfi, test.getfeatureinfo) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.getfeatureinfo, gfi)
+		}
+	}
+}
+
+func TestGetFeatureInfoBuildRESTful(t *testing.T) {
+	var tests = []struct {
+		getfeatureinfo GetFeatureInfo
+		url            string
+		exceptions     ows.Exceptions
+	}{
+		0: {getfeatureinfo: GetFeatureInfo{J: 1, I: 2, InfoFormat: `application/json`,
+			GetTile: GetTile{Layer: `luchtfoto`, Style: `default`, Format: `image/jpeg`, DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}},
+				TileMatrixSet: `EPSG:28992`, TileMatrix: `05`, TileRow: 12, TileCol: 20}},
+			url: `https://service.pdok.nl/luchtfoto/wmts/default/2020/EPSG:28992/05/20/12/1/2.json`},
+		1: {getfeatureinfo: GetFeatureInfo{InfoFormat: `text/html`, GetTile: GetTile{Layer: `luchtfoto`}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`text/html`, INFOFORMAT)}},
+	}
+
+	for k, test := range tests {
+		url, exceptions := test.getfeatureinfo.BuildRESTful(restfulContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if url != test.url {
+			t.Errorf("test: %d, expected: %s,\n got: %s", k, test.url, url)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	wms130 "github.com/pdok/ogc-specifications/pkg/wms130/request"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+)
+
+// sldVersion of the StyledLayerDescriptor in the WMS requests
+const sldVersion = `1.1.0`
+
+// BuildGetMap builds the WMS 1.3.0 GetMap request that renders the requested tile
+// The BBOX is the extent of the tile and the WIDTH and HEIGHT are the tile size of the TileMatrix.
+// The default Style of the Layer is requested as the default WMS style, the dimensions become TIME, ELEVATION or DIM_<name>.
+// Dimensions of the Layer that aren't requested get their Default value.
+func (gt *GetTile) BuildGetMap(c capabilities.Contents) (wms130.GetMap, ows.Exceptions) {
+	layer, tilematrix, exceptions := gt.validate(c)
+	if len(exceptions) > 0 {
+		return wms130.GetMap{}, exceptions
+	}
+	tilematrixset, _ := c.GetTileMatrixSet(gt.TileMatrixSet)
+
+	var gm wms130.GetMap
+	gm.XMLName.Local = `GetMap`
+	gm.BaseRequest = wms130.BaseRequest{Service: wms130.Service, Version: wms130.Version}
+	gm.StyledLayerDescriptor = wms130.StyledLayerDescriptor{
+		Version:    sldVersion,
+		NamedLayer: []wms130.NamedLayer{{Name: layer.Identifier, NamedStyle: &wms130.NamedStyle{Name: wmsStyle(layer, gt.Style)}}},
+	}
+	crs, exception := wmsCRS(tilematrixset.SupportedCRS)
+	if exception != nil {
+		return wms130.GetMap{}, ows.Exceptions{exception}
+	}
+	gm.CRS = crs
+	gm.BoundingBox = tilematrixset.TileBoundingBox(tilematrix, gt.TileRow, gt.TileCol)
+	gm.Output = wms130.Output{Size: wms130.Size{Width: tilematrix.TileWidth, Height: tilematrix.TileHeight}, Format: gt.Format}
+	gm.Dimensions = wmsDimensions(defaultDimensions(layer, gt.DimensionNameValue))
+
+	return gm, nil
+}
+
+// BuildGetFeatureInfo builds the WMS 1.3.0 GetFeatureInfo request for the point in the requested tile
+// The map part of the request is the GetMap of the tile, the Layer is the only QUERY_LAYER
+// Without a tile Format, like a RESTful FeatureInfo, the map is requested in the first Format of the Layer
+func (gfi *GetFeatureInfo) BuildGetFeatureInfo(c capabilities.Contents) (wms130.GetFeatureInfo, ows.Exceptions) {
+	if exceptions := gfi.Validate(&c); exceptions != nil {
+		return wms130.GetFeatureInfo{}, exceptions
+	}
+	gt := gfi.mapTile(c)
+	gm, exceptions := gt.BuildGetMap(c)
+	if exceptions != nil {
+		return wms130.GetFeatureInfo{}, exceptions
+	}
+
+	infoformat := gfi.InfoFormat
+
+	var wmsgfi wms130.GetFeatureInfo
+	wmsgfi.XMLName.Local = `GetFeatureInfo`
+	wmsgfi.BaseRequest = gm.BaseRequest
+	wmsgfi.StyledLayerDescriptor = gm.StyledLayerDescriptor
+	wmsgfi.CRS = gm.CRS.String()
+	wmsgfi.BoundingBox = gm.BoundingBox
+	wmsgfi.Size = gm.Output.Size
+	wmsgfi.Format = gm.Output.Format
+	wmsgfi.QueryLayers = []string{gfi.GetTile.Layer}
+	wmsgfi.I = gfi.I
+	wmsgfi.J = gfi.J
+	wmsgfi.InfoFormat = &infoformat
+	wmsgfi.Dimensions = gm.Dimensions
+
+	return wmsgfi, nil
+}
+
+// wmsStyle returns the WMS style for the Style of the Layer, the default Style is the empty WMS style
+func wmsStyle(layer capabilities.Layer, style string) string {
+	if d, ok := layer.DefaultStyle(); ok && d.Identifier == style {
+		return ``
+	}
+	return style
+}
+
+// wmsCRS returns the SupportedCRS of the TileMatrixSet as WMS CRS
+func wmsCRS(supportedcrs string) (ows.CRS, ows.Exception) {
+	var crs ows.CRS
+	if exception := crs.ParseString(supportedcrs); exception != nil {
+		return ows.CRS{}, exception
+	}
+	return crs, nil
+}
+
+// wmsDimensions returns the dimensions with the WMS keys
+// TIME and ELEVATION keep their name, the other dimensions become sample dimensions with the DIM_ prefix
+func wmsDimensions(dimensions []DimensionNameValue) map[string]string {
+	if len(dimensions) == 0 {
+		return nil
+	}
+	wmsdimensions := make(map[string]string, len(dimensions))
+	for _, d := range dimensions {
+		switch name := strings.ToUpper(d.Name); name {
+		case wms130.TIME, wms130.ELEVATION:
+			wmsdimensions[name] = d.Value
+		default:
+			wmsdimensions[wms130.DIMPREFIX+name] = d.Value
+		}
+	}
+	return wmsdimensions
+}
@@ -0,0 +1,111 @@
+package request
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	wms130 "github.com/pdok/ogc-specifications/pkg/wms130/request"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/exception"
+)
+
+var wmsContents = capabilities.Contents{
+	Layer: []capabilities.Layer{
+		{Identifier: `ahn`, Style: []capabilities.Style{{Identifier: `default`, IsDefault: true}, {Identifier: `grey`}},
+			Format: []string{`image/jpeg`, `image/png`}, InfoFormat: []string{`application/json`},
+			Dimension:         []capabilities.Dimension{{Identifier: `Time`, Default: `2021`, Value: []string{`2020`, `2021`}}, {Identifier: `Wavelength`, Value: []string{`1200`}}},
+			TileMatrixSetLink: []capabilities.TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`}, {TileMatrixSet: `WorldCRS84Quad`}, {TileMatrixSet: `Unknown`}}},
+	},
+	TileMatrixSet: []capabilities.TileMatrixSet{
+		{Identifier: `EPSG:28992`, SupportedCRS: `urn:ogc:def:crs:EPSG::28992`, TileMatrix: []capabilities.TileMatrix{
+			{Identifier: `01`, ScaleDenominator: 6144000, TopLeftCorner: ows.Position{-285401.92, 903401.92}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 2}}},
+		{Identifier: `WorldCRS84Quad`, SupportedCRS: `urn:ogc:def:crs:OGC:1.3:CRS84`, TileMatrix: []capabilities.TileMatrix{
+			{Identifier: `0`, ScaleDenominator: 279541132.0143589, TopLeftCorner: ows.Position{-180, 90}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 1}}},
+		{Identifier: `Unknown`, SupportedCRS: `garbage`, TileMatrix: []capabilities.TileMatrix{
+			{Identifier: `0`, ScaleDenominator: 279541132.0143589, TopLeftCorner: ows.Position{-180, 90}, TileWidth: 256, TileHeight: 256, MatrixWidth: 2, MatrixHeight: 1}}},
+	},
+}
+
+func TestGetTileBuildGetMap(t *testing.T) {
+	var tests = []struct {
+		gettile    GetTile
+		query      url.Values
+		exceptions ows.Exceptions
+	}{
+		0: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 0,
+			DimensionNameValue: []DimensionNameValue{{Name: `time`, Value: `2020`}, {Name: `Wavelength`, Value: `1200`}}},
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`ahn`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`-285401.920000,22598.080000,155000.000000,463000.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`256`},
+				wms130.FORMAT: {`image/png`}, wms130.TIME: {`2020`}, `DIM_WAVELENGTH`: {`1200`}}},
+		1: {gettile: GetTile{Layer: `ahn`, Style: `grey`, Format: `image/png`, TileMatrixSet: `WorldCRS84Quad`, TileMatrix: `0`, TileRow: 0, TileCol: 1},
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`ahn`}, wms130.STYLES: {`grey`},
+				wms130.CRS: {`CRS:84`}, wms130.BBOX: {`0.000000,-90.000000,180.000000,90.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`256`},
+				wms130.FORMAT: {`image/png`}, wms130.TIME: {`2021`}}},
+		2: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 2},
+			exceptions: ows.Exceptions{exception.TileOutOfRange(2, 0, 1, TILEROW)}},
+		3: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `Unknown`, TileMatrix: `0`},
+			exceptions: ows.Exceptions{ows.InvalidParameterValue(`garbage`, `crs`)}},
+		// the Default of a Dimension that isn't requested is used
+		4: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 0},
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetMap`}, wms130.LAYERS: {`ahn`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`-285401.920000,22598.080000,155000.000000,463000.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`256`},
+				wms130.FORMAT: {`image/png`}, wms130.TIME: {`2021`}}},
+		5: {gettile: GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 0,
+			DimensionNameValue: []DimensionNameValue{{Name: `junk`, Value: `1`}}},
+			exceptions: ows.Exceptions{exception.InvalidParameterValue(`1`, `junk`)}},
+	}
+
+	for k, test := range tests {
+		gm, exceptions := test.gettile.BuildGetMap(wmsContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if query := gm.BuildKVP(); !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
+
+func TestGetFeatureInfoBuildGetFeatureInfo(t *testing.T) {
+	gettile := GetTile{Layer: `ahn`, Style: `default`, Format: `image/png`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 0,
+		DimensionNameValue: []DimensionNameValue{{Name: `TIME`, Value: `2020`}}}
+
+	var tests = []struct {
+		getfeatureinfo GetFeatureInfo
+		query          url.Values
+		exceptions     ows.Exceptions
+	}{
+		0: {getfeatureinfo: GetFeatureInfo{GetTile: gettile, J: 86, I: 132, InfoFormat: `application/json`},
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetFeatureInfo`}, wms130.LAYERS: {`ahn`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`-285401.920000,22598.080000,155000.000000,463000.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`256`},
+				wms130.FORMAT: {`image/png`}, wms130.QUERYLAYERS: {`ahn`}, wms130.INFOFORMAT: {`application/json`}, wms130.I: {`132`}, wms130.J: {`86`}, wms130.TIME: {`2020`}}},
+		1: {getfeatureinfo: GetFeatureInfo{GetTile: gettile, J: 256, I: 0, InfoFormat: `text/html`},
+			exceptions: ows.Exceptions{exception.PointIJOutOfRange(256, 0, 255, J), exception.InvalidParameterValue(`text/html`, INFOFORMAT)}},
+		// without a tile Format, like a RESTful FeatureInfo, the map gets the first Format of the Layer
+		2: {getfeatureinfo: GetFeatureInfo{GetTile: GetTile{Layer: `ahn`, Style: `default`, TileMatrixSet: `EPSG:28992`, TileMatrix: `01`, TileRow: 1, TileCol: 0},
+			J: 86, I: 132, InfoFormat: `application/json`},
+			query: url.Values{wms130.SERVICE: {`WMS`}, wms130.VERSION: {`1.3.0`}, wms130.REQUEST: {`GetFeatureInfo`}, wms130.LAYERS: {`ahn`}, wms130.STYLES: {``},
+				wms130.CRS: {`EPSG:28992`}, wms130.BBOX: {`-285401.920000,22598.080000,155000.000000,463000.000000`}, wms130.WIDTH: {`256`}, wms130.HEIGHT: {`256`},
+				wms130.FORMAT: {`image/jpeg`}, wms130.QUERYLAYERS: {`ahn`}, wms130.INFOFORMAT: {`application/json`}, wms130.I: {`132`}, wms130.J: {`86`}, wms130.TIME: {`2021`}}},
+	}
+
+	for k, test := range tests {
+		gfi, exceptions := test.getfeatureinfo.BuildGetFeatureInfo(wmsContents)
+		if !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+			continue
+		}
+		if exceptions != nil {
+			continue
+		}
+		if query := gfi.BuildKVP(); !reflect.DeepEqual(query, test.query) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.query, query)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package response
 
 import (
 	"encoding/xml"
-	"regexp"
+	"fmt"
 
 	"github.com/pdok/ogc-specifications/pkg/ows"
 	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
@@ -19,6 +19,16 @@ const (
 	Version = `1.0.0`
 )
 
+// GetEncoding is the name of the Constraint on the DCP of an Operation that lists the supported encodings
+const GetEncoding = `GetEncoding`
+
+// Encodings of the WMTS operations
+const (
+	KVP     = `KVP`
+	RESTful = `RESTful`
+	SOAP    = `SOAP`
+)
+
 // Type function needed for the interface
 func (gc *GetCapabilities) Type() string {
 	return getcapabilities
@@ -34,25 +44,55 @@ func (gc *GetCapabilities) Version() string {
 	return Version
 }
 
-// Validate function of the wfs200 spec
+// Validate function of the wmts100 spec
+// Checks if the Layers are linked to known TileMatrixSets and if the Themes refer to known Layers
 func (gc *GetCapabilities) Validate() ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, l := range gc.Contents.Layer {
+		for _, t := range l.TileMatrixSetLink {
+			if _, ok := gc.Contents.GetTileMatrixSet(t.TileMatrixSet); !ok {
+				exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("Layer %s is linked to the unknown TileMatrixSet %s", l.Identifier, t.TileMatrixSet)))
+			}
+		}
+	}
+	if gc.Themes != nil {
+		for _, t := range gc.Themes.Theme {
+			exceptions = append(exceptions, t.validate(gc.Contents)...)
+		}
+	}
+
+	if len(exceptions) > 0 {
+		return exceptions
+	}
 	return nil
 }
 
 // BuildXML builds a GetCapabilities response object
 func (gc *GetCapabilities) BuildXML() []byte {
-	si, _ := xml.MarshalIndent(gc, "", "")
-	re := regexp.MustCompile(`><.*>`)
-	return []byte(xml.Header + re.ReplaceAllString(string(si), "/>"))
+	si, _ := ows.CanonicalNamespaces.Marshal(gc, "", " ")
+	return append([]byte(xml.Header), si...)
+}
+
+// ParseXML reads a WMTS Capabilities document
+func (gc *GetCapabilities) ParseXML(doc []byte) error {
+	var capabilities GetCapabilities
+	if err := xml.Unmarshal(doc, &capabilities); err != nil {
+		return err
+	}
+	*gc = capabilities
+	return nil
 }
 
 // GetCapabilities base struct
 type GetCapabilities struct {
-	XMLName               xml.Name `xml:"Capabilities"`
+	XMLName               xml.Name `xml:"http://www.opengis.net/wmts/1.0 Capabilities"`
 	Namespaces            `yaml:"namespaces"`
-	ServiceIdentification ServiceIdentification `xml:"ows:ServiceIdentification" yaml:"serviceidentification"`
-	Contents              capabilities.Contents `xml:"Contents" yaml:"contents"`
-	ServiceMetadataURL    ServiceMetadataURL    `xml:"ServiceMetadataURL" yaml:"servicemetadataurl"`
+	ServiceIdentification ServiceIdentification `xml:"http://www.opengis.net/ows/1.1 ServiceIdentification" yaml:"serviceidentification"`
+	ServiceProvider       *ServiceProvider      `xml:"http://www.opengis.net/ows/1.1 ServiceProvider" yaml:"serviceprovider,omitempty"`
+	OperationsMetadata    *OperationsMetadata   `xml:"http://www.opengis.net/ows/1.1 OperationsMetadata" yaml:"operationsmetadata,omitempty"`
+	Contents              capabilities.Contents `xml:"http://www.opengis.net/wmts/1.0 Contents" yaml:"contents"`
+	Themes                *Themes               `xml:"http://www.opengis.net/wmts/1.0 Themes" yaml:"themes,omitempty"`
+	ServiceMetadataURL    ServiceMetadataURL    `xml:"http://www.opengis.net/wmts/1.0 ServiceMetadataURL" yaml:"servicemetadataurl"`
 }
 
 // Namespaces struct containing the namespaces needed for the XML document
@@ -63,20 +103,142 @@ type Namespaces struct {
 	XmlnsXSI       string `xml:"xmlns:xsi,attr" yaml:"xsi"`     //http://www.w3.org/2001/XMLSchema-instance
 	XmlnsGml       string `xml:"xmlns:gml,attr" yaml:"gml"`     //http://www.opengis.net/gml
 	Version        string `xml:"version,attr" yaml:"version"`
-	SchemaLocation string `xml:"xsi:schemaLocation,attr" yaml:"schemalocation"`
+	SchemaLocation string `xml:"http://www.w3.org/2001/XMLSchema-instance schemaLocation,attr" yaml:"schemalocation"`
 }
 
 // ServiceIdentification struct should only be fill by the "template" configuration wmts100.yaml
 type ServiceIdentification struct {
-	Title              string `xml:"ows:Title" yaml:"title"`
-	Abstract           string `xml:"ows:Abstract" yaml:"abstract"`
-	ServiceType        string `xml:"ows:ServiceType" yaml:"servicetype"`
-	ServiceTypeVersion string `xml:"ows:ServiceTypeVersion" yaml:"servicetypeversion"`
-	Fees               string `xml:"ows:Fees" yaml:"fees"`
-	AccessConstraints  string `xml:"ows:AccessConstraints" yaml:"accessconstraints"`
+	Title              string        `xml:"http://www.opengis.net/ows/1.1 Title" yaml:"title"`
+	Abstract           string        `xml:"http://www.opengis.net/ows/1.1 Abstract" yaml:"abstract"`
+	Keywords           *ows.Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords,omitempty"`
+	ServiceType        string        `xml:"http://www.opengis.net/ows/1.1 ServiceType" yaml:"servicetype"`
+	ServiceTypeVersion string        `xml:"http://www.opengis.net/ows/1.1 ServiceTypeVersion" yaml:"servicetypeversion"`
+	Fees               string        `xml:"http://www.opengis.net/ows/1.1 Fees" yaml:"fees"`
+	AccessConstraints  string        `xml:"http://www.opengis.net/ows/1.1 AccessConstraints" yaml:"accessconstraints"`
+}
+
+// ServiceProvider struct containing the provider/organization information should only be fill by the "template" configuration wmts100.yaml
+type ServiceProvider struct {
+	ProviderName string `xml:"http://www.opengis.net/ows/1.1 ProviderName" yaml:"providername"`
+	ProviderSite struct {
+		Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+		Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	} `xml:"http://www.opengis.net/ows/1.1 ProviderSite" yaml:"providersite"`
+	ServiceContact struct {
+		IndividualName string `xml:"http://www.opengis.net/ows/1.1 IndividualName" yaml:"individualname"`
+		PositionName   string `xml:"http://www.opengis.net/ows/1.1 PositionName" yaml:"positionname"`
+		ContactInfo    struct {
+			Phone struct {
+				Voice     string `xml:"http://www.opengis.net/ows/1.1 Voice" yaml:"voice"`
+				Facsimile string `xml:"http://www.opengis.net/ows/1.1 Facsimile" yaml:"facsmile"`
+			} `xml:"http://www.opengis.net/ows/1.1 Phone" yaml:"phone"`
+			Address struct {
+				DeliveryPoint         string `xml:"http://www.opengis.net/ows/1.1 DeliveryPoint" yaml:"deliverypoint"`
+				City                  string `xml:"http://www.opengis.net/ows/1.1 City" yaml:"city"`
+				AdministrativeArea    string `xml:"http://www.opengis.net/ows/1.1 AdministrativeArea" yaml:"administrativearea"`
+				PostalCode            string `xml:"http://www.opengis.net/ows/1.1 PostalCode" yaml:"postalcode"`
+				Country               string `xml:"http://www.opengis.net/ows/1.1 Country" yaml:"country"`
+				ElectronicMailAddress string `xml:"http://www.opengis.net/ows/1.1 ElectronicMailAddress" yaml:"electronicmailaddress"`
+			} `xml:"http://www.opengis.net/ows/1.1 Address" yaml:"address"`
+			OnlineResource struct {
+				Type string `xml:"http://www.w3.org/1999/xlink type,attr" yaml:"type"`
+				Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+			} `xml:"http://www.opengis.net/ows/1.1 OnlineResource" yaml:"onlineresource"`
+			HoursOfService      string `xml:"http://www.opengis.net/ows/1.1 HoursOfService" yaml:"hoursofservice"`
+			ContactInstructions string `xml:"http://www.opengis.net/ows/1.1 ContactInstructions" yaml:"contactinstructions"`
+		} `xml:"http://www.opengis.net/ows/1.1 ContactInfo" yaml:"contactinfo"`
+		Role string `xml:"http://www.opengis.net/ows/1.1 Role" yaml:"role"`
+	} `xml:"http://www.opengis.net/ows/1.1 ServiceContact" yaml:"servicecontact"`
+}
+
+// OperationsMetadata struct for the WMTS 1.0.0
+type OperationsMetadata struct {
+	Operation []Operation `xml:"http://www.opengis.net/ows/1.1 Operation" yaml:"operation"`
+}
+
+// GetOperation returns the Operation with the given name
+func (om OperationsMetadata) GetOperation(name string) (Operation, bool) {
+	for _, o := range om.Operation {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return Operation{}, false
+}
+
+// Operation struct for the WMTS 1.0.0
+type Operation struct {
+	Name string `xml:"name,attr" yaml:"name"`
+	DCP  struct {
+		HTTP struct {
+			Get  []Method `xml:"http://www.opengis.net/ows/1.1 Get" yaml:"get,omitempty"`
+			Post []Method `xml:"http://www.opengis.net/ows/1.1 Post" yaml:"post,omitempty"`
+		} `xml:"http://www.opengis.net/ows/1.1 HTTP" yaml:"http"`
+	} `xml:"http://www.opengis.net/ows/1.1 DCP" yaml:"dcp"`
+}
+
+// GetEncodings returns the encodings the Operation supports for HTTP Get requests, like KVP and RESTful
+// An URL without GetEncoding Constraint supports all encodings, and is left out
+func (o Operation) GetEncodings() []string {
+	var encodings []string
+	for _, m := range o.DCP.HTTP.Get {
+		for _, c := range m.Constraint {
+			if c.Name == GetEncoding {
+				encodings = append(encodings, c.AllowedValues.Value...)
+			}
+		}
+	}
+	return encodings
+}
+
+// Method is an URL of the DCP with the Constraints that apply to it
+type Method struct {
+	Type       string       `xml:"http://www.w3.org/1999/xlink type,attr,omitempty" yaml:"type,omitempty"`
+	Href       string       `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
+	Constraint []Constraint `xml:"http://www.opengis.net/ows/1.1 Constraint" yaml:"constraint,omitempty"`
+}
+
+// Constraint struct for the WMTS 1.0.0
+type Constraint struct {
+	Name          string        `xml:"name,attr" yaml:"name"`
+	AllowedValues AllowedValues `xml:"http://www.opengis.net/ows/1.1 AllowedValues" yaml:"allowedvalues"`
+}
+
+// AllowedValues of a Constraint
+type AllowedValues struct {
+	Value []string `xml:"http://www.opengis.net/ows/1.1 Value" yaml:"value"`
+}
+
+// Themes organises the Layers in a (nested) hierarchy
+type Themes struct {
+	Theme []Theme `xml:"http://www.opengis.net/wmts/1.0 Theme" yaml:"theme"`
+}
+
+// Theme in struct for repeatability
+type Theme struct {
+	Title      string        `xml:"http://www.opengis.net/ows/1.1 Title,omitempty" yaml:"title,omitempty"`
+	Abstract   string        `xml:"http://www.opengis.net/ows/1.1 Abstract,omitempty" yaml:"abstract,omitempty"`
+	Keywords   *ows.Keywords `xml:"http://www.opengis.net/ows/1.1 Keywords" yaml:"keywords,omitempty"`
+	Identifier string        `xml:"http://www.opengis.net/ows/1.1 Identifier" yaml:"identifier"`
+	Theme      []Theme       `xml:"http://www.opengis.net/wmts/1.0 Theme" yaml:"theme,omitempty"`
+	LayerRef   []string      `xml:"http://www.opengis.net/wmts/1.0 LayerRef" yaml:"layerref,omitempty"`
+}
+
+// validate checks if the Theme and its sub Themes refer to known Layers
+func (t Theme) validate(contents capabilities.Contents) ows.Exceptions {
+	var exceptions ows.Exceptions
+	for _, l := range t.LayerRef {
+		if _, ok := contents.GetLayer(l); !ok {
+			exceptions = append(exceptions, ows.NoApplicableCode(fmt.Sprintf("Theme %s refers to the unknown Layer %s", t.Identifier, l)))
+		}
+	}
+	for _, s := range t.Theme {
+		exceptions = append(exceptions, s.validate(contents)...)
+	}
+	return exceptions
 }
 
 // ServiceMetadataURL in struct for repeatability
 type ServiceMetadataURL struct {
-	Href string `xml:"xlink:href,attr" yaml:"href"`
+	Href string `xml:"http://www.w3.org/1999/xlink href,attr" yaml:"href"`
 }
@@ -0,0 +1,215 @@
+package response
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+)
+
+var pdokCapabilities = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Capabilities xmlns="http://www.opengis.net/wmts/1.0" xmlns:ows="http://www.opengis.net/ows/1.1" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" version="1.0.0" xsi:schemaLocation="http://www.opengis.net/wmts/1.0 http://schemas.opengis.net/wmts/1.0/wmtsGetCapabilities_response.xsd">
+  <ows:ServiceIdentification>
+    <ows:Title>Web Map Tile Service - PDOK</ows:Title>
+    <ows:Abstract>Service van PDOK</ows:Abstract>
+    <ows:Keywords>
+      <ows:Keyword>tiles</ows:Keyword>
+    </ows:Keywords>
+    <ows:ServiceType>OGC WMTS</ows:ServiceType>
+    <ows:ServiceTypeVersion>1.0.0</ows:ServiceTypeVersion>
+    <ows:Fees>NONE</ows:Fees>
+    <ows:AccessConstraints>NONE</ows:AccessConstraints>
+  </ows:ServiceIdentification>
+  <ows:ServiceProvider>
+    <ows:ProviderName>PDOK</ows:ProviderName>
+    <ows:ProviderSite xlink:href="https://www.pdok.nl"/>
+    <ows:ServiceContact>
+      <ows:IndividualName>KlantContactCenter PDOK</ows:IndividualName>
+      <ows:ContactInfo>
+        <ows:Address>
+          <ows:City>Apeldoorn</ows:City>
+          <ows:Country>Nederland</ows:Country>
+          <ows:ElectronicMailAddress>beheerPDOK@kadaster.nl</ows:ElectronicMailAddress>
+        </ows:Address>
+      </ows:ContactInfo>
+      <ows:Role>pointOfContact</ows:Role>
+    </ows:ServiceContact>
+  </ows:ServiceProvider>
+  <ows:OperationsMetadata>
+    <ows:Operation name="GetCapabilities">
+      <ows:DCP>
+        <ows:HTTP>
+          <ows:Get xlink:href="https://service.pdok.nl/wmts?">
+            <ows:Constraint name="GetEncoding">
+              <ows:AllowedValues>
+                <ows:Value>KVP</ows:Value>
+              </ows:AllowedValues>
+            </ows:Constraint>
+          </ows:Get>
+          <ows:Get xlink:href="https://service.pdok.nl/wmts/1.0.0/WMTSCapabilities.xml">
+            <ows:Constraint name="GetEncoding">
+              <ows:AllowedValues>
+                <ows:Value>RESTful</ows:Value>
+              </ows:AllowedValues>
+            </ows:Constraint>
+          </ows:Get>
+        </ows:HTTP>
+      </ows:DCP>
+    </ows:Operation>
+    <ows:Operation name="GetTile">
+      <ows:DCP>
+        <ows:HTTP>
+          <ows:Get xlink:href="https://service.pdok.nl/wmts?"/>
+        </ows:HTTP>
+      </ows:DCP>
+    </ows:Operation>
+  </ows:OperationsMetadata>
+  <Contents>
+    <Layer>
+      <ows:Title>Luchtfoto</ows:Title>
+      <ows:Identifier>luchtfoto</ows:Identifier>
+      <Style isDefault="true">
+        <ows:Identifier>default</ows:Identifier>
+      </Style>
+      <Format>image/jpeg</Format>
+      <TileMatrixSetLink>
+        <TileMatrixSet>EPSG:28992</TileMatrixSet>
+      </TileMatrixSetLink>
+    </Layer>
+    <TileMatrixSet>
+      <ows:Identifier>EPSG:28992</ows:Identifier>
+      <ows:SupportedCRS>urn:ogc:def:crs:EPSG::28992</ows:SupportedCRS>
+      <TileMatrix>
+        <ows:Identifier>00</ows:Identifier>
+        <ScaleDenominator>12288000</ScaleDenominator>
+        <TopLeftCorner>-285401.92 903401.92</TopLeftCorner>
+        <TileWidth>256</TileWidth>
+        <TileHeight>256</TileHeight>
+        <MatrixWidth>1</MatrixWidth>
+        <MatrixHeight>1</MatrixHeight>
+      </TileMatrix>
+    </TileMatrixSet>
+  </Contents>
+  <Themes>
+    <Theme>
+      <ows:Title>Beelden</ows:Title>
+      <ows:Identifier>beelden</ows:Identifier>
+      <Theme>
+        <ows:Identifier>ortho</ows:Identifier>
+        <LayerRef>luchtfoto</LayerRef>
+      </Theme>
+    </Theme>
+  </Themes>
+  <ServiceMetadataURL xlink:href="https://service.pdok.nl/wmts/1.0.0/WMTSCapabilities.xml"/>
+</Capabilities>`)
+
+func TestGetCapabilitiesParseXML(t *testing.T) {
+	var gc GetCapabilities
+	if err := gc.ParseXML(pdokCapabilities); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+
+	if gc.Namespaces.Version != `1.0.0` || gc.ServiceIdentification.Title != `Web Map Tile Service - PDOK` || !reflect.DeepEqual(gc.ServiceIdentification.Keywords, &ows.Keywords{Keyword: []string{`tiles`}}) {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 1, `Web Map Tile Service - PDOK`, gc.ServiceIdentification)
+	}
+	if gc.ServiceProvider == nil || gc.ServiceProvider.ProviderSite.Href != `https://www.pdok.nl` || gc.ServiceProvider.ServiceContact.ContactInfo.Address.City != `Apeldoorn` {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 2, `PDOK`, gc.ServiceProvider)
+	}
+	if gc.OperationsMetadata == nil || len(gc.OperationsMetadata.Operation) != 2 {
+		t.Errorf("test: %d, expected: %d operations,\n got: %+v", 3, 2, gc.OperationsMetadata)
+		return
+	}
+	if layer, ok := gc.Contents.GetLayer(`luchtfoto`); !ok || !layer.HasFormat(`image/jpeg`) {
+		t.Errorf("test: %d, expected: %s,\n got: %+v", 4, `luchtfoto`, gc.Contents)
+	}
+	expected := &Themes{Theme: []Theme{{Title: `Beelden`, Identifier: `beelden`, Theme: []Theme{{Identifier: `ortho`, LayerRef: []string{`luchtfoto`}}}}}}
+	if !reflect.DeepEqual(gc.Themes, expected) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 5, expected, gc.Themes)
+	}
+	if gc.ServiceMetadataURL.Href != `https://service.pdok.nl/wmts/1.0.0/WMTSCapabilities.xml` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 6, `https://service.pdok.nl/wmts/1.0.0/WMTSCapabilities.xml`, gc.ServiceMetadataURL.Href)
+	}
+
+	if err := gc.ParseXML([]byte(`no XML document, just a string`)); err == nil {
+		t.Errorf("test: %d, expected an error,\n got: nil", 7)
+	}
+}
+
+func TestGetCapabilitiesRoundTrip(t *testing.T) {
+	var original GetCapabilities
+	if err := original.ParseXML(pdokCapabilities); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+		return
+	}
+
+	doc := original.BuildXML()
+	for _, element := range []string{`<Capabilities `, `<ows:ServiceProvider>`, `<ows:OperationsMetadata>`, `<ows:Constraint name="GetEncoding">`, `<Themes>`, `<LayerRef>luchtfoto</LayerRef>`} {
+		if !strings.Contains(string(doc), element) {
+			t.Errorf("test: %d, expected: %s,\n got: %s", 1, element, doc)
+		}
+	}
+
+	var result GetCapabilities
+	if err := result.ParseXML(doc); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 2, err.Error())
+		return
+	}
+	if !reflect.DeepEqual(result, original) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 3, original, result)
+	}
+}
+
+func TestOperationGetEncodings(t *testing.T) {
+	var gc GetCapabilities
+	gc.ParseXML(pdokCapabilities)
+
+	var tests = []struct {
+		operation string
+		encodings []string
+		ok        bool
+	}{
+		0: {operation: `GetCapabilities`, encodings: []string{KVP, RESTful}, ok: true},
+		1: {operation: `GetTile`, ok: true},
+		2: {operation: `GetFeatureInfo`},
+	}
+
+	for k, test := range tests {
+		operation, ok := gc.OperationsMetadata.GetOperation(test.operation)
+		if ok != test.ok {
+			t.Errorf("test: %d, expected: %t,\n got: %t", k, test.ok, ok)
+			continue
+		}
+		if encodings := operation.GetEncodings(); !reflect.DeepEqual(encodings, test.encodings) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.encodings, encodings)
+		}
+	}
+}
+
+func TestGetCapabilitiesValidate(t *testing.T) {
+	contents := capabilities.Contents{
+		Layer:         []capabilities.Layer{{Identifier: `luchtfoto`, TileMatrixSetLink: []capabilities.TileMatrixSetLink{{TileMatrixSet: `EPSG:28992`}, {TileMatrixSet: `EPSG:3857`}}}},
+		TileMatrixSet: []capabilities.TileMatrixSet{{Identifier: `EPSG:28992`}},
+	}
+
+	var tests = []struct {
+		capabilities GetCapabilities
+		exceptions   ows.Exceptions
+	}{
+		0: {capabilities: GetCapabilities{Contents: capabilities.Contents{TileMatrixSet: contents.TileMatrixSet}}},
+		1: {capabilities: GetCapabilities{Contents: contents,
+			Themes: &Themes{Theme: []Theme{{Identifier: `beelden`, LayerRef: []string{`luchtfoto`}, Theme: []Theme{{Identifier: `ortho`, LayerRef: []string{`ortho`}}}}}}},
+			exceptions: ows.Exceptions{
+				ows.NoApplicableCode(`Layer luchtfoto is linked to the unknown TileMatrixSet EPSG:3857`),
+				ows.NoApplicableCode(`Theme ortho refers to the unknown Layer ortho`),
+			}},
+	}
+
+	for k, test := range tests {
+		if exceptions := test.capabilities.Validate(); !reflect.DeepEqual(exceptions, test.exceptions) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.exceptions, exceptions)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package tilematrixset
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+)
+
+// Identifiers of the built-in TileMatrixSets
+// Based on OGC Two Dimensional Tile Matrix Set (http://docs.opengeospatial.org/is/17-083r2/17-083r2.html)
+const (
+	WebMercatorQuad        = `WebMercatorQuad`
+	WorldCRS84Quad         = `WorldCRS84Quad`
+	WorldMercatorWGS84Quad = `WorldMercatorWGS84Quad`
+	EuropeanETRS89LAEAQuad = `EuropeanETRS89_LAEAQuad`
+	NetherlandsRDNewQuad   = `NetherlandsRDNewQuad`
+)
+
+// WellKnownScaleSets referenced by the built-in TileMatrixSets
+const (
+	GoogleMapsCompatible = `urn:ogc:def:wkss:OGC:1.0:GoogleMapsCompatible`
+	GoogleCRS84Quad      = `urn:ogc:def:wkss:OGC:1.0:GoogleCRS84Quad`
+	WorldMercatorWGS84   = `urn:ogc:def:wkss:OGC:1.0:WorldMercatorWGS84`
+)
+
+const (
+	webMercatorScale        = 559082264.0287178
+	webMercatorTopLeftCoord = 20037508.3427892
+)
+
+var (
+	mutex    sync.RWMutex
+	registry = map[string]capabilities.TileMatrixSet{}
+)
+
+func init() {
+	for _, t := range []capabilities.TileMatrixSet{
+		Quad(WebMercatorQuad, `urn:ogc:def:crs:EPSG::3857`, GoogleMapsCompatible, ows.Position{-webMercatorTopLeftCoord, webMercatorTopLeftCoord}, webMercatorScale, 1, 1, 25),
+		Quad(WorldCRS84Quad, `urn:ogc:def:crs:OGC:1.3:CRS84`, GoogleCRS84Quad, ows.Position{-180, 90}, 279541132.0143589, 2, 1, 18),
+		Quad(WorldMercatorWGS84Quad, `urn:ogc:def:crs:EPSG::3395`, WorldMercatorWGS84, ows.Position{-webMercatorTopLeftCoord, webMercatorTopLeftCoord}, webMercatorScale, 1, 1, 25),
+		// The TopLeftCorner is in the northing easting axis order of EPSG:3035
+		Quad(EuropeanETRS89LAEAQuad, `urn:ogc:def:crs:EPSG::3035`, ``, ows.Position{5500000, 2000000}, 62779017.857142866, 1, 1, 16),
+		// The Dutch national tiling scheme, as used by PDOK and defined by Geonovum
+		Quad(NetherlandsRDNewQuad, `urn:ogc:def:crs:EPSG::28992`, ``, ows.Position{-285401.92, 903401.92}, 12288000, 1, 1, 17),
+	} {
+		registry[t.Identifier] = t
+	}
+}
+
+// Quad builds a TileMatrixSet of 256x256 pixel tiles where every next TileMatrix halves the ScaleDenominator
+// and doubles the MatrixWidth and MatrixHeight, starting from the given first TileMatrix
+func Quad(identifier, supportedcrs, wellknownscaleset string, topleftcorner ows.Position, scaledenominator float64, matrixwidth, matrixheight, levels int) capabilities.TileMatrixSet {
+	t := capabilities.TileMatrixSet{Identifier: identifier, SupportedCRS: supportedcrs, WellKnownScaleSet: wellknownscaleset}
+	for z := 0; z < levels; z++ {
+		factor := int(math.Pow(2, float64(z)))
+		t.TileMatrix = append(t.TileMatrix, capabilities.TileMatrix{
+			Identifier:       strconv.Itoa(z),
+			ScaleDenominator: scaledenominator / float64(factor),
+			TopLeftCorner:    topleftcorner,
+			TileWidth:        256,
+			TileHeight:       256,
+			MatrixWidth:      matrixwidth * factor,
+			MatrixHeight:     matrixheight * factor,
+		})
+	}
+	return t
+}
+
+// Register adds a TileMatrixSet to the registry, so it can be retrieved by its Identifier
+// An already registered Identifier can't be registered again
+func Register(t capabilities.TileMatrixSet) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := registry[t.Identifier]; ok {
+		return fmt.Errorf("TileMatrixSet %s is already registered", t.Identifier)
+	}
+	registry[t.Identifier] = copyTileMatrixSet(t)
+	return nil
+}
+
+// Get returns the registered TileMatrixSet with the given Identifier
+func Get(identifier string) (capabilities.TileMatrixSet, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	t, ok := registry[identifier]
+	if !ok {
+		return capabilities.TileMatrixSet{}, false
+	}
+	return copyTileMatrixSet(t), true
+}
+
+// GetByWellKnownScaleSet returns the registered TileMatrixSets that reference the given WellKnownScaleSet
+func GetByWellKnownScaleSet(wellknownscaleset string) []capabilities.TileMatrixSet {
+	var tilematrixsets []capabilities.TileMatrixSet
+	for _, identifier := range Identifiers() {
+		if t, _ := Get(identifier); t.WellKnownScaleSet == wellknownscaleset && wellknownscaleset != `` {
+			tilematrixsets = append(tilematrixsets, t)
+		}
+	}
+	return tilematrixsets
+}
+
+// Identifiers returns the sorted Identifiers of the registered TileMatrixSets
+func Identifiers() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	var identifiers []string
+	for identifier := range registry {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+	return identifiers
+}
+
+// copyTileMatrixSet so the TileMatrices in the registry can't be changed by the callers
+func copyTileMatrixSet(t capabilities.TileMatrixSet) capabilities.TileMatrixSet {
+	t.TileMatrix = append([]capabilities.TileMatrix(nil), t.TileMatrix...)
+	return t
+}
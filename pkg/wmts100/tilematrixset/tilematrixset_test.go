@@ -0,0 +1,101 @@
+package tilematrixset
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pdok/ogc-specifications/pkg/ows"
+	"github.com/pdok/ogc-specifications/pkg/wmts100/capabilities"
+)
+
+func TestGet(t *testing.T) {
+	var tests = []struct {
+		identifier   string
+		supportedcrs string
+		levels       int
+		last         capabilities.TileMatrix
+		pixelsize    float64
+	}{
+		0: {identifier: WebMercatorQuad, supportedcrs: `urn:ogc:def:crs:EPSG::3857`, levels: 25, pixelsize: 156543.03392804097,
+			last: capabilities.TileMatrix{Identifier: `24`, ScaleDenominator: 559082264.0287178 / 16777216, TopLeftCorner: ows.Position{-20037508.3427892, 20037508.3427892},
+				TileWidth: 256, TileHeight: 256, MatrixWidth: 16777216, MatrixHeight: 16777216}},
+		1: {identifier: WorldCRS84Quad, supportedcrs: `urn:ogc:def:crs:OGC:1.3:CRS84`, levels: 18, pixelsize: 0.703125,
+			last: capabilities.TileMatrix{Identifier: `17`, ScaleDenominator: 279541132.0143589 / 131072, TopLeftCorner: ows.Position{-180, 90},
+				TileWidth: 256, TileHeight: 256, MatrixWidth: 262144, MatrixHeight: 131072}},
+		2: {identifier: EuropeanETRS89LAEAQuad, supportedcrs: `urn:ogc:def:crs:EPSG::3035`, levels: 16, pixelsize: 17578.125,
+			last: capabilities.TileMatrix{Identifier: `15`, ScaleDenominator: 62779017.857142866 / 32768, TopLeftCorner: ows.Position{5500000, 2000000},
+				TileWidth: 256, TileHeight: 256, MatrixWidth: 32768, MatrixHeight: 32768}},
+		3: {identifier: NetherlandsRDNewQuad, supportedcrs: `urn:ogc:def:crs:EPSG::28992`, levels: 17, pixelsize: 3440.64,
+			last: capabilities.TileMatrix{Identifier: `16`, ScaleDenominator: 187.5, TopLeftCorner: ows.Position{-285401.92, 903401.92},
+				TileWidth: 256, TileHeight: 256, MatrixWidth: 65536, MatrixHeight: 65536}},
+	}
+
+	for k, test := range tests {
+		tms, ok := Get(test.identifier)
+		if !ok {
+			t.Errorf("test: %d, expected: %s,\n got: nothing", k, test.identifier)
+			continue
+		}
+		if tms.SupportedCRS != test.supportedcrs || len(tms.TileMatrix) != test.levels {
+			t.Errorf("test: %d, expected: %s %d,\n got: %s %d", k, test.supportedcrs, test.levels, tms.SupportedCRS, len(tms.TileMatrix))
+			continue
+		}
+		if last := tms.TileMatrix[test.levels-1]; !reflect.DeepEqual(last, test.last) {
+			t.Errorf("test: %d, expected: %+v,\n got: %+v", k, test.last, last)
+		}
+		if pixelsize := tms.PixelSize(tms.TileMatrix[0]); math.Abs(pixelsize-test.pixelsize) > 1e-6 {
+			t.Errorf("test: %d, expected: %f,\n got: %f", k, test.pixelsize, pixelsize)
+		}
+	}
+
+	if _, ok := Get(`unknown`); ok {
+		t.Errorf("test: %d, expected: %t,\n got: %t", 4, false, ok)
+	}
+
+	// the registry can't be changed through a returned TileMatrixSet
+	tms, _ := Get(WebMercatorQuad)
+	tms.TileMatrix[0].Identifier = `changed`
+	if tms, _ := Get(WebMercatorQuad); tms.TileMatrix[0].Identifier != `0` {
+		t.Errorf("test: %d, expected: %s,\n got: %s", 5, `0`, tms.TileMatrix[0].Identifier)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	utm := Quad(`UTM31WGS84Quad`, `urn:ogc:def:crs:EPSG::32631`, ``, ows.Position{-9501965.72931276, 20003931.4586255}, 279072704.500914, 1, 1, 2)
+	if err := Register(utm); err != nil {
+		t.Errorf("test: %d, expected no error,\n got: %s", 0, err.Error())
+	}
+	if err := Register(utm); err == nil || err.Error() != `TileMatrixSet UTM31WGS84Quad is already registered` {
+		t.Errorf("test: %d, expected: an error,\n got: %v", 1, err)
+	}
+	if tms, ok := Get(`UTM31WGS84Quad`); !ok || !reflect.DeepEqual(tms, utm) {
+		t.Errorf("test: %d, expected: %+v,\n got: %+v", 2, utm, tms)
+	}
+
+	expected := []string{EuropeanETRS89LAEAQuad, NetherlandsRDNewQuad, `UTM31WGS84Quad`, WebMercatorQuad, WorldCRS84Quad, WorldMercatorWGS84Quad}
+	if identifiers := Identifiers(); !reflect.DeepEqual(identifiers, expected) {
+		t.Errorf("test: %d, expected: %v,\n got: %v", 3, expected, identifiers)
+	}
+}
+
+func TestGetByWellKnownScaleSet(t *testing.T) {
+	var tests = []struct {
+		wellknownscaleset string
+		identifiers       []string
+	}{
+		0: {wellknownscaleset: GoogleMapsCompatible, identifiers: []string{WebMercatorQuad}},
+		1: {wellknownscaleset: GoogleCRS84Quad, identifiers: []string{WorldCRS84Quad}},
+		2: {wellknownscaleset: ``},
+	}
+
+	for k, test := range tests {
+		var identifiers []string
+		for _, tms := range GetByWellKnownScaleSet(test.wellknownscaleset) {
+			identifiers = append(identifiers, tms.Identifier)
+		}
+		if !reflect.DeepEqual(identifiers, test.identifiers) {
+			t.Errorf("test: %d, expected: %v,\n got: %v", k, test.identifiers, identifiers)
+		}
+	}
+}